@@ -3,9 +3,14 @@ package at
 import (
 	"bufio"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/xlab/at/calls"
 	"github.com/xlab/at/pdu"
@@ -30,6 +35,13 @@ var (
 	ErrWriteFailed     = errors.New("at: write failed")
 	ErrParseReport     = errors.New("at: error while parsing report")
 	ErrUnknownReport   = errors.New("at: got unknown report")
+	ErrNoPrompt        = errors.New("at: no prompt received")
+	ErrSimLocked       = errors.New("at: SIM is locked")
+	ErrNotSupported    = errors.New("at: command is not supported by the device profile")
+	ErrTextTooLong     = errors.New("at: the text doesn't fit into a single message in text mode")
+	ErrDropMessage     = errors.New("at: the message is dropped by the filter")
+	ErrExpired         = errors.New("at: the scheduled message is expired")
+	ErrNoDevice        = errors.New("at: no device, see NewOutbox")
 )
 
 // Encoding is an encoding option to use.
@@ -51,22 +63,85 @@ type Device struct {
 	CommandPort string
 	// CommandPort is the path or name of notification serial port.
 	NotifyPort string
-	// State holds the device state.
-	State *DeviceState
 	// Commands is a profile that provides implementation of Init and the other commands.
 	Commands DeviceProfile
 	// Timeout to override the default timeout (1m)
 	Timeout time.Duration
+	// Retry is the policy of retrying commands failed with a transient error,
+	// DefaultRetryPolicy is used if it's nil.
+	Retry *RetryPolicy
+	// CommandRetry overrides the retry policy for commands that start with
+	// the given prefix, e.g. "AT+CMGS".
+	CommandRetry map[string]*RetryPolicy
+	// SendRetry is the policy of retrying the message submission (AT+CMGS, AT+CMSS),
+	// DefaultSendRetryPolicy is used if it's nil. CommandRetry takes precedence.
+	SendRetry *RetryPolicy
+	// SendLimit limits the rate of the messages sent with SendSMS if not nil.
+	SendLimit *RateLimit
+	// StorageFullPolicy is the action taken when the message storage is full,
+	// see StorageFullPolicies. The incoming messages stop until there is a free slot.
+	StorageFullPolicy Opt
+	// Retention is the policy of deleting the incoming messages from the device memory,
+	// see RetentionPolicies. The messages are deleted right after they were sent over
+	// the IncomingSms channel by default.
+	Retention Opt
+	// Tracker keeps the delivery states of the messages sent with SendSMS if not nil. Every
+	// message sent by the device requests a status report then, regardless of the StatusReportRequest
+	// of SendOptions, and the status report notifications are turned on if they're off.
+	Tracker *DeliveryTracker
+	// DeliverSIMMessages delivers the SIM-specific messages over the IncomingSms channel
+	// like the other ones. They're sent over the SIMMessages channel and left in the device
+	// memory otherwise, see RawSIMMessage.
+	DeliverSIMMessages bool
+	// ReplaceMessages deletes the message kept in the device memory, see Retention, once
+	// the message that supersedes it is received, see sms.Message.Replaces. The modems
+	// usually store the replace short messages as the new ones.
+	ReplaceMessages bool
 
+	// cmdMux serializes the transactions on the command port
+	cmdMux     sync.Mutex
 	cmdPort    *os.File
+	cmdReader  *bufio.Reader
 	notifyPort *os.File
 
 	incomingCallerIDs chan *calls.CallerID
 	messages          chan *sms.Message
+	statusReports     chan *sms.Message
+	simMessages       chan *RawSIMMessage
+	storageFull       chan StringOpt
 	ussd              chan Ussd
 	updated           chan struct{}
 	closed            chan struct{}
 
+	stateMux sync.RWMutex
+	state    *DeviceState
+
+	waitersMux sync.Mutex
+	waiters    []*waiter
+
+	pendingMux sync.Mutex
+	pending    map[*sms.Message]uint16
+	// replaceable are the stored messages of the replace types by their indexes
+	replaceable map[uint16]*sms.Message
+
+	ackDeliveries bool
+	textMode      bool
+
+	notifyMux sync.Mutex
+	// notification config applied by the profile, nil if unknown
+	notifyConfig *NotificationConfig
+	// reference number of the last concatenated message
+	concatRef uint32
+	// header of the report which body is on the next line, e.g. +CMT
+	partialReport string
+
+	limiter rateLimiter
+
+	filtersMux sync.RWMutex
+	filters    []MessageFilter
+
+	callbacks callbacks
+
 	active bool
 }
 
@@ -80,6 +155,24 @@ func (d *Device) IncomingSms() <-chan *sms.Message {
 	return d.messages
 }
 
+// StatusReports fires when an SMS-STATUS-REPORT was received, i.e. the delivery
+// of a message sent with the status report request was confirmed or failed.
+func (d *Device) StatusReports() <-chan *sms.Message {
+	return d.statusReports
+}
+
+// SIMMessages fires when a SIM-specific message was received, unless the device delivers them
+// as the other ones, see Device.DeliverSIMMessages. The messages are dropped if the channel is full.
+func (d *Device) SIMMessages() <-chan *RawSIMMessage {
+	return d.simMessages
+}
+
+// StorageFull fires when the message storage is full, the memory is UnknownStringOpt
+// if it's not reported by the device. See Device.StorageFullPolicy.
+func (d *Device) StorageFull() <-chan StringOpt {
+	return d.storageFull
+}
+
 // UssdReply fires when an Ussd reply was received.
 func (d *Device) UssdReply() <-chan Ussd {
 	return d.ussd
@@ -90,6 +183,37 @@ func (d *Device) StateUpdate() <-chan struct{} {
 	return d.updated
 }
 
+// State returns a snapshot of the device state. It's safe to call
+// concurrently with the Watch routine.
+func (d *Device) State() DeviceState {
+	d.stateMux.RLock()
+	defer d.stateMux.RUnlock()
+	if d.state == nil {
+		return *NewDeviceState()
+	}
+	return d.state.clone()
+}
+
+// UpdateState atomically applies the update function to the device state.
+// The function should report whether the state was changed, in that case
+// the StateUpdate event fires. UpdateState is intended to be used by
+// device profiles.
+func (d *Device) UpdateState(update func(state *DeviceState) (changed bool)) {
+	d.stateMux.Lock()
+	if d.state == nil {
+		d.state = NewDeviceState()
+	}
+	changed := update(d.state)
+	d.stateMux.Unlock()
+	if changed {
+		select {
+		case d.updated <- struct{}{}:
+		default:
+			// there is a pending event already
+		}
+	}
+}
+
 // Closed fires when the connection was closed.
 func (d *Device) Closed() <-chan struct{} {
 	return d.closed
@@ -99,28 +223,28 @@ func (d *Device) Closed() <-chan struct{} {
 // a prompt should be received first (i.e. when sending SMS, the PDU should be
 // entered after the device replied with '>') and then the second part of payload
 // should be sent (the second payload will be sent using Send).
-func (d *Device) sendInteractive(part1, part2 string, prompt byte) (reply string, err error) {
-
-	err = d.withTimeout(func() error {
-		_, err := d.cmdPort.Write([]byte(part1 + Sep))
+func (d *Device) sendInteractive(part1, part2 string) (reply string, err error) {
+	if err = d.sanityCheck(true); err != nil {
+		return
+	}
+	return d.retry(part1, func() (string, error) {
+		// the command and the payload are a single transaction
+		d.cmdMux.Lock()
+		defer d.cmdMux.Unlock()
+		resp, err := d.roundTrip(part1)
 		if err != nil {
-			return err
+			return "", err
 		}
-
-		// finally: send control character to exit interactive mode
-		defer d.cmdPort.Write([]byte{pdu.Esc})
-
-		buf := bufio.NewReader(d.cmdPort)
-		reply, err = buf.ReadString(prompt)
+		if resp.Result != IntermediateResults.Prompt {
+			return "", ErrNoPrompt
+		}
+		reply, err := responseText(d.roundTrip(part2 + Sub))
 		if err != nil {
-			return err
+			// send control character to exit interactive mode
+			d.cmdPort.Write([]byte{pdu.Esc})
 		}
-
-		reply, err = d.Send(part2 + Sub)
-		return err
+		return reply, err
 	})
-
-	return reply, err
 }
 
 // sanityCheck checks whether ports are opened and (if requested) that the initialization
@@ -140,14 +264,115 @@ func (d *Device) sanityCheck(initialized bool) error {
 	return nil
 }
 
+// Response represents a parsed reply of the device to a command.
+type Response struct {
+	// Lines contain the information text of the reply, result codes are not included.
+	Lines []string
+	// Result is the result code that terminated the reply.
+	Result StringOpt
+	// Intermediate is set if the reply was terminated by an intermediate result code,
+	// i.e. the device waits for the rest of the input (see IntermediateResults.Prompt) or
+	// it entered the data mode (see IntermediateResults.Connect).
+	Intermediate bool
+}
+
+// Text returns the information text of the reply, multiple lines are joined with '\n'.
+func (r *Response) Text() string {
+	return strings.Join(r.Lines, "\n")
+}
+
 // Send writes a command to the device's command port and parses the output.
 // Result will not contain any FinalReply since they're used to detect error status.
 // Multiple lines will be joined with '\n'.
+//
+// Commands failed with a transient error are retried according to the retry policy,
+// see RetryPolicy.
 func (d *Device) Send(req string) (reply string, err error) {
 	if err = d.sanityCheck(true); err != nil {
 		return
 	}
+	return d.retry(req, func() (string, error) {
+		return d.send(req)
+	})
+}
+
+// Exchange is like Send, but returns the whole response including the result
+// code. Unlike Send, it also returns when an intermediate result code is received,
+// so the caller is able to continue a multi-stage command or to take over the
+// command port in the data mode using DataPort.
+func (d *Device) Exchange(req string) (resp *Response, err error) {
+	if err = d.sanityCheck(true); err != nil {
+		return
+	}
+	_, err = d.retry(req, func() (string, error) {
+		var err error
+		resp, err = d.exchange(req)
+		return "", err
+	})
+	return
+}
+
+// DataPort returns the command port of the device to be used in the data mode,
+// i.e. after the CONNECT intermediate result was received by Exchange.
+// The data that was received after the result code is not lost.
+func (d *Device) DataPort() io.ReadWriter {
+	return struct {
+		io.Reader
+		io.Writer
+	}{d.cmdReader, d.cmdPort}
+}
+
+// send makes a single attempt to send the command, see Send.
+func (d *Device) send(req string) (reply string, err error) {
+	return responseText(d.exchange(req))
+}
+
+// responseText returns the information text of the response to the command,
+// the intermediate result is an error.
+func responseText(resp *Response, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if resp.Intermediate {
+		return "", errors.New("at: unexpected intermediate result: " + resp.Result.Description)
+	}
+	return resp.Text(), nil
+}
+
+// readLine reads a line from the command port. The prompt "> " is returned as a line
+// as well, despite it is not terminated with <CR>.
+func (d *Device) readLine() (string, error) {
+	var line []byte
+	for {
+		b, err := d.cmdReader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case b == '\r':
+			return string(line), nil
+		case b == '\n' && len(line) == 0:
+			continue
+		}
+		line = append(line, b)
+		if len(line) == 2 && line[0] == '>' && line[1] == ' ' {
+			return ">", nil
+		}
+	}
+}
 
+// exchange makes a single attempt to send the command and read the response. The command
+// port is held until the response is read, so the transactions of the different goroutines,
+// e.g. Watch reading the incoming messages while Outbox sends, don't interleave.
+func (d *Device) exchange(req string) (resp *Response, err error) {
+	d.cmdMux.Lock()
+	defer d.cmdMux.Unlock()
+	return d.roundTrip(req)
+}
+
+// roundTrip sends the command and reads the response, the caller must hold cmdMux.
+func (d *Device) roundTrip(req string) (resp *Response, err error) {
+	resp = new(Response)
 	err = d.withTimeout(func() error {
 		_, err := d.cmdPort.Write([]byte(req + Sep))
 		if err != nil {
@@ -155,48 +380,64 @@ func (d *Device) Send(req string) (reply string, err error) {
 		}
 
 		var line string
-		buf := bufio.NewReader(d.cmdPort)
-		if line, err = buf.ReadString('\r'); err != nil {
-			return err
-		}
-		text := strings.TrimSpace(line)
-		if !strings.HasPrefix(req, text) {
-			return err
+		for {
+			if line, err = d.readLine(); err != nil {
+				return err
+			}
+			text := strings.TrimSpace(line)
+			if len(text) > 0 && strings.HasPrefix(req, text) {
+				break
+			}
+			// a stray line that was received before the echo
+			if len(text) > 0 {
+				d.dispatch(text)
+			}
 		}
 
-		var done bool
-		for !done {
-			if line, err = buf.ReadString('\r'); err != nil {
-				break
+		for {
+			if line, err = d.readLine(); err != nil {
+				return err
 			}
 			text := strings.TrimSpace(line)
 			if len(text) < 1 {
 				continue
 			}
-			switch opt := FinalResults.Resolve(text); opt {
+			d.dispatch(text)
+			if opt := IntermediateResults.Resolve(text); opt != UnknownStringOpt {
+				resp.Result = opt
+				resp.Intermediate = true
+				if opt == IntermediateResults.Connect && d.cmdReader.Buffered() > 0 {
+					// the data starts after <CR><LF>
+					if b, _ := d.cmdReader.Peek(1); b[0] == '\n' {
+						d.cmdReader.ReadByte()
+					}
+				}
+				return nil
+			}
+			opt := FinalResults.Resolve(text)
+			if opt == FinalResults.Noop && text != NoopCmd {
+				// e.g. the list of commands reported by AT+CLAC
+				opt = UnknownStringOpt
+			}
+			switch opt {
 			case FinalResults.Ok, FinalResults.Noop:
-				done = true
+				resp.Result = opt
+				return nil
 			case FinalResults.Timeout:
-				err = ErrTimeout
-				done = true
+				resp.Result = opt
+				return ErrTimeout
 			case FinalResults.CmeError, FinalResults.CmsError:
-				err = errors.New(text)
-				done = true
+				resp.Result = opt
+				return newResultError(text, opt)
 			case FinalResults.Error, FinalResults.NotSupported,
 				FinalResults.TooManyParameters, FinalResults.NoCarrier:
-				err = errors.New(opt.Description)
-				done = true
+				resp.Result = opt
+				return errors.New(opt.Description)
 			default:
-				if len(reply) > 0 {
-					reply += "\n"
-				}
-				reply += text
+				resp.Lines = append(resp.Lines, text)
 			}
 		}
-
-		return err
 	})
-
 	return
 }
 
@@ -249,6 +490,7 @@ func (d *Device) Watch() error {
 			if len(text) < 1 {
 				continue
 			}
+			d.dispatch(text)
 			d.handleReport(text) // ignore errors
 		}
 	}
@@ -257,6 +499,16 @@ func (d *Device) Watch() error {
 // handleReport detects and parses a report from the notification port represented
 // as a string. The parsed values may change the inner state or be sent over out channels.
 func (d *Device) handleReport(str string) (err error) {
+	if header := d.partialReport; header != "" {
+		d.partialReport = ""
+		return d.handleReportBody(header, str)
+	}
+	if h, ok := d.Commands.(ReportHandler); ok {
+		var handled bool
+		if handled, err = h.HandleReport(str); handled {
+			return
+		}
+	}
 	report := Reports.Resolve(str)
 	str = strings.TrimSpace(strings.TrimPrefix(str, report.ID))
 	switch report {
@@ -268,24 +520,16 @@ func (d *Device) handleReport(str string) (err error) {
 
 		callerID := report.GetCallerID()
 		d.incomingCallerIDs <- callerID
-	case Reports.Message:
+	case Reports.Message, Reports.StoredReport:
 		var report messageReport
 		if err = report.Parse(str); err != nil {
 			return
 		}
-		var octets []byte
-		octets, err = d.Commands.CMGR(report.Index)
-		if err != nil {
-			return
-		}
-		if err = d.Commands.CMGD(report.Index, DeleteOptions.Index); err != nil {
-			return
-		}
-		var msg sms.Message
-		if _, err = msg.ReadFrom(octets); err != nil {
+		var msg *sms.Message
+		if msg, err = d.readMessage(report.Index); err != nil {
 			return
 		}
-		d.messages <- &msg
+		return d.deliver(msg, report.Index)
 	case Reports.Ussd:
 		var ussd ussdReport
 		if err = ussd.Parse(str); err != nil {
@@ -298,7 +542,7 @@ func (d *Device) handleReport(str string) (err error) {
 				return
 			}
 		} else if ussd.Enc == Encodings.Gsm7Bit {
-			text, err = pdu.Decode7Bit(ussd.Octets)
+			text, err = pdu.Decode7BitUSSD(ussd.Octets)
 			if err != nil {
 				return
 			}
@@ -311,45 +555,62 @@ func (d *Device) handleReport(str string) (err error) {
 		if err = rssi.Parse(str); err != nil {
 			return
 		}
-		if d.State.SignalStrength != int(rssi) {
-			d.State.SignalStrength = int(rssi)
-			d.updated <- struct{}{}
-		}
+		d.UpdateState(func(s *DeviceState) bool {
+			if s.SignalStrength == int(rssi) {
+				return false
+			}
+			s.SignalStrength = int(rssi)
+			return true
+		})
 	case Reports.Mode:
 		var report modeReport
 		if err = report.Parse(str); err != nil {
 			return
 		}
-		var updated bool
-		if d.State.SystemMode != report.Mode {
-			d.State.SystemMode = report.Mode
-			updated = true
-		}
-		if d.State.SystemSubmode != report.Submode {
-			d.State.SystemSubmode = report.Submode
-			updated = true
-		}
-		if updated {
-			d.updated <- struct{}{}
-		}
+		d.UpdateState(func(s *DeviceState) bool {
+			if s.SystemMode == report.Mode && s.SystemSubmode == report.Submode {
+				return false
+			}
+			s.SystemMode = report.Mode
+			s.SystemSubmode = report.Submode
+			return true
+		})
 	case Reports.ServiceState:
 		var report serviceStateReport
 		if err = report.Parse(str); err != nil {
 			return
 		}
-		if d.State.ServiceState != Opt(report) {
-			d.State.ServiceState = Opt(report)
-			d.updated <- struct{}{}
-		}
+		d.UpdateState(func(s *DeviceState) bool {
+			if s.ServiceState == Opt(report) {
+				return false
+			}
+			s.ServiceState = Opt(report)
+			return true
+		})
 	case Reports.SimState:
 		var report simStateReport
 		if err = report.Parse(str); err != nil {
 			return
 		}
-		if d.State.SimState != Opt(report) {
-			d.State.SimState = Opt(report)
-			d.updated <- struct{}{}
+		d.UpdateState(func(s *DeviceState) bool {
+			if s.SimState == Opt(report) {
+				return false
+			}
+			s.SimState = Opt(report)
+			return true
+		})
+	case Reports.Registration:
+		var report registrationReport
+		if err = report.Parse(str); err != nil {
+			return
 		}
+		d.UpdateState(func(s *DeviceState) bool {
+			if s.Registration == Opt(report) {
+				return false
+			}
+			s.Registration = Opt(report)
+			return true
+		})
 	case Reports.BootHandshake:
 		var token bootHandshakeReport
 		if err = token.Parse(str); err != nil {
@@ -358,6 +619,28 @@ func (d *Device) handleReport(str string) (err error) {
 		if err = d.Commands.BOOT(uint64(token)); err != nil {
 			return
 		}
+	case Reports.StatusReport:
+		if d.textMode {
+			// the status report is reported on a single line in text mode
+			var msg *sms.Message
+			if msg, err = parseTextStatusReport(splitFields(str)); err != nil {
+				d.AckDelivery(false)
+				return
+			}
+			return d.incomingDirect(msg)
+		}
+		// the PDU follows on the next line
+		d.partialReport = report.ID + str
+	case Reports.DirectMessage:
+		// the PDU or the text follows on the next line
+		d.partialReport = report.ID + str
+	case Reports.StorageFull:
+		return d.handleStorageFull(MemoryTypes.Resolve(trimField(str)))
+	case Reports.Indicator:
+		fields := splitFields(str)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "smsfull") && fields[1] != "0" {
+			return d.handleStorageFull(UnknownStringOpt)
+		}
 	case Reports.Stin:
 		// ignore. what is this btw?
 	default:
@@ -371,12 +654,103 @@ func (d *Device) handleReport(str string) (err error) {
 	return nil
 }
 
+// handleReportBody handles the reports that consist of the header line
+// and the body line, e.g. +CMT.
+func (d *Device) handleReportBody(header, body string) (err error) {
+	switch Reports.Resolve(header) {
+	case Reports.DirectMessage, Reports.StatusReport:
+		var msg *sms.Message
+		if d.textMode {
+			fields := splitFields(strings.TrimPrefix(header, Reports.DirectMessage.ID))
+			msg, err = parseTextMessage(fields, body)
+		} else {
+			msg, err = decodeMessage(body)
+		}
+		if err != nil {
+			d.AckDelivery(false)
+			return
+		}
+		return d.incomingDirect(msg)
+	}
+	return nil
+}
+
+// incomingDirect delivers the message routed directly to the TE and acknowledges it,
+// the message is acknowledged even if it was dropped by a filter, unless the filter
+// rejected it with RejectError.
+func (d *Device) incomingDirect(msg *sms.Message) error {
+	if d.incomingSIM(msg, 0, false) {
+		return d.AckDelivery(true)
+	}
+	_, reject, filterErr := d.incoming(msg)
+	var err error
+	if reject != nil {
+		err = d.ackDeliveryReport(reject)
+	} else {
+		err = d.AckDelivery(true)
+	}
+	if err != nil {
+		return err
+	}
+	return filterErr
+}
+
+// handleStorageFull reports that the given message storage is full
+// and applies the StorageFullPolicy.
+func (d *Device) handleStorageFull(mem StringOpt) error {
+	d.storageFull <- mem
+	switch d.StorageFullPolicy.ID {
+	case StorageFullPolicies.DeleteRead.ID:
+		return d.Commands.CMGD(1, DeleteOptions.AllReadNotMO)
+	case StorageFullPolicies.SwitchMemory.ID:
+		status, err := d.Commands.StorageStatus()
+		if err != nil {
+			return err
+		}
+		if mem == UnknownStringOpt {
+			mem = status.Receive.Memory
+		}
+		next := MemoryTypes.NvRAM
+		if mem == MemoryTypes.NvRAM {
+			next = MemoryTypes.Sim
+		}
+		return d.Commands.CPMS(status.Read.Memory, status.Write.Memory, next)
+	}
+	return nil
+}
+
+// decodeMessage decodes the hex-encoded PDU.
+func decodeMessage(str string) (*sms.Message, error) {
+	var msg sms.Message
+	if _, err := msg.ReadFromHex(str); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// readMessage reads the message stored at the given index in PDU or text mode.
+func (d *Device) readMessage(index uint16) (*sms.Message, error) {
+	if d.textMode {
+		return d.Commands.CMGRText(index)
+	}
+	slot, err := d.Commands.CMGR(index)
+	if err != nil {
+		return nil, err
+	}
+	var msg sms.Message
+	if _, err = msg.ReadFrom(slot.Payload); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
 // Open is used to open serial ports of the device. This should be used first.
 // The method returns error if open was not succeed, i.e. if device is absent.
 func (d *Device) Open() (err error) {
 	if d.cmdPort, err = os.OpenFile(d.CommandPort, os.O_RDWR, 0); err != nil {
 		return
 	}
+	d.cmdReader = bufio.NewReader(d.cmdPort)
 	if d.NotifyPort != "" && d.NotifyPort != d.CommandPort {
 		if d.notifyPort, err = os.OpenFile(d.NotifyPort, os.O_RDWR, 0); err != nil {
 			d.cmdPort.Close()
@@ -396,9 +770,21 @@ func (d *Device) Init(profile DeviceProfile) error {
 	d.closed = make(chan struct{})
 	d.incomingCallerIDs = make(chan *calls.CallerID, 100)
 	d.messages = make(chan *sms.Message, 100)
+	d.statusReports = make(chan *sms.Message, 100)
+	d.simMessages = make(chan *RawSIMMessage, 100)
+	d.storageFull = make(chan StringOpt, 100)
 	d.ussd = make(chan Ussd, 100)
 	d.updated = make(chan struct{}, 100)
+	d.ackDeliveries = false
+	d.pendingMux.Lock()
+	d.pending = make(map[*sms.Message]uint16)
+	d.replaceable = make(map[uint16]*sms.Message)
+	d.pendingMux.Unlock()
+	d.stateMux.Lock()
+	d.state = NewDeviceState()
+	d.stateMux.Unlock()
 	d.Commands = profile
+	d.startCallbacks()
 	return profile.Init(d)
 }
 
@@ -429,27 +815,538 @@ func (d *Device) SendUSSD(req string) (err error) {
 	return
 }
 
-// SendSMS sends an SMS message with given text to the given address,
-// the encoding and other parameters are default.
-func (d *Device) SendSMS(text string, address sms.PhoneNumber) (err error) {
+// SendOptions represent the optional parameters of an outgoing message.
+type SendOptions struct {
+	// ValidityPeriod is the time the service center keeps trying to deliver
+	// the message, 4 days by default.
+	ValidityPeriod time.Duration
+	// StatusReportRequest requests an SMS-STATUS-REPORT for each sent part. It can't be
+	// turned off if the device has a delivery Tracker, the reports are always requested then.
+	StatusReportRequest bool
+	// Flash sends the message with class 0, such messages are displayed immediately.
+	// The class is set in the chosen encoding, the encodings of the message waiting
+	// indication groups have no class and are rejected with sms.ErrEncodingMismatch.
+	Flash bool
+	// Encoding overrides the encoding of the text, by default GSM 7-bit is used
+	// if the text is encodable with it and UCS2 otherwise. Only the encodings of
+	// the GSM 7-bit and UCS2 alphabets are accepted, the other ones are rejected
+	// with sms.ErrEncodingMismatch, see SendBinary for the 8-bit data. The text
+	// that can't be encoded with GSM 7-bit encoding is rejected with sms.ErrNotEncodable.
+	Encoding *sms.Encoding
+	// Latin1 sends the text in ISO-8859-1 with the 8-bit data encoding for the legacy SMSCs,
+	// see sms.Alphabets.Latin1. The Encoding must be of the 8-bit data alphabet then.
+	// The text that can't be encoded is rejected with sms.ErrNotEncodable, such messages
+	// are not supported in text mode.
+	Latin1 bool
+	// ServiceCenter overrides the SMSC address set in the device.
+	ServiceCenter sms.PhoneNumber
+	// MoreToSend keeps the radio link open after the message, since the caller
+	// is going to send more messages, see AT+CMMS.
+	MoreToSend bool
+}
+
+// SendSMS sends an SMS message with given text to the given address. The text that doesn't
+// fit into a single message is split into parts joined by the concatenation header,
+// the message references of all sent parts are returned.
+func (d *Device) SendSMS(text string, address sms.PhoneNumber) (refs []byte, err error) {
+	return d.SendSMSWithOptions(text, address, SendOptions{})
+}
+
+// SendSMSWithOptions sends an SMS message like SendSMS does using the given options.
+func (d *Device) SendSMSWithOptions(text string, address sms.PhoneNumber,
+	opts SendOptions) (refs []byte, err error) {
+	parts, err := d.textParts(text, address, opts)
+	if err != nil {
+		return
+	}
+	return d.sendTextParts(parts, opts.MoreToSend)
+}
+
+// textParts returns the parts of the text message SendSMSWithOptions sends.
+func (d *Device) textParts(text string, address sms.PhoneNumber, opts SendOptions) ([]sms.Message, error) {
+	vp := opts.ValidityPeriod
+	if vp == 0 {
+		vp = 24 * time.Hour * 4
+	}
 	msg := sms.Message{
-		Text:     text,
+		Text:                 text,
+		Type:                 sms.MessageTypes.Submit,
+		Address:              address,
+		ServiceCenterAddress: opts.ServiceCenter,
+		VPFormat:             sms.ValidityPeriodFormats.Relative,
+		VP:                   sms.ValidityPeriod(vp),
+
+		StatusReportRequest: opts.StatusReportRequest || d.Tracker != nil,
+	}
+
+	msg.Encoding = sms.ChooseEncoding(text)
+	if opts.Latin1 {
+		if d.textMode {
+			return nil, ErrNotSupported
+		}
+		msg.Encoding, msg.Latin1 = sms.Encodings.Data8Bit, true
+	}
+	if opts.Encoding != nil {
+		msg.Encoding = *opts.Encoding
+	}
+	dcs := msg.DCS()
+	switch {
+	case opts.Latin1 && dcs.Alphabet != sms.Alphabets.Latin1:
+		return nil, fmt.Errorf("%w: DCS 0x%02X isn't an 8-bit encoding",
+			sms.ErrEncodingMismatch, byte(msg.Encoding))
+	case !opts.Latin1 && dcs.Alphabet != sms.Alphabets.Gsm7Bit && dcs.Alphabet != sms.Alphabets.UCS2:
+		return nil, fmt.Errorf("%w: DCS 0x%02X isn't a text encoding",
+			sms.ErrEncodingMismatch, byte(msg.Encoding))
+	}
+	if _, invalid := pdu.Check7Bit(text); dcs.Alphabet == sms.Alphabets.Gsm7Bit && invalid >= 0 {
+		r, _ := utf8.DecodeRuneInString(text[invalid:])
+		return nil, fmt.Errorf("%w: %q at offset %d", sms.ErrNotEncodable, r, invalid)
+	}
+	if opts.Flash {
+		dcs.Class = sms.MessageClasses.Class0
+		msg.SetDCS(dcs)
+		if msg.DCS().Class != sms.MessageClasses.Class0 {
+			return nil, fmt.Errorf("%w: DCS 0x%02X has no message class",
+				sms.ErrEncodingMismatch, byte(msg.Encoding))
+		}
+	}
+
+	return d.split(&msg)
+}
+
+// sendTextParts sends the parts of the text message in the current mode.
+func (d *Device) sendTextParts(parts []sms.Message, more bool) (refs []byte, err error) {
+	if parts[0].StatusReportRequest {
+		if err = d.enableStatusReports(); err != nil {
+			return
+		}
+	}
+	if !d.textMode {
+		return d.sendParts(parts, more)
+	}
+	address := parts[0].Address
+	if err = d.waitSendSlot(address); err != nil {
+		return
+	}
+	if more {
+		d.keepLinkOpen()
+	}
+	ref, err := d.sendText(parts)
+	if err != nil {
+		return nil, err
+	}
+	if d.Tracker != nil {
+		d.Tracker.Track(ref, address)
+	}
+	return []byte{ref}, nil
+}
+
+// SendBinary sends the binary payload to the given application port of the given address,
+// e.g. to deliver OTA configuration or messages of a custom machine-to-machine protocol.
+// The payload is sent with 8-bit data encoding and the application port addressing header,
+// a zero source port is replaced with the destination one. The payload that doesn't fit into
+// a single message is split into concatenated parts, the message references are returned.
+// The binary messages are not supported in text mode.
+func (d *Device) SendBinary(data []byte, address sms.PhoneNumber, dstPort, srcPort uint16) (refs []byte, err error) {
+	if d.textMode {
+		return nil, ErrNotSupported
+	}
+	if srcPort == 0 {
+		srcPort = dstPort
+	}
+	msg := sms.Message{
+		Data:     data,
 		Type:     sms.MessageTypes.Submit,
-		Encoding: sms.Encodings.Gsm7Bit,
+		Encoding: sms.Encodings.Data8Bit,
 		Address:  address,
 		VPFormat: sms.ValidityPeriodFormats.Relative,
 		VP:       sms.ValidityPeriod(24 * time.Hour * 4),
+
+		StatusReportRequest:      d.Tracker != nil,
+		UserDataStartsWithHeader: true,
+		UserDataHeader: sms.UserDataHeader{
+			DestinationPort: int(dstPort),
+			SourcePort:      int(srcPort),
+		},
+	}
+	parts, err := d.split(&msg)
+	if err != nil {
+		return
 	}
+	if msg.StatusReportRequest {
+		if err = d.enableStatusReports(); err != nil {
+			return
+		}
+	}
+	return d.sendParts(parts, false)
+}
 
-	if !pdu.Is7BitEncodable(text) {
-		msg.Encoding = sms.Encodings.UCS2
+// SendCommand sends the SMS-COMMAND to the service center, e.g. to delete a previously
+// submitted message or to enquire about its status, the MessageNumber of the command is
+// the reference number returned when the message was sent. Returns the reference number
+// of the command. The commands are not supported in text mode.
+func (d *Device) SendCommand(cmd *sms.Command) (ref byte, err error) {
+	if d.textMode {
+		return 0, ErrNotSupported
 	}
+	length, octets, err := cmd.PDU()
+	if err != nil {
+		return
+	}
+	return d.Commands.CMGC(length, octets)
+}
+
+// split splits the message into the parts that fit into a single PDU each, see sms.Split.
+// The concatenated parts are tagged with the reference number of the device and all parts
+// are validated, so the message is rejected before any of its parts is sent.
+func (d *Device) split(msg *sms.Message) ([]sms.Message, error) {
+	parts, err := sms.Split(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > 1 {
+		tag := int(byte(atomic.AddUint32(&d.concatRef, 1)))
+		for i := range parts {
+			parts[i].UserDataHeader.Tag = tag
+		}
+	}
+	for i := range parts {
+		if err := parts[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+// sendParts sends the message parts in PDU mode. The radio link is kept open between
+// the parts and after the last one if there is more to send.
+func (d *Device) sendParts(parts []sms.Message, more bool) (refs []byte, err error) {
+	n := len(parts)
+	for i := range parts {
+		address := parts[i].Address
+		length, octets, err := parts[i].PDU()
+		if err != nil {
+			return refs, err
+		}
+		if err = d.waitSendSlot(address); err != nil {
+			return refs, err
+		}
+		if i < n-1 || more {
+			d.keepLinkOpen()
+		}
+		ref, err := d.Commands.CMGS(length, octets)
+		if err != nil {
+			return refs, err
+		}
+		refs = append(refs, ref)
+		if d.Tracker != nil {
+			d.Tracker.Track(ref, address)
+		}
+	}
+	return
+}
+
+// keepLinkOpen asks the device to keep the radio link open for the next message.
+func (d *Device) keepLinkOpen() {
+	state := d.State()
+	if state.Supports(`AT+CMMS`) {
+		d.Commands.CMMS(MoreMessagesModes.Once) // optional, the messages are just sent a bit slower
+	}
+}
 
-	n, octets, err := msg.PDU()
+// enableStatusReports turns on the status report notifications if they're off, so the
+// reports of the messages sent with the status report request are routed back.
+// The notifications are left as is if the profile didn't report their configuration.
+func (d *Device) enableStatusReports() error {
+	d.notifyMux.Lock()
+	cfg := d.notifyConfig
+	d.notifyMux.Unlock()
+	if cfg == nil || cfg.StatusReports {
+		return nil
+	}
+	enabled := *cfg
+	enabled.StatusReports = true
+	if err := d.Commands.SetNotifications(enabled); err != nil {
+		return fmt.Errorf("at: unable to turn on status reports: %w", err)
+	}
+	return nil
+}
+
+// SelectRAT selects the radio access technology, see AccessTechnologies.
+// ErrNotSupported is returned if the device profile doesn't implement RATSelector.
+func (d *Device) SelectRAT(tech Opt) error {
+	if s, ok := d.Commands.(RATSelector); ok {
+		return s.SelectRAT(tech)
+	}
+	return ErrNotSupported
+}
+
+// StoredMessage represents a decoded message stored in the device memory.
+type StoredMessage struct {
+	Index uint16
+	// Status is the state of the message in memory, see MessageFlags.
+	Status  Opt
+	Message *sms.Message
+}
+
+// ListMessages returns the messages stored in the memory that match the given filter,
+// see MessageFlags. Unlike FetchInbox the messages are left in the memory as is, though
+// the modem usually marks the unread messages as read once they are listed.
+func (d *Device) ListMessages(flag Opt) (list []StoredMessage, err error) {
+	var slots []MessageSlot
+	if d.textMode {
+		slots, err = d.Commands.CMGLText(flag)
+	} else {
+		slots, err = d.Commands.CMGL(flag)
+	}
 	if err != nil {
 		return
 	}
+	for _, slot := range slots {
+		msg := slot.Message
+		if msg == nil {
+			msg = new(sms.Message)
+			if _, err = msg.ReadFrom(slot.Payload); err != nil {
+				return nil, err
+			}
+		}
+		list = append(list, StoredMessage{Index: slot.Index, Status: slot.Status, Message: msg})
+	}
+	return
+}
+
+// deliver sends the message read from the given memory slot over the IncomingSms
+// channel and applies the retention policy.
+func (d *Device) deliver(msg *sms.Message, index uint16) error {
+	if d.incomingSIM(msg, index, true) {
+		return nil
+	}
+	if d.ReplaceMessages && d.Retention.ID != RetentionPolicies.DeleteImmediately.ID {
+		if err := d.replaceStored(msg, index); err != nil {
+			return err
+		}
+	}
+	switch d.Retention.ID {
+	case RetentionPolicies.DeleteAfterAck.ID:
+		d.pendingMux.Lock()
+		d.pending[msg] = index
+		d.pendingMux.Unlock()
+		delivered, _, err := d.incoming(msg)
+		if delivered || err != nil {
+			return err
+		}
+		// nobody is going to acknowledge the dropped message
+		d.pendingMux.Lock()
+		delete(d.pending, msg)
+		d.pendingMux.Unlock()
+	case RetentionPolicies.Keep.ID:
+		_, _, err := d.incoming(msg)
+		return err
+	default:
+		if _, _, err := d.incoming(msg); err != nil {
+			return err
+		}
+	}
+	if err := d.Commands.CMGD(index, DeleteOptions.Index); err != nil {
+		return fmt.Errorf("at: unable to delete message: %w", err)
+	}
+	return nil
+}
+
+// Ack acknowledges the message received over the IncomingSms or StatusReports channel, the message
+// is deleted from the device memory if the retention policy is DeleteAfterAck.
+// Ack is a no-op for the messages that are not waiting for an acknowledgement.
+// The unacknowledged messages are delivered again after the next Init.
+func (d *Device) Ack(msg *sms.Message) error {
+	d.pendingMux.Lock()
+	index, ok := d.pending[msg]
+	d.pendingMux.Unlock()
+	if !ok {
+		return nil
+	}
+	if err := d.Commands.CMGD(index, DeleteOptions.Index); err != nil {
+		return err
+	}
+	d.pendingMux.Lock()
+	delete(d.pending, msg)
+	delete(d.replaceable, index)
+	d.pendingMux.Unlock()
+	return nil
+}
+
+// replaceStored deletes the stored messages superseded by the message read from the given slot,
+// the message is kept track of if it may be superseded itself. The superseded messages are read
+// again before they're deleted since their slots may have been freed and reused since.
+func (d *Device) replaceStored(msg *sms.Message, index uint16) error {
+	var superseded []uint16
+	d.pendingMux.Lock()
+	delete(d.replaceable, index)
+	if msg.ReplaceType() > 0 {
+		for i, stored := range d.replaceable {
+			if supersedes(msg, stored) {
+				delete(d.replaceable, i)
+				superseded = append(superseded, i)
+			}
+		}
+		d.replaceable[index] = msg
+	}
+	d.pendingMux.Unlock()
+
+	for _, i := range superseded {
+		stored, err := d.readMessage(i)
+		if err != nil || !supersedes(msg, stored) {
+			continue
+		}
+		if err := d.Commands.CMGD(i, DeleteOptions.Index); err != nil {
+			return fmt.Errorf("at: unable to delete replaced message: %w", err)
+		}
+		// nobody is going to acknowledge the replaced message
+		d.pendingMux.Lock()
+		for pending, j := range d.pending {
+			if j == i {
+				delete(d.pending, pending)
+			}
+		}
+		d.pendingMux.Unlock()
+	}
+	return nil
+}
+
+// supersedes checks whether the message replaces the stored one, the parts of the same
+// concatenated message don't replace each other.
+func supersedes(msg, stored *sms.Message) bool {
+	if !msg.Replaces(stored) {
+		return false
+	}
+	udh, other := msg.UserDataHeader, stored.UserDataHeader
+	return !msg.UserDataStartsWithHeader || !stored.UserDataStartsWithHeader || udh.TotalNumber < 2 ||
+		udh.Tag != other.Tag || udh.TotalNumber != other.TotalNumber
+}
+
+// AckDelivery acknowledges the message routed directly to the TE, i.e. reported
+// with +CMT or +CDS, if the phase 2+ messaging service was selected during Init,
+// see InitOptions.AckDeliveries. It's a no-op otherwise.
+func (d *Device) AckDelivery(ok bool) error {
+	if !d.ackDeliveries {
+		return nil
+	}
+	return d.Commands.CNMA(ok)
+}
 
-	_, err = d.Commands.CMGS(n, octets)
+// ackDeliveryReport acknowledges the message routed directly to the TE like AckDelivery,
+// the given SMS-DELIVER-REPORT is sent along, e.g. to tell the service center why
+// the message was rejected. Only the acknowledgement itself is sent in text mode.
+func (d *Device) ackDeliveryReport(report *sms.DeliverReport) error {
+	if !d.ackDeliveries {
+		return nil
+	}
+	if d.textMode {
+		return d.Commands.CNMA(report.Ack())
+	}
+	return d.Commands.CNMAReport(report)
+}
+
+// incoming sends the received message over the IncomingSms channel or over
+// the StatusReports channel if it's a status report, the status reports are
+// passed to the delivery tracker as well. The message is passed through the
+// filters first, delivered is false if it was dropped by a filter, see filter.
+func (d *Device) incoming(msg *sms.Message) (delivered bool, reject *sms.DeliverReport, err error) {
+	if delivered, reject, err = d.filter(msg); !delivered {
+		return
+	}
+	if msg.Type == sms.MessageTypes.StatusReport {
+		if d.Tracker != nil {
+			d.Tracker.HandleReport(msg)
+		}
+		d.statusReports <- msg
+		return
+	}
+	d.messages <- msg
 	return
 }
+
+// RawSIMMessage represents a SIM-specific message, i.e. the one of class 2 or with the SIM
+// Data Download protocol identifier, see sms.Message.SIMSpecific. Such messages are meant
+// for the UICC, e.g. the OTA commands, so they're neither delivered as the user messages
+// nor deleted from the device memory by default.
+type RawSIMMessage struct {
+	// Index is the memory slot the message is stored at if Stored is true,
+	// the message was routed directly to the TE otherwise.
+	Index   uint16
+	Stored  bool
+	Message *sms.Message
+}
+
+// incomingSIM sends the SIM-specific message over the SIMMessages channel, it returns false
+// if the message is not SIM-specific or the device delivers such messages as the other ones.
+func (d *Device) incomingSIM(msg *sms.Message, index uint16, stored bool) bool {
+	if d.DeliverSIMMessages || !msg.SIMSpecific() {
+		return false
+	}
+	select {
+	case d.simMessages <- &RawSIMMessage{Index: index, Stored: stored, Message: msg}:
+	default:
+	}
+	return true
+}
+
+// MessageFilter is called for each incoming message and status report before it's
+// delivered, the filter may modify the message. If the filter returns an error the
+// message is dropped, ErrDropMessage should be returned to drop it deliberately
+// and RejectError to reject the message routed directly to the TE.
+type MessageFilter func(msg *sms.Message) error
+
+// RejectError is returned by a MessageFilter to drop the message and to acknowledge
+// it with the SMS-DELIVER-REPORT that carries the failure cause, i.e. with RP-ERROR,
+// so the service center may try to deliver it later. It applies to the messages routed
+// directly to the TE with the phase 2+ messaging service, see InitOptions.AckDeliveries,
+// the other ones are just dropped.
+type RejectError struct {
+	Report sms.DeliverReport
+}
+
+// Reject returns the RejectError with the given failure cause, e.g.
+// sms.FailureCauses.MemoryCapacityExceeded.
+func Reject(cause sms.FailureCause) error {
+	return &RejectError{Report: sms.DeliverReport{FailureCause: cause}}
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("at: the message is rejected with the failure cause 0x%02X", byte(e.Report.FailureCause))
+}
+
+// Is reports that the rejected message is dropped, i.e. the error matches ErrDropMessage.
+func (e *RejectError) Is(target error) bool {
+	return target == ErrDropMessage
+}
+
+// AddFilter appends the filter to the chain of the incoming message filters,
+// the filters are called in the order they were added.
+func (d *Device) AddFilter(f MessageFilter) {
+	d.filtersMux.Lock()
+	d.filters = append(d.filters, f)
+	d.filtersMux.Unlock()
+}
+
+// filter runs the filters on the message, it returns false if the message was dropped
+// and the report to reject the message with if a filter returned RejectError.
+// The errors except ErrDropMessage and RejectError are returned.
+func (d *Device) filter(msg *sms.Message) (bool, *sms.DeliverReport, error) {
+	d.filtersMux.RLock()
+	filters := d.filters
+	d.filtersMux.RUnlock()
+	for _, f := range filters {
+		if err := f(msg); err != nil {
+			var reject *RejectError
+			if errors.As(err, &reject) {
+				report := reject.Report
+				return false, &report, nil
+			}
+			if errors.Is(err, ErrDropMessage) {
+				return false, nil, nil
+			}
+			return false, nil, err
+		}
+	}
+	return true, nil, nil
+}
@@ -0,0 +1,169 @@
+package at
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+// Test that the state can be read while the reports are being handled.
+func TestStateConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{updated: make(chan struct{}, 1)}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			dev.handleReport("^RSSI: " + strconv.Itoa(i%32))
+			dev.handleReport("^MODE: 5,4")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			state := dev.State()
+			assert.True(t, state.SignalStrength >= 0 && state.SignalStrength < 32)
+		}
+	}()
+	wg.Wait()
+
+	state := dev.State()
+	assert.Equal(t, 3, state.SignalStrength)
+	assert.Equal(t, SystemModes.WCDMA, state.SystemMode)
+	assert.Equal(t, SystemSubmodes.WCDMA, state.SystemSubmode)
+	assert.Len(t, dev.StateUpdate(), 1)
+}
+
+func TestDeviceStateSupports(t *testing.T) {
+	t.Parallel()
+
+	state := NewDeviceState()
+	assert.True(t, state.Supports("AT+CLIP=1"))
+	state.Commands = []string{"AT+CGMI", "+CLIP", "^SYSINFO", "D"}
+	assert.True(t, state.Supports("AT+CLIP=1"))
+	assert.True(t, state.Supports("AT+CGMI"))
+	assert.True(t, state.Supports("at^sysinfo"))
+	assert.True(t, state.Supports("D"))
+	assert.False(t, state.Supports("AT+CREG?"))
+
+	snapshot := state.clone()
+	snapshot.Commands[0] = "+CGMM"
+	assert.Equal(t, "AT+CGMI", state.Commands[0])
+}
+
+func TestDirectMessageReport(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{messages: make(chan *sms.Message, 1)}
+	require.NoError(t, dev.handleReport("+CMT: ,24"))
+	require.NoError(t, dev.handleReport("07919762020033F1040B919762995696F0000041606291401561066379180E8200"))
+	require.Len(t, dev.IncomingSms(), 1)
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "crap Δ", msg.Text)
+
+	require.NoError(t, dev.handleReport(`+CMT: "alpha",24`))
+	assert.Error(t, dev.handleReport("XYZ"))
+	assert.Empty(t, dev.IncomingSms())
+	// the report after the broken one is handled as usual
+	require.NoError(t, dev.handleReport("^RSSI: 12"))
+}
+
+func TestDirectSIMMessage(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{
+		messages:    make(chan *sms.Message, 1),
+		simMessages: make(chan *RawSIMMessage, 1),
+	}
+	// the SIM data download message
+	require.NoError(t, dev.handleReport("+CMT: ,24"))
+	require.NoError(t, dev.handleReport("07919762020033F1040B919762995696F07F0041606291401561066379180E8200"))
+	assert.Empty(t, dev.IncomingSms())
+	require.Len(t, dev.SIMMessages(), 1)
+	raw := <-dev.SIMMessages()
+	assert.False(t, raw.Stored)
+	assert.Equal(t, sms.ProtocolIdentifiers.SimDataDownload, raw.Message.ProtocolIdentifier)
+}
+
+func TestStatusReport(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{statusReports: make(chan *sms.Message, 1)}
+	require.NoError(t, dev.handleReport("+CDS: 27"))
+	require.NoError(t, dev.handleReport("079194710600400706360d91947106000000f122206151457440222061514584400000"))
+	require.Len(t, dev.StatusReports(), 1)
+	report := <-dev.StatusReports()
+	assert.Equal(t, sms.MessageTypes.StatusReport, report.Type)
+	assert.EqualValues(t, 0x36, report.MessageReference)
+}
+
+func TestSupportedValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, [][]int{{0, 1, 2}, {0, 2}, {1}, nil},
+		supportedValues(" (0-2),(0,2),(1),()"))
+}
+
+func TestStorageStatus(t *testing.T) {
+	t.Parallel()
+
+	var status StorageStatus
+	require.NoError(t, status.Parse(`"SM",20,20,"ME",3,50,"SM",20,20`))
+	assert.Equal(t, MemoryUsage{MemoryTypes.Sim, 20, 20}, status.Read)
+	assert.Equal(t, MemoryUsage{MemoryTypes.NvRAM, 3, 50}, status.Write)
+	assert.True(t, status.Receive.Full())
+	assert.Equal(t, 47, status.Write.Free())
+
+	status = StorageStatus{}
+	require.NoError(t, status.Parse(`"SM",1,20,"SM",1,20`))
+	assert.Equal(t, MemoryUsage{}, status.Receive)
+	assert.Error(t, status.Parse(`"SM",1`))
+}
+
+func TestStorageFullReport(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{storageFull: make(chan StringOpt, 2)}
+	require.NoError(t, dev.handleReport(`^SMMEMFULL: "SM"`))
+	require.NoError(t, dev.handleReport(`+CIEV: "smsfull",0`))
+	require.NoError(t, dev.handleReport(`+CIEV: "SMSFULL",1`))
+	require.NoError(t, dev.handleReport(`+CIEV: "signal",3`))
+	require.Len(t, dev.StorageFull(), 2)
+	assert.Equal(t, MemoryTypes.Sim, <-dev.StorageFull())
+	assert.Equal(t, UnknownStringOpt, <-dev.StorageFull())
+}
+
+func TestMessageFilters(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{messages: make(chan *sms.Message, 2)}
+	dev.AddFilter(func(msg *sms.Message) error {
+		msg.Text = strings.ToUpper(msg.Text)
+		return nil
+	})
+	var blocked sms.PhoneNumber
+	dev.AddFilter(func(msg *sms.Message) error {
+		if msg.Address == blocked {
+			return ErrDropMessage
+		}
+		return nil
+	})
+	const pdu = "07919762020033F1040B919762995696F0000041606291401561066379180E8200"
+	require.NoError(t, dev.handleReport("+CMT: ,24"))
+	require.NoError(t, dev.handleReport(pdu))
+	require.Len(t, dev.IncomingSms(), 1)
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "CRAP Δ", msg.Text)
+
+	blocked = msg.Address
+	require.NoError(t, dev.handleReport("+CMT: ,24"))
+	require.NoError(t, dev.handleReport(pdu))
+	assert.Empty(t, dev.IncomingSms())
+}
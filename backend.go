@@ -0,0 +1,27 @@
+package at
+
+import "github.com/xlab/at/sms"
+
+// Modem represents the capabilities of a modem that are available regardless of the way
+// it's connected. It's implemented by Device that talks to the serial AT ports and by the
+// alternative backends, e.g. the HTTP API of Huawei HiLink sticks (see the hilink package),
+// so the applications could target any of them with the same code.
+type Modem interface {
+	// SendSMS sends an SMS message with given text to the given address, the message
+	// references are returned if they are known to the backend.
+	SendSMS(text string, address sms.PhoneNumber) (refs []byte, err error)
+	// IncomingSms fires when an SMS was received.
+	IncomingSms() <-chan *sms.Message
+	// State returns a snapshot of the device state.
+	State() DeviceState
+	// StateUpdate fires when the device state was changed.
+	StateUpdate() <-chan struct{}
+	// Watch monitors the device until it's closed.
+	Watch() error
+	// Closed fires when the connection was closed.
+	Closed() <-chan struct{}
+	// Close closes the connection.
+	Close() error
+}
+
+var _ Modem = (*Device)(nil)
@@ -0,0 +1,128 @@
+package at
+
+import (
+	"sync"
+
+	"github.com/xlab/at/calls"
+	"github.com/xlab/at/sms"
+)
+
+// The callbacks are an alternative to the event channels for the simple applications:
+// once a callback is set, the events of its kind are consumed from the channel by
+// the callback routine of the device and passed to the callback instead. The callbacks
+// are called one at a time from the callback routine, so a blocked callback delays the others.
+// The callback routine is started by Init and stops when the device is closed.
+
+// handlers are the callbacks of the device events, nil if not set.
+type handlers struct {
+	sms          func(msg *sms.Message)
+	statusReport func(msg *sms.Message)
+	ussd         func(reply Ussd)
+	call         func(id *calls.CallerID)
+	stateChange  func(state DeviceState)
+}
+
+// callbacks keeps the callbacks of the device.
+type callbacks struct {
+	mux      sync.Mutex
+	handlers handlers
+	// changed fires when a callback was set
+	changed chan struct{}
+}
+
+// OnSMS sets the callback of the received SMS messages, nil restores the IncomingSms channel.
+func (d *Device) OnSMS(fn func(msg *sms.Message)) {
+	d.setHandler(func(h *handlers) { h.sms = fn })
+}
+
+// OnStatusReport sets the callback of the received status reports,
+// nil restores the StatusReports channel.
+func (d *Device) OnStatusReport(fn func(msg *sms.Message)) {
+	d.setHandler(func(h *handlers) { h.statusReport = fn })
+}
+
+// OnUSSD sets the callback of the USSD replies, nil restores the UssdReply channel.
+func (d *Device) OnUSSD(fn func(reply Ussd)) {
+	d.setHandler(func(h *handlers) { h.ussd = fn })
+}
+
+// OnCall sets the callback of the incoming caller IDs, nil restores the IncomingCallerID channel.
+func (d *Device) OnCall(fn func(id *calls.CallerID)) {
+	d.setHandler(func(h *handlers) { h.call = fn })
+}
+
+// OnStateChange sets the callback of the device state updates, the callback gets
+// a snapshot of the updated state. Nil restores the StateUpdate channel.
+func (d *Device) OnStateChange(fn func(state DeviceState)) {
+	d.setHandler(func(h *handlers) { h.stateChange = fn })
+}
+
+func (d *Device) setHandler(set func(h *handlers)) {
+	d.callbacks.mux.Lock()
+	set(&d.callbacks.handlers)
+	changed := d.callbacks.changed
+	d.callbacks.mux.Unlock()
+	select {
+	case changed <- struct{}{}:
+	default:
+		// the callback routine is not running or there is a pending event already
+	}
+}
+
+func (d *Device) getHandlers() handlers {
+	d.callbacks.mux.Lock()
+	defer d.callbacks.mux.Unlock()
+	return d.callbacks.handlers
+}
+
+// startCallbacks starts the callback routine for the event channels created by Init.
+func (d *Device) startCallbacks() {
+	changed := make(chan struct{}, 1)
+	d.callbacks.mux.Lock()
+	d.callbacks.changed = changed
+	d.callbacks.mux.Unlock()
+	go d.runCallbacks(changed, d.closed, d.messages, d.statusReports, d.ussd, d.incomingCallerIDs, d.updated)
+}
+
+// runCallbacks passes the events to the callbacks, the channels without a callback are left as is.
+func (d *Device) runCallbacks(changed, closed <-chan struct{}, messages, statusReports <-chan *sms.Message,
+	ussd <-chan Ussd, callerIDs <-chan *calls.CallerID, updated <-chan struct{}) {
+	for {
+		h := d.getHandlers()
+		// receiving from a nil channel blocks forever, so it's never selected
+		var msgCh, reportCh <-chan *sms.Message
+		var ussdCh <-chan Ussd
+		var callCh <-chan *calls.CallerID
+		var stateCh <-chan struct{}
+		if h.sms != nil {
+			msgCh = messages
+		}
+		if h.statusReport != nil {
+			reportCh = statusReports
+		}
+		if h.ussd != nil {
+			ussdCh = ussd
+		}
+		if h.call != nil {
+			callCh = callerIDs
+		}
+		if h.stateChange != nil {
+			stateCh = updated
+		}
+		select {
+		case <-closed:
+			return
+		case <-changed:
+		case msg := <-msgCh:
+			h.sms(msg)
+		case msg := <-reportCh:
+			h.statusReport(msg)
+		case reply := <-ussdCh:
+			h.ussd(reply)
+		case id := <-callCh:
+			h.call(id)
+		case <-stateCh:
+			h.stateChange(d.State())
+		}
+	}
+}
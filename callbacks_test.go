@@ -0,0 +1,80 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/pdu"
+	"github.com/xlab/at/sms"
+)
+
+func TestCallbacks(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=5", "+CMGR: 0,,24", testDeliverPDU, "OK")
+	modem.Reply("AT+CMGD=5,0", "OK")
+
+	messages := make(chan *sms.Message, 1)
+	replies := make(chan Ussd, 1)
+	states := make(chan DeviceState, 10)
+	// the callbacks could be set before Init
+	dev.OnSMS(func(msg *sms.Message) { messages <- msg })
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	dev.OnUSSD(func(reply Ussd) { replies <- reply })
+	dev.OnStateChange(func(state DeviceState) { states <- state })
+
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",5`))
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "crap Δ", msg.Text)
+	case <-time.After(time.Second):
+		t.Fatal("the message was not passed to the callback")
+	}
+
+	require.NoError(t, dev.handleReport(fmt.Sprintf("+CUSD: 0,%02X,%d", pdu.Encode7Bit("hi"), Encodings.Gsm7Bit)))
+	select {
+	case reply := <-replies:
+		assert.Equal(t, Ussd("hi"), reply)
+	case <-time.After(time.Second):
+		t.Fatal("the USSD reply was not passed to the callback")
+	}
+
+	// the spare bits of the 7 characters are zeros instead of <CR>
+	balance := []byte{0xC2, 0x30, 0x3B, 0xEC, 0x1E, 0x97, 0x01}
+	require.NoError(t, dev.handleReport(fmt.Sprintf("+CUSD: 0,%02X,%d", balance, Encodings.Gsm7Bit)))
+	select {
+	case reply := <-replies:
+		assert.Equal(t, Ussd("Balance"), reply)
+	case <-time.After(time.Second):
+		t.Fatal("the USSD reply was not passed to the callback")
+	}
+
+	require.NoError(t, dev.handleReport("^RSSI: 17"))
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case state := <-states:
+				if state.SignalStrength == 17 {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	// the events without a callback are sent over the channels
+	dev.OnUSSD(nil)
+	// the callback routine picks up the change asynchronously
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, dev.handleReport(fmt.Sprintf("+CUSD: 0,%02X,%d", pdu.Encode7Bit("hi"), Encodings.Gsm7Bit)))
+	assert.Len(t, dev.UssdReply(), 1)
+}
@@ -3,7 +3,9 @@ package at
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xlab/at/calls"
 	"github.com/xlab/at/pdu"
@@ -17,96 +19,373 @@ import (
 type DeviceProfile interface {
 	Init(*Device) error
 	CMGS(length int, octets []byte) (byte, error)
+	CMGC(length int, octets []byte) (byte, error)
+	CMGW(length int, octets []byte, flag Opt) (index uint16, err error)
+	CMSS(index uint16) (byte, error)
+	CMMS(mode Opt) (err error)
 	CUSD(reporting Opt, octets []byte, enc Encoding) (err error)
-	CMGR(index uint16) (octets []byte, err error)
+	CMGR(index uint16) (slot *MessageSlot, err error)
 	CMGD(index uint16, option Opt) (err error)
 	CMGL(flag Opt) (octets []MessageSlot, err error)
+	CSMP(fo, vp, pid, dcs int) (err error)
+	CMGSText(address sms.PhoneNumber, text string) (byte, error)
+	CMGRText(index uint16) (msg *sms.Message, err error)
+	CMGLText(flag Opt) (result []MessageSlot, err error)
 	CMGF(text bool) (err error)
 	CLIP(text bool) (err error)
 	CHUP() (err error)
+	CPIN() (state StringOpt, err error)
+	CREG(reporting bool) (err error)
+	CSQ() (rssi int, err error)
 	CNMI(mode, mt, bm, ds, bfr int) (err error)
+	SetNotifications(cfg NotificationConfig) (err error)
+	CSMS(service int) (mt, mo, bm bool, err error)
+	CNMA(ack bool) (err error)
+	CNMAReport(report *sms.DeliverReport) (err error)
 	CPMS(mem1 StringOpt, mem2 StringOpt, mem3 StringOpt) (err error)
+	StorageStatus() (status *StorageStatus, err error)
+	CSCA() (addr sms.PhoneNumber, err error)
+	CSCS() (charset StringOpt, err error)
+	CMEE(mode Opt) (err error)
+	SetCSCS(charset StringOpt) (err error)
+	SetCSCA(addr sms.PhoneNumber) (err error)
 	BOOT(token uint64) (err error)
 	SYSCFG(roaming, cellular bool) (err error)
 	SYSINFO() (info *SystemInfoReport, err error)
 	COPS(auto bool, text bool) (err error)
+	RegistrationState() (state Opt, err error)
 	OperatorName() (str string, err error)
+	Manufacturer() (str string, err error)
 	ModelName() (str string, err error)
 	IMEI() (str string, err error)
 }
 
+// ReportHandler may be implemented by a DeviceProfile in order to handle vendor-specific
+// unsolicited reports. HandleReport is invoked for every report received on the notification
+// port before the default handling, it returns true if the report was handled by the profile.
+type ReportHandler interface {
+	HandleReport(report string) (handled bool, err error)
+}
+
+// RATSelector may be implemented by a DeviceProfile that is able to select the radio
+// access technology, see AccessTechnologies. The vendor-specific modes are mapped
+// onto AccessTechnologies, so the technology could be selected regardless of the modem.
+type RATSelector interface {
+	SelectRAT(tech Opt) error
+	RAT() (tech Opt, err error)
+}
+
 // DeviceE173 returns an instance of DeviceProfile implementation for Huawei E173,
 // it's also the default one.
 func DeviceE173() DeviceProfile {
 	return &DefaultProfile{}
 }
 
+// DefaultReadyTimeout is the default time to wait for the SIM to become ready during Init.
+const DefaultReadyTimeout = 30 * time.Second
+
+// readyPollInterval is the delay between readiness probes during Init.
+var readyPollInterval = time.Second
+
 // DefaultProfile is a reference implementation that could be embedded
 // in any other custom implementation of the DeviceProfile interface.
 type DefaultProfile struct {
 	dev *Device
 	DeviceProfile
+
+	// ReadyTimeout limits the time to wait for the SIM to become ready during Init,
+	// DefaultReadyTimeout is used if it's zero.
+	ReadyTimeout time.Duration
+	// Options customize the steps of Init.
+	Options InitOptions
+
+	// notifyMode is the preferred mode of AT+CNMI.
+	notifyMode int
+}
+
+// InitOptions customize the initial setup of the modem, the zero value
+// keeps the default steps of the profile.
+type InitOptions struct {
+	// SkipOperatorName disables reading of the operator's name.
+	SkipOperatorName bool
+	// SkipCLIP leaves the caller ID reports disabled.
+	SkipCLIP bool
+	// KeepStoredMessages leaves the messages that are stored in the memory as is,
+	// otherwise they are fetched and deleted.
+	KeepStoredMessages bool
+	// Notifications overrides the profile's configuration of the new message notifications.
+	Notifications *NotificationConfig
+	// CNMI overrides the profile's parameters of the new message notifications,
+	// it takes precedence over Notifications.
+	//
+	// Deprecated: use Notifications, the parameters are sent as is, so they're neither
+	// validated against AT+CNMI=? nor adapted to the device.
+	CNMI *CNMIParams
+	// AckDeliveries selects the phase 2+ messaging service (AT+CSMS=1), so the messages
+	// routed directly to the TE must be acknowledged with AT+CNMA, see Device.AckDelivery.
+	// Otherwise the network retransmits them or the modem buffers them forever.
+	AckDeliveries bool
+	// Storage overrides the profile's message storages for reading, writing and
+	// receiving, the missing ones are set to the last given storage.
+	Storage []StringOpt
+	// ServiceCenter is the SMSC address that is set if the SIM has none configured.
+	ServiceCenter sms.PhoneNumber
+	// VerboseErrors selects the verbose error reporting (AT+CMEE=2), the numeric
+	// one is selected otherwise. The verbose causes are mapped onto CmeError codes.
+	VerboseErrors bool
+	// TextMode selects the text mode (AT+CMGF=1) for the modules that don't support
+	// the PDU mode. Only single-part messages could be sent in text mode.
+	TextMode bool
+}
+
+// NotificationConfig represents the routing of the incoming messages and reports,
+// it's translated by the profile into the parameters of AT+CNMI, see SetNotifications.
+// The zero value stores the messages in the memory, status reports and cell broadcast
+// messages are disabled.
+type NotificationConfig struct {
+	// DeliverTo selects the route of the incoming messages, see DeliveryTargets.
+	DeliverTo Opt
+	// StatusReports enables the status reports, they follow the route of the messages.
+	StatusReports bool
+	// CellBroadcast enables the cell broadcast messages routed directly to the TE.
+	CellBroadcast bool
+	// BufferFlush selects what happens to the reports buffered by the modem,
+	// see BufferFlushPolicies.
+	BufferFlush Opt
+}
+
+// CNMIParams represent the parameters of AT+CNMI, see CNMI.
+type CNMIParams struct {
+	Mode, MT, BM, DS, BFR int
 }
 
 // Init invokes a set of methods that will make the initial setup of the modem.
+// Init waits until the SIM is ready, optional steps that are not supported by
+// the device are skipped.
 func (p *DefaultProfile) Init(d *Device) (err error) {
 	p.dev = d
-	p.dev.Send(NoopCmd) // kinda flush
-	if err = p.COPS(true, true); err != nil {
-		return fmt.Errorf("at init: unable to adjust the format of operator's name: %w", err)
+	p.dev.Send(NoopCmd)   // kinda flush
+	p.ProbeCapabilities() // optional, all commands are considered supported otherwise
+	p.errorReporting()    // optional, the errors carry no causes otherwise
+	if p.supports(`AT+COPS`) {
+		p.COPS(true, true) // optional, the numeric format is used otherwise
+	}
+	deadline := time.Now().Add(p.readyTimeout())
+	if err = p.waitSimReady(deadline); err != nil {
+		return fmt.Errorf("at init: SIM is not ready: %w", err)
 	}
 	var info *SystemInfoReport
-	if info, err = p.SYSINFO(); err != nil {
+	if info, err = p.waitSystemInfo(deadline); err != nil {
 		return fmt.Errorf("at init: unable to read system info: %w", err)
 	}
-	p.dev.State = &DeviceState{
+	state := DeviceState{
 		ServiceState:  info.ServiceState,
 		ServiceDomain: info.ServiceDomain,
 		RoamingState:  info.RoamingState,
 		SystemMode:    info.SystemMode,
 		SystemSubmode: info.SystemSubmode,
 		SimState:      info.SimState,
+		Registration:  UnknownOpt,
+	}
+	state.Charset = p.charset() // optional, the strings are reported as is otherwise
+	if !p.Options.SkipOperatorName {
+		// the operator is unknown until the network is found
+		state.OperatorName, _ = p.OperatorName()
 	}
-	if p.dev.State.OperatorName, err = p.OperatorName(); err != nil {
-		return fmt.Errorf("at init: unable to read operator's name: %w", err)
+	state.Manufacturer, _ = p.Manufacturer()
+	if state.ServiceCenter, err = p.serviceCenter(); err != nil {
+		return fmt.Errorf("at init: unable to set service center address: %w", err)
 	}
-	if p.dev.State.ModelName, err = p.ModelName(); err != nil {
+	if state.ModelName, err = p.ModelName(); err != nil {
 		return fmt.Errorf("at init: unable to read modem's model name: %w", err)
 	}
-	if p.dev.State.IMEI, err = p.IMEI(); err != nil {
+	if state.IMEI, err = p.IMEI(); err != nil {
 		return fmt.Errorf("at init: unable to read modem's IMEI code: %w", err)
 	}
-	if err = p.CMGF(false); err != nil {
-		return fmt.Errorf("at init: unable to switch message format to PDU: %w", err)
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		state.Capabilities, state.Commands = s.Capabilities, s.Commands
+		*s = state
+		return true
+	})
+	if err = p.messageFormat(); err != nil {
+		return fmt.Errorf("at init: unable to switch message format: %w", err)
 	}
-	if err = p.CPMS(MemoryTypes.NvRAM, MemoryTypes.NvRAM, MemoryTypes.NvRAM); err != nil {
+	mem := p.storage(MemoryTypes.NvRAM)
+	if err = p.CPMS(mem[0], mem[1], mem[2]); err != nil {
 		return fmt.Errorf("at init: unable to set messages storage: %w", err)
 	}
-	if err = p.CNMI(1, 1, 0, 0, 0); err != nil {
+	if err = p.messageService(); err != nil {
+		return fmt.Errorf("at init: unable to select message service: %w", err)
+	}
+	if p.notifyMode == 0 {
+		p.notifyMode = 1
+	}
+	if err = p.notifications(); err != nil {
 		return fmt.Errorf("at init: unable to turn on message notifications: %w", err)
 	}
-	if err = p.CLIP(true); err != nil {
-		return fmt.Errorf("at init: unable to turn on calling party ID notifications: %w", err)
+	return p.initFinish()
+}
+
+// errorReporting turns on the extended error reporting, so the failures are reported
+// with +CME ERROR that carries the cause rather than with the plain ERROR.
+func (p *DefaultProfile) errorReporting() {
+	if !p.supports(`AT+CMEE`) {
+		return
+	}
+	mode := ErrorReportingModes.Numeric
+	if p.Options.VerboseErrors {
+		mode = ErrorReportingModes.Verbose
 	}
+	p.CMEE(mode)
+}
 
+// initFinish makes the final optional steps of Init.
+func (p *DefaultProfile) initFinish() error {
+	if !p.Options.SkipCLIP && p.supports(`AT+CLIP`) {
+		p.CLIP(true) // optional, no caller ID reports if not supported
+	}
+	if p.Options.KeepStoredMessages {
+		return nil
+	}
 	return p.FetchInbox()
 }
 
+// serviceCenter reads the SMSC address, the one set by the options is written
+// if there is no address configured. The address is optional, so the read errors are ignored.
+func (p *DefaultProfile) serviceCenter() (addr sms.PhoneNumber, err error) {
+	if p.supports(`AT+CSCA`) {
+		addr, _ = p.CSCA()
+	}
+	if len(addr) == 0 && len(p.Options.ServiceCenter) > 0 {
+		if err = p.SetCSCA(p.Options.ServiceCenter); err != nil {
+			return
+		}
+		addr = p.Options.ServiceCenter
+	}
+	return
+}
+
+// storage returns the message storages set by the options or the given default one.
+func (p *DefaultProfile) storage(def StringOpt) (mem [3]StringOpt) {
+	for i := range mem {
+		switch {
+		case i < len(p.Options.Storage):
+			mem[i] = p.Options.Storage[i]
+		case len(p.Options.Storage) > 0:
+			mem[i] = p.Options.Storage[len(p.Options.Storage)-1]
+		default:
+			mem[i] = def
+		}
+	}
+	return
+}
+
+// messageService selects the phase 2+ messaging service if the options require
+// the deliveries to be acknowledged.
+func (p *DefaultProfile) messageService() error {
+	if !p.Options.AckDeliveries {
+		return nil
+	}
+	if _, _, _, err := p.CSMS(1); err != nil {
+		return err
+	}
+	p.dev.ackDeliveries = true
+	return nil
+}
+
+// notifications turns on the new message notifications using the configuration
+// set by the options or the default one.
+func (p *DefaultProfile) notifications() error {
+	if params := p.Options.CNMI; params != nil {
+		return p.CNMI(params.Mode, params.MT, params.BM, params.DS, params.BFR)
+	}
+	var cfg NotificationConfig
+	if p.Options.Notifications != nil {
+		cfg = *p.Options.Notifications
+	}
+	return p.SetNotifications(cfg)
+}
+
+func (p *DefaultProfile) readyTimeout() time.Duration {
+	if p.ReadyTimeout > 0 {
+		return p.ReadyTimeout
+	}
+	return DefaultReadyTimeout
+}
+
+// sleepUntil waits for the next readiness probe, it returns false if the deadline
+// is exceeded or the device was closed.
+func (p *DefaultProfile) sleepUntil(deadline time.Time) bool {
+	if time.Now().Add(readyPollInterval).After(deadline) {
+		return false
+	}
+	select {
+	case <-time.After(readyPollInterval):
+		return true
+	case <-p.dev.Closed():
+		return false
+	}
+}
+
+// waitSimReady probes the SIM state until it's ready or the deadline is exceeded.
+// The SIM that requires a PIN or PUK code is reported immediately.
+func (p *DefaultProfile) waitSimReady(deadline time.Time) error {
+	for {
+		state, err := p.CPIN()
+		if err == nil {
+			switch state {
+			case SimPinStates.Ready:
+				return nil
+			case UnknownStringOpt:
+				err = ErrParseReport
+			default:
+				return fmt.Errorf("%w: %s", ErrSimLocked, state.Description)
+			}
+		}
+		if !p.sleepUntil(deadline) {
+			return err
+		}
+	}
+}
+
+// waitSystemInfo reads the system info until it reports a SIM card, since the modems report
+// no card (255) while the SIM is initializing. Once the deadline is exceeded, the last reply
+// is returned as is, i.e. the error of the failed request or the system info with no card.
+func (p *DefaultProfile) waitSystemInfo(deadline time.Time) (*SystemInfoReport, error) {
+	for {
+		info, err := p.SYSINFO()
+		if err == nil && info.SimState != SimStates.NoCard {
+			return info, nil
+		}
+		if !p.sleepUntil(deadline) {
+			return info, err
+		}
+	}
+}
+
 func (p *DefaultProfile) FetchInbox() error {
-	slots, err := p.CMGL(MessageFlags.Any)
+	var slots []MessageSlot
+	var err error
+	if p.dev.textMode {
+		slots, err = p.CMGLText(MessageFlags.Any)
+	} else {
+		slots, err = p.CMGL(MessageFlags.Any)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to check message inbox: %w", err)
 	}
 
 	for i := range slots {
-		var msg sms.Message
-		if _, err := msg.ReadFrom(slots[i].Payload); err != nil {
-			return fmt.Errorf("error while parsing message inbox: %w", err)
+		msg := slots[i].Message
+		if msg == nil {
+			msg = new(sms.Message)
+			if _, err := msg.ReadFrom(slots[i].Payload); err != nil {
+				return fmt.Errorf("error while parsing message inbox: %w", err)
+			}
 		}
-		if err := p.CMGD(slots[i].Index, DeleteOptions.Index); err != nil {
+		if err := p.dev.deliver(msg, slots[i].Index); err != nil {
 			return fmt.Errorf("error while cleaning message inbox: %w", err)
 		}
-		p.dev.messages <- &msg
 	}
 	return nil
 }
@@ -119,6 +398,18 @@ func (s *signalStrengthReport) Parse(str string) error {
 	return err
 }
 
+type registrationReport Opt
+
+func (r *registrationReport) Parse(str string) error {
+	fields := strings.Split(str, ",")
+	stat, err := parseUint8(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return err
+	}
+	*r = registrationReport(RegistrationStates.Resolve(int(stat)))
+	return nil
+}
+
 type modeReport struct {
 	Mode    Opt
 	Submode Opt
@@ -294,21 +585,52 @@ func (m *messageReport) Parse(str string) (err error) {
 	return
 }
 
-// CMGR sends AT+CMGR with the given index to the device and returns the message contents.
-func (p *DefaultProfile) CMGR(index uint16) (octets []byte, err error) {
+// CMGR sends AT+CMGR with the given index to the device and returns the message contents
+// along with the status, the alpha and the length reported in the header.
+func (p *DefaultProfile) CMGR(index uint16) (slot *MessageSlot, err error) {
 	req := fmt.Sprintf(`AT+CMGR=%d`, index)
 	reply, err := p.dev.Send(req)
 	if err != nil {
 		return
 	}
-	lines := strings.Split(reply, "\n")
-	if len(lines) < 2 {
+	var payload strings.Builder
+	for _, line := range strings.Split(reply, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case len(line) == 0:
+		case strings.HasPrefix(line, `+CMGR: `):
+			// the status reports in SR storage may be reported without the alpha
+			fields := splitFields(strings.TrimPrefix(line, `+CMGR: `))
+			if slot, err = parseSlotFields(fields); err != nil {
+				return nil, err
+			}
+		case slot == nil:
+			return nil, ErrParseReport
+		default:
+			payload.WriteString(line)
+		}
+	}
+	if slot == nil || payload.Len() == 0 {
+		return nil, ErrParseReport
+	}
+	slot.Index = index
+	slot.Alpha = p.decodeString(slot.Alpha)
+	if slot.Payload, err = util.Bytes(payload.String()); err != nil {
 		return nil, ErrParseReport
 	}
-	octets, err = util.Bytes(lines[1])
+	slot.Payload = withSMSC(slot.Payload, slot.Length)
 	return
 }
 
+// withSMSC prepends the empty SMSC address to the PDU of the given TPDU length
+// if it's missing, some devices report the status reports in SR storage so.
+func withSMSC(octets []byte, length int) []byte {
+	if length > 0 && len(octets) == length {
+		return append([]byte{0x00}, octets...)
+	}
+	return octets
+}
+
 // CMGD sends AT+CMGD with the given index and option to the device. Option defines the mode
 // in which messages will be deleted. The default mode is to delete by index.
 func (p *DefaultProfile) CMGD(index uint16, option Opt) (err error) {
@@ -325,6 +647,67 @@ func (p *DefaultProfile) CPMS(mem1 StringOpt, mem2 StringOpt, mem3 StringOpt) (e
 	return
 }
 
+// MemoryUsage represents the number of used and total slots of a message storage.
+type MemoryUsage struct {
+	Memory StringOpt
+	Used   int
+	Total  int
+}
+
+// Free returns the number of free slots.
+func (m MemoryUsage) Free() int {
+	return m.Total - m.Used
+}
+
+// Full checks whether there are no free slots left.
+func (m MemoryUsage) Full() bool {
+	return m.Total > 0 && m.Used >= m.Total
+}
+
+// StorageStatus represents the usage of the message storages selected for reading,
+// writing and receiving, see CPMS. The Receive storage is empty if it's not reported.
+type StorageStatus struct {
+	Read    MemoryUsage
+	Write   MemoryUsage
+	Receive MemoryUsage
+}
+
+// Parse parses the reply of AT+CPMS?, e.g. "SM",3,20,"SM",3,20,"SM",3,20.
+func (s *StorageStatus) Parse(str string) (err error) {
+	fields := splitFields(str)
+	if len(fields) < 6 || len(fields)%3 != 0 {
+		return ErrParseReport
+	}
+	usage := []*MemoryUsage{&s.Read, &s.Write, &s.Receive}
+	for i := 0; i < len(fields) && i/3 < len(usage); i += 3 {
+		u := usage[i/3]
+		u.Memory = MemoryTypes.Resolve(fields[i])
+		if u.Used, err = strconv.Atoi(fields[i+1]); err != nil {
+			return ErrParseReport
+		}
+		if u.Total, err = strconv.Atoi(fields[i+2]); err != nil {
+			return ErrParseReport
+		}
+	}
+	return nil
+}
+
+// StorageStatus sends AT+CPMS? to the device and gets the usage of the selected message storages.
+func (p *DefaultProfile) StorageStatus() (status *StorageStatus, err error) {
+	reply, err := p.dev.Send(`AT+CPMS?`)
+	if err != nil {
+		return
+	}
+	if !strings.HasPrefix(reply, "+CPMS: ") {
+		return nil, ErrParseReport
+	}
+	status = new(StorageStatus)
+	if err = status.Parse(strings.TrimPrefix(reply, "+CPMS: ")); err != nil {
+		return nil, err
+	}
+	return
+}
+
 // CNMI sends AT+CNMI with the given parameters to the device.
 // It's used to adjust the settings of the new message arrival notifications.
 func (p *DefaultProfile) CNMI(mode, mt, bm, ds, bfr int) (err error) {
@@ -333,10 +716,138 @@ func (p *DefaultProfile) CNMI(mode, mt, bm, ds, bfr int) (err error) {
 	return
 }
 
+// CSMS sends AT+CSMS with the given service to the device. The service 1 (phase 2+)
+// requires the messages routed directly to the TE to be acknowledged, see CNMA.
+// Returns the support of mobile terminated, mobile originated and broadcast messages.
+func (p *DefaultProfile) CSMS(service int) (mt, mo, bm bool, err error) {
+	req := fmt.Sprintf(`AT+CSMS=%d`, service)
+	reply, err := p.dev.Send(req)
+	if err != nil {
+		return
+	}
+	fields := strings.Split(strings.TrimSpace(strings.TrimPrefix(reply, `+CSMS:`)), ",")
+	if len(fields) < 3 {
+		return false, false, false, ErrParseReport
+	}
+	return fields[0] == "1", fields[1] == "1", strings.TrimSpace(fields[2]) == "1", nil
+}
+
+// CNMA sends AT+CNMA to the device in order to acknowledge the message routed directly
+// to the TE, the positive acknowledgement is sent as RP-ACK and the negative one is
+// sent as RP-ERROR.
+func (p *DefaultProfile) CNMA(ack bool) (err error) {
+	n := 1
+	if !ack {
+		n = 2
+	}
+	req := fmt.Sprintf(`AT+CNMA=%d`, n)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// CNMAReport sends AT+CNMA with the given SMS-DELIVER-REPORT TPDU to the device in PDU mode,
+// the report is sent with RP-ACK or with RP-ERROR if it carries a failure cause.
+func (p *DefaultProfile) CNMAReport(report *sms.DeliverReport) (err error) {
+	octets, err := report.Bytes()
+	if err != nil {
+		return
+	}
+	n := 1
+	if !report.Ack() {
+		n = 2
+	}
+	part1 := fmt.Sprintf(`AT+CNMA=%d,%d`, n, len(octets))
+	part2 := fmt.Sprintf("%02X", octets)
+	_, err = p.dev.sendInteractive(part1, part2)
+	return
+}
+
+// CNMISupport sends AT+CNMI=? to the device and returns the supported values of the
+// AT+CNMI parameters: mode, mt, bm, ds and bfr.
+func (p *DefaultProfile) CNMISupport() (values [5][]int, err error) {
+	reply, err := p.dev.Send(`AT+CNMI=?`)
+	if err != nil {
+		return
+	}
+	groups := supportedValues(strings.TrimPrefix(reply, `+CNMI:`))
+	if len(groups) < len(values) {
+		return values, ErrParseReport
+	}
+	copy(values[:], groups)
+	return values, nil
+}
+
+// NotificationParams translates the notification config into the parameters of AT+CNMI.
+// The values are validated against the ones reported by AT+CNMI=?, the alternatives
+// are used if possible, e.g. +CDSI instead of +CDS. The values are not validated if
+// AT+CNMI=? is not supported by the device.
+func (p *DefaultProfile) NotificationParams(cfg NotificationConfig) (params CNMIParams, err error) {
+	support, err := p.CNMISupport()
+	known := err == nil
+	choose := func(n int, name string, values ...int) (int, error) {
+		if !known {
+			return values[0], nil
+		}
+		for _, v := range values {
+			for _, s := range support[n] {
+				if v == s {
+					return v, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("%w: %s", ErrNotSupported, name)
+	}
+
+	mode := p.notifyMode
+	if mode == 0 {
+		mode = 2
+	}
+	if params.Mode, err = choose(0, "notification mode", mode, 3-mode); err != nil {
+		return
+	}
+	mt, ds := 1, []int{2, 1}
+	if cfg.DeliverTo == DeliveryTargets.TE {
+		mt, ds = 2, []int{1, 2}
+	}
+	if params.MT, err = choose(1, "message routing", mt); err != nil {
+		return
+	}
+	if cfg.CellBroadcast {
+		if params.BM, err = choose(2, "cell broadcast routing", 2); err != nil {
+			return
+		}
+	}
+	if cfg.StatusReports {
+		if params.DS, err = choose(3, "status reports", ds...); err != nil {
+			return
+		}
+	}
+	if cfg.BufferFlush == BufferFlushPolicies.Clear {
+		params.BFR = 1
+	}
+	return params, nil
+}
+
+// SetNotifications sends AT+CNMI to the device with the parameters translated
+// from the given config, see NotificationParams.
+func (p *DefaultProfile) SetNotifications(cfg NotificationConfig) (err error) {
+	params, err := p.NotificationParams(cfg)
+	if err != nil {
+		return
+	}
+	if err = p.CNMI(params.Mode, params.MT, params.BM, params.DS, params.BFR); err != nil {
+		return
+	}
+	p.dev.notifyMux.Lock()
+	p.dev.notifyConfig = &cfg
+	p.dev.notifyMux.Unlock()
+	return
+}
+
 // CMGF sends AT+CMGF with the given value to the device. It toggles
 // the mode of message handling between PDU and TEXT.
 //
-// Note, that the at package works only in PDU mode.
+// Note, that the at package works in PDU mode unless InitOptions.TextMode is set.
 func (p *DefaultProfile) CMGF(text bool) (err error) {
 	var flag int
 	if text {
@@ -359,6 +870,110 @@ func (p *DefaultProfile) CLIP(text bool) (err error) {
 	return
 }
 
+// CLAC sends AT+CLAC to the device and returns the list of supported commands.
+func (p *DefaultProfile) CLAC() (cmds []string, err error) {
+	reply, err := p.dev.Send(`AT+CLAC`)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(reply, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			cmds = append(cmds, line)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil, ErrParseReport
+	}
+	return cmds, nil
+}
+
+// GCAP sends AT+GCAP to the device and returns the list of its capabilities, e.g. "+CGSM".
+func (p *DefaultProfile) GCAP() (caps []string, err error) {
+	reply, err := p.dev.Send(`AT+GCAP`)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(reply, "\n") {
+		caps = append(caps, splitFields(strings.TrimPrefix(line, `+GCAP:`))...)
+	}
+	return caps, nil
+}
+
+// ProbeCapabilities queries the supported commands and the capabilities of the device
+// and stores them in the device state, see DeviceState.Supports. The lists are left
+// unknown if the device doesn't support the queries.
+func (p *DefaultProfile) ProbeCapabilities() error {
+	cmds, err := p.CLAC()
+	caps, _ := p.GCAP()
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		s.Commands = cmds
+		s.Capabilities = caps
+		return true
+	})
+	return err
+}
+
+// supports checks whether the command is supported by the device, see ProbeCapabilities.
+func (p *DefaultProfile) supports(cmd string) bool {
+	state := p.dev.State()
+	return state.Supports(cmd)
+}
+
+// CPIN sends AT+CPIN? to the device and returns the state of the SIM,
+// see SimPinStates for the list of possible states.
+func (p *DefaultProfile) CPIN() (state StringOpt, err error) {
+	reply, err := p.dev.Send(`AT+CPIN?`)
+	if err != nil {
+		return UnknownStringOpt, err
+	}
+	return SimPinStates.Resolve(strings.TrimSpace(strings.TrimPrefix(reply, `+CPIN:`))), nil
+}
+
+// CREG sends AT+CREG with the given value to the device. It toggles
+// the network registration reports.
+func (p *DefaultProfile) CREG(reporting bool) (err error) {
+	var flag int
+	if reporting {
+		flag = 1
+	}
+	req := fmt.Sprintf(`AT+CREG=%d`, flag)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// RegistrationState sends AT+CREG? to the device and returns the network registration state,
+// see RegistrationStates for the list of possible states.
+func (p *DefaultProfile) RegistrationState() (state Opt, err error) {
+	reply, err := p.dev.Send(`AT+CREG?`)
+	if err != nil {
+		return UnknownOpt, err
+	}
+	fields := strings.Split(strings.TrimSpace(strings.TrimPrefix(reply, `+CREG:`)), ",")
+	if len(fields) < 2 {
+		return UnknownOpt, ErrParseReport
+	}
+	var report registrationReport
+	if err = report.Parse(strings.Join(fields[1:], ",")); err != nil {
+		return UnknownOpt, err
+	}
+	return Opt(report), nil
+}
+
+// CSQ sends AT+CSQ to the device and returns the received signal strength indication.
+// The value is in range from 0 to 31, or 99 if unknown.
+func (p *DefaultProfile) CSQ() (rssi int, err error) {
+	reply, err := p.dev.Send(`AT+CSQ`)
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Split(strings.TrimSpace(strings.TrimPrefix(reply, `+CSQ:`)), ",")
+	var report signalStrengthReport
+	if err = report.Parse(fields[0]); err != nil {
+		return 0, err
+	}
+	return int(report), nil
+}
+
 // CHUP sends ATH+CHUP to the device. It hangs up
 // an active incoming call
 func (p *DefaultProfile) CHUP() (err error) {
@@ -368,8 +983,16 @@ func (p *DefaultProfile) CHUP() (err error) {
 }
 
 type MessageSlot struct {
-	Index   uint16
+	Index uint16
+	// Status is the state of the message in memory, see MessageFlags.
+	Status Opt
+	// Alpha is the name of the sender or the recipient from the phonebook, if any.
+	Alpha string
+	// Length is the number of TPDU bytes as it was reported by the device.
+	Length  int
 	Payload []byte
+	// Message is the decoded message in text mode, the Payload is empty then.
+	Message *sms.Message
 }
 
 // CMGL sends AT+CMGL with the given filtering flag to the device and then parses
@@ -381,34 +1004,88 @@ func (p *DefaultProfile) CMGL(flag Opt) (result []MessageSlot, err error) {
 	if err != nil {
 		return
 	}
-	lines := strings.Split(reply, "\n")
-	if len(lines) < 2 {
-		return
-	}
 
-	for i := 0; i < len(lines); i += 2 {
-		header := strings.TrimPrefix(lines[i], `+CMGL: `)
-		fields := strings.Split(header, ",")
-		if len(fields) < 4 {
-			return nil, ErrParseReport
+	var slot *MessageSlot
+	var payload strings.Builder
+	flush := func() error {
+		if slot == nil {
+			return nil
 		}
-		n, err := parseUint16(fields[0])
+		oct, err := util.Bytes(payload.String())
 		if err != nil {
-			return nil, ErrParseReport
+			return ErrParseReport
 		}
-		var oct []byte
-		if oct, err = util.Bytes(lines[i+1]); err != nil {
-			return nil, ErrParseReport
+		slot.Payload = withSMSC(oct, slot.Length)
+		slot.Alpha = p.decodeString(slot.Alpha)
+		result = append(result, *slot)
+		payload.Reset()
+		return nil
+	}
+	for _, line := range strings.Split(reply, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
 		}
-
-		result = append(result, MessageSlot{
-			Index:   n,
-			Payload: oct,
-		})
+		if !strings.HasPrefix(line, `+CMGL: `) {
+			if slot == nil {
+				return nil, ErrParseReport
+			}
+			// some devices wrap the long PDUs across lines
+			payload.WriteString(line)
+			continue
+		}
+		if err = flush(); err != nil {
+			return nil, err
+		}
+		if slot, err = parseMessageSlot(strings.TrimPrefix(line, `+CMGL: `)); err != nil {
+			return nil, err
+		}
+	}
+	if err = flush(); err != nil {
+		return nil, err
 	}
 	return
 }
 
+// parseMessageSlot parses the header of a message listed by AT+CMGL in PDU mode,
+// i.e. <index>,<stat>,[<alpha>],<length>.
+func parseMessageSlot(header string) (*MessageSlot, error) {
+	fields := splitFields(header)
+	if len(fields) < 3 {
+		return nil, ErrParseReport
+	}
+	n, err := parseUint16(fields[0])
+	if err != nil {
+		return nil, ErrParseReport
+	}
+	slot, err := parseSlotFields(fields[1:])
+	if err != nil {
+		return nil, err
+	}
+	slot.Index = n
+	return slot, nil
+}
+
+// parseSlotFields parses the fields of a message header in PDU mode,
+// i.e. <stat>,[<alpha>],<length>. The alpha may be omitted.
+func parseSlotFields(fields []string) (*MessageSlot, error) {
+	if len(fields) < 2 {
+		return nil, ErrParseReport
+	}
+	stat, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, ErrParseReport
+	}
+	slot := &MessageSlot{Status: MessageFlags.Resolve(stat)}
+	if len(fields) > 2 {
+		slot.Alpha = fields[1]
+	}
+	if slot.Length, err = strconv.Atoi(fields[len(fields)-1]); err != nil {
+		return nil, ErrParseReport
+	}
+	return slot, nil
+}
+
 // BOOT sends AT^BOOT with the given token to the device. This completes
 // the handshaking procedure.
 func (p *DefaultProfile) BOOT(token uint64) (err error) {
@@ -423,7 +1100,7 @@ func (p *DefaultProfile) BOOT(token uint64) (err error) {
 func (p *DefaultProfile) CMGS(length int, octets []byte) (byte, error) {
 	part1 := fmt.Sprintf("AT+CMGS=%d", length)
 	part2 := fmt.Sprintf("%02X", octets)
-	reply, err := p.dev.sendInteractive(part1, part2, byte('>'))
+	reply, err := p.dev.sendInteractive(part1, part2)
 
 	if err != nil {
 		return 0, err
@@ -441,6 +1118,71 @@ func (p *DefaultProfile) CMGS(length int, octets []byte) (byte, error) {
 	return byte(number), nil
 }
 
+// CMGC sends AT+CMGC with the given SMS-COMMAND PDU to the device, the length
+// is the number of TPDU octets, see CMGS. Returns the reference number of the command.
+func (p *DefaultProfile) CMGC(length int, octets []byte) (byte, error) {
+	part1 := fmt.Sprintf("AT+CMGC=%d", length)
+	part2 := fmt.Sprintf("%02X", octets)
+	reply, err := p.dev.sendInteractive(part1, part2)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(reply, "+CMGC: ") {
+		return 0, fmt.Errorf("unable to get sequence number of reply '%s'", reply)
+	}
+	number, err := parseUint8(reply[7:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse sequence number of reply '%s': %w", reply, err)
+	}
+	return number, nil
+}
+
+// CMGW sends AT+CMGW with the given parameters to the device. This is used to store
+// a message in the memory using the given PDU data, see CMGS. The flag is the state
+// of the stored message, usually MessageFlags.Unsent. Returns the index of the stored message.
+func (p *DefaultProfile) CMGW(length int, octets []byte, flag Opt) (index uint16, err error) {
+	part1 := fmt.Sprintf("AT+CMGW=%d,%d", length, flag.ID)
+	part2 := fmt.Sprintf("%02X", octets)
+	reply, err := p.dev.sendInteractive(part1, part2)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(reply, "+CMGW: ") {
+		return 0, fmt.Errorf("unable to get index of reply '%s'", reply)
+	}
+	index, err = parseUint16(reply[7:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse index of reply '%s': %w", reply, err)
+	}
+	return index, nil
+}
+
+// CMSS sends AT+CMSS with the given index to the device. This is used to send
+// the message stored in the memory, see CMGW. Returns the reference number of the sent message.
+func (p *DefaultProfile) CMSS(index uint16) (byte, error) {
+	req := fmt.Sprintf("AT+CMSS=%d", index)
+	reply, err := p.dev.Send(req)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(reply, "+CMSS: ") {
+		return 0, fmt.Errorf("unable to get sequence number of reply '%s'", reply)
+	}
+	number, err := parseUint8(reply[7:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse sequence number of reply '%s': %w", reply, err)
+	}
+	return byte(number), nil
+}
+
+// CMMS sends AT+CMMS with the given mode to the device, see MoreMessagesModes. It keeps
+// the radio link open between the consecutive messages, so they are sent faster.
+func (p *DefaultProfile) CMMS(mode Opt) (err error) {
+	req := fmt.Sprintf("AT+CMMS=%d", mode.ID)
+	_, err = p.dev.Send(req)
+	return
+}
+
 // SYSCFG sends AT^SYSCFG with the given parameters to the device.
 // The arguments of this command may vary, so the options are limited to switchng roaming and
 // cellular mode on/off.
@@ -536,14 +1278,127 @@ func (p *DefaultProfile) COPS(auto bool, text bool) (err error) {
 }
 
 // OperatorName sends AT+COPS? to the device and gets the operator's name.
+// The name is empty if the device is not registered in a network.
 func (p *DefaultProfile) OperatorName() (str string, err error) {
 	result, err := p.dev.Send(`AT+COPS?`)
+	if err != nil {
+		return
+	}
 	fields := strings.Split(strings.TrimPrefix(result, `+COPS: `), ",")
-	if len(fields) < 4 {
+	if len(fields) == 1 {
+		return
+	}
+	if len(fields) < 3 {
+		err = ErrParseReport
+		return
+	}
+	str = p.decodeString(strings.TrimLeft(strings.TrimRight(fields[2], `"`), `"`))
+	return
+}
+
+// CSCA sends AT+CSCA? to the device and gets the address of the service center
+// that is used to send messages. The address is empty if it's not configured.
+func (p *DefaultProfile) CSCA() (addr sms.PhoneNumber, err error) {
+	reply, err := p.dev.Send(`AT+CSCA?`)
+	if err != nil {
+		return
+	}
+	if !strings.HasPrefix(reply, `+CSCA: `) {
 		err = ErrParseReport
 		return
 	}
-	str = strings.TrimLeft(strings.TrimRight(fields[2], `"`), `"`)
+	fields := splitFields(strings.TrimPrefix(reply, `+CSCA: `))
+	addr = sms.PhoneNumber(fields[0])
+	return
+}
+
+// SetCSCA sends AT+CSCA with the given address of the service center to the device,
+// the address is stored on the SIM and used to send messages.
+func (p *DefaultProfile) SetCSCA(addr sms.PhoneNumber) (err error) {
+	typ := 129
+	if strings.HasPrefix(string(addr), "+") {
+		typ = 145
+	}
+	if _, err = p.dev.Send(fmt.Sprintf(`AT+CSCA="%s",%d`, addr, typ)); err != nil {
+		return
+	}
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		if s.ServiceCenter == addr {
+			return false
+		}
+		s.ServiceCenter = addr
+		return true
+	})
+	return
+}
+
+// CMEE sends AT+CMEE with the given mode to the device, see ErrorReportingModes.
+func (p *DefaultProfile) CMEE(mode Opt) (err error) {
+	_, err = p.dev.Send(fmt.Sprintf(`AT+CMEE=%d`, mode.ID))
+	return
+}
+
+// CSCS sends AT+CSCS? to the device and gets the selected character set, see Charsets.
+func (p *DefaultProfile) CSCS() (charset StringOpt, err error) {
+	reply, err := p.dev.Send(`AT+CSCS?`)
+	if err != nil {
+		return
+	}
+	if !strings.HasPrefix(reply, `+CSCS: `) {
+		err = ErrParseReport
+		return
+	}
+	charset = Charsets.Resolve(trimField(strings.TrimPrefix(reply, `+CSCS: `)))
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		if s.Charset == charset {
+			return false
+		}
+		s.Charset = charset
+		return true
+	})
+	return
+}
+
+// SetCSCS sends AT+CSCS with the given character set to the device, see Charsets.
+// The strings reported in UCS2 are converted into UTF-8, e.g. the operator's name.
+func (p *DefaultProfile) SetCSCS(charset StringOpt) (err error) {
+	if _, err = p.dev.Send(fmt.Sprintf(`AT+CSCS="%s"`, charset.ID)); err != nil {
+		return
+	}
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		if s.Charset == charset {
+			return false
+		}
+		s.Charset = charset
+		return true
+	})
+	return
+}
+
+// charset reads the selected character set, it's unknown if not supported.
+func (p *DefaultProfile) charset() StringOpt {
+	if !p.supports(`AT+CSCS`) {
+		return UnknownStringOpt
+	}
+	charset, err := p.CSCS()
+	if err != nil {
+		return UnknownStringOpt
+	}
+	return charset
+}
+
+// decodeString converts the string reported by the device
+// in the selected character set into UTF-8.
+func (p *DefaultProfile) decodeString(str string) string {
+	if state := p.dev.State(); state.Charset == Charsets.UCS2 {
+		return decodeText(str)
+	}
+	return str
+}
+
+// Manufacturer sends AT+GMI to the device and gets the modem's manufacturer.
+func (p *DefaultProfile) Manufacturer() (str string, err error) {
+	str, err = p.dev.Send(`AT+GMI`)
 	return
 }
 
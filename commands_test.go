@@ -0,0 +1,132 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replyE173 sets up the fake modem to reply like Huawei E173 does.
+func replyE173(modem *fakeModem) {
+	modem.Reply("AT", "OK")
+	modem.Reply("AT+COPS=0,0", "OK")
+	modem.Reply("AT+CPIN?", "+CPIN: READY", "OK")
+	modem.Reply("AT^SYSINFO", "^SYSINFO:2,3,0,5,1,,4", "OK")
+	modem.Reply("AT+COPS?", `+COPS: 0,0,"MTS",2`, "OK")
+	modem.Reply("AT+GMI", "huawei", "OK")
+	modem.Reply("AT+GMM", "E173", "OK")
+	modem.Reply("AT+GSN", "351111111111111", "OK")
+	modem.Reply("AT+CMGF=0", "OK")
+	modem.Reply(`AT+CPMS="ME","ME","ME"`, "OK")
+	modem.Reply("AT+CNMI=1,1,0,0,0", "OK")
+	modem.Reply("AT+CLIP=1", "OK")
+	modem.Reply("AT+CMGL=4", "OK")
+}
+
+func TestDefaultProfileInit(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyE173(modem)
+	require.NoError(t, dev.Init(DeviceE173()))
+
+	state := dev.State()
+	assert.Equal(t, "MTS", state.OperatorName)
+	assert.Equal(t, "huawei", state.Manufacturer)
+	assert.Equal(t, "E173", state.ModelName)
+	assert.Equal(t, "351111111111111", state.IMEI)
+	assert.Equal(t, ServiceStates.Valid, state.ServiceState)
+	assert.Equal(t, SystemSubmodes.WCDMA, state.SystemSubmode)
+}
+
+func TestDefaultProfileInitWaitsForSim(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	dev.Retry = NoRetry
+	replyE173(modem)
+	modem.ReplySequence("AT+CPIN?", []string{"+CME ERROR: 14"}, []string{"+CPIN: READY", "OK"})
+	modem.Reply("AT+COPS=0,0", "+CME ERROR: 3")
+	modem.Reply("AT+COPS?", "+COPS: 0", "OK")
+	modem.Reply("AT+CLIP=1", "COMMAND NOT SUPPORT")
+	require.NoError(t, dev.Init(DeviceE173()))
+	assert.Empty(t, dev.State().OperatorName)
+}
+
+func TestDefaultProfileInitSimLocked(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyE173(modem)
+	modem.Reply("AT+CPIN?", "+CPIN: SIM PIN", "OK")
+	err := dev.Init(&DefaultProfile{ReadyTimeout: time.Second})
+	assert.ErrorIs(t, err, ErrSimLocked)
+}
+
+func TestCMGL(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	const pdu = "07919762020033F1040B919762995696F0000041606291401561066379180E8200"
+	modem.Reply("AT+CMGL=4",
+		`+CMGL: 3,1,"Mom",24`, pdu[:30], pdu[30:],
+		"+CMGL: 5,0,24", pdu,
+		"OK")
+	slots, err := dev.Commands.CMGL(MessageFlags.Any)
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	assert.Equal(t, MessageSlot{
+		Index:   3,
+		Status:  MessageFlags.Read,
+		Alpha:   "Mom",
+		Length:  24,
+		Payload: slots[1].Payload,
+	}, slots[0])
+	assert.Equal(t, MessageFlags.Unread, slots[1].Status)
+	assert.Len(t, slots[1].Payload, 33)
+
+	modem.Reply("AT+CMGL=0", "OK")
+	slots, err = dev.Commands.CMGL(MessageFlags.Unread)
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+
+	modem.Reply("AT+CMGL=1", pdu, "OK")
+	_, err = dev.Commands.CMGL(MessageFlags.Read)
+	assert.ErrorIs(t, err, ErrParseReport)
+}
+
+func TestCMGR(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	const pdu = "07919762020033F1040B919762995696F0000041606291401561066379180E8200"
+	modem.Reply("AT+CMGR=3", `+CMGR: 1,"Mom",24`, pdu, "OK")
+	slot, err := dev.Commands.CMGR(3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, slot.Index)
+	assert.Equal(t, MessageFlags.Read, slot.Status)
+	assert.Equal(t, "Mom", slot.Alpha)
+	assert.Equal(t, 24, slot.Length)
+	assert.Len(t, slot.Payload, 33)
+
+	// the status report in SR storage without the SMSC address
+	const report = "079194710600400706360d91947106000000f122206151457440222061514584400000"
+	modem.Reply("AT+CMGR=1", "+CMGR: 0,27", report[16:], "OK")
+	slot, err = dev.Commands.CMGR(1)
+	require.NoError(t, err)
+	assert.Equal(t, 27, slot.Length)
+	assert.Equal(t, byte(0x00), slot.Payload[0])
+	assert.Len(t, slot.Payload, 28)
+	msg, err := dev.readMessage(1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0x36, msg.MessageReference)
+
+	modem.Reply("AT+CMGR=2", "+CMGR: 0,,0", "OK")
+	_, err = dev.Commands.CMGR(2)
+	assert.ErrorIs(t, err, ErrParseReport)
+}
@@ -0,0 +1,52 @@
+package at
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xlab/at/sms"
+)
+
+// duplicates remembers the fingerprints of the messages received within the window.
+type duplicates struct {
+	window time.Duration
+	now    func() time.Time
+
+	mux  sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewDuplicateFilter returns a filter that drops the incoming messages that were
+// already received within the given window, since some modems deliver the same message
+// twice, e.g. once reported with +CMTI and once again from the storage after reboot.
+// The messages are identified by their fingerprints, see sms.Message.Fingerprint,
+// the status reports are never dropped. See AddFilter.
+func NewDuplicateFilter(window time.Duration) MessageFilter {
+	d := &duplicates{
+		window: window,
+		now:    time.Now,
+		seen:   make(map[string]time.Time),
+	}
+	return d.filter
+}
+
+func (d *duplicates) filter(msg *sms.Message) error {
+	if msg.Type != sms.MessageTypes.Deliver {
+		return nil
+	}
+	key := msg.Fingerprint()
+
+	now := d.now()
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	for k, t := range d.seen {
+		if now.Sub(t) > d.window {
+			delete(d.seen, k)
+		}
+	}
+	if _, ok := d.seen[key]; ok {
+		return ErrDropMessage
+	}
+	d.seen[key] = now
+	return nil
+}
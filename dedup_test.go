@@ -0,0 +1,43 @@
+package at
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xlab/at/sms"
+)
+
+func TestDuplicateFilter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 5, 18, 12, 0, 0, 0, time.UTC)
+	d := &duplicates{
+		window: time.Hour,
+		now:    func() time.Time { return now },
+		seen:   make(map[string]time.Time),
+	}
+	msg := &sms.Message{
+		Type:              sms.MessageTypes.Deliver,
+		Address:           "+79997654321",
+		ServiceCenterTime: sms.Timestamp(now.Add(-time.Minute)),
+		Text:              "hi",
+	}
+	assert.NoError(t, d.filter(msg))
+	assert.ErrorIs(t, d.filter(msg), ErrDropMessage)
+
+	part := *msg
+	part.UserDataStartsWithHeader = true
+	part.UserDataHeader = sms.UserDataHeader{TotalNumber: 2, Sequence: 2, Tag: 7}
+	assert.NoError(t, d.filter(&part))
+	part.UserDataHeader.Sequence = 1
+	assert.NoError(t, d.filter(&part))
+	assert.ErrorIs(t, d.filter(&part), ErrDropMessage)
+
+	report := &sms.Message{Type: sms.MessageTypes.StatusReport}
+	assert.NoError(t, d.filter(report))
+	assert.NoError(t, d.filter(report))
+
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, d.filter(msg))
+}
@@ -0,0 +1,286 @@
+package at
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceE3372 returns an instance of DeviceProfile implementation for Huawei LTE sticks
+// such as E3372s and E8372 in the stick (non-HiLink) mode.
+func DeviceE3372() DeviceProfile {
+	return &E3372Profile{}
+}
+
+// E3372Profile is a DeviceProfile implementation for Huawei LTE sticks. The LTE-era
+// firmware replaced ^SYSINFO, ^SYSCFG and ^RSSI with ^SYSINFOEX, ^SYSCFGEX and ^HCSQ
+// and doesn't require the ^BOOT handshake, it reports ^SYSSTART on boot instead.
+type E3372Profile struct {
+	GenericProfile
+}
+
+// Init makes the initial setup of the Huawei LTE stick.
+func (p *E3372Profile) Init(d *Device) (err error) {
+	return p.init(d, p)
+}
+
+// BOOT is a no-op, the handshake is not used by the LTE-era firmware.
+func (p *E3372Profile) BOOT(token uint64) error {
+	return nil
+}
+
+// SYSINFO sends AT^SYSINFOEX to the device and parses the output.
+func (p *E3372Profile) SYSINFO() (info *SystemInfoReport, err error) {
+	return p.SYSINFOEX()
+}
+
+// SYSINFOEX sends AT^SYSINFOEX to the device and parses the output.
+func (p *E3372Profile) SYSINFOEX() (info *SystemInfoReport, err error) {
+	reply, err := p.dev.Send(`AT^SYSINFOEX`)
+	if err != nil {
+		return nil, err
+	}
+	var report systemInfoExReport
+	if err = report.Parse(strings.TrimPrefix(reply, `^SYSINFOEX:`)); err != nil {
+		return nil, err
+	}
+	info = (*SystemInfoReport)(&report)
+	return info, nil
+}
+
+// systemInfoExReport is the AT^SYSINFOEX report that looks like
+// 2,3,0,1,,6,"LTE",101,"LTE", the modes are mapped onto SystemModes and SystemSubmodes.
+type systemInfoExReport SystemInfoReport
+
+func (s *systemInfoExReport) Parse(str string) error {
+	fields := strings.Split(str, ",")
+	if len(fields) < 6 {
+		return ErrParseReport
+	}
+	var n [8]int
+	for i, field := range fields {
+		if i >= len(n) {
+			break
+		}
+		field = strings.TrimSpace(field)
+		if i == 4 || i == 6 || len(field) == 0 {
+			// the lock state and the mode name
+			continue
+		}
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return ErrParseReport
+		}
+		n[i] = v
+	}
+	s.ServiceState = ServiceStates.Resolve(n[0])
+	s.ServiceDomain = ServiceDomains.Resolve(n[1])
+	s.RoamingState = RoamingStates.Resolve(n[2])
+	s.SimState = SimStates.Resolve(n[3])
+	s.SystemMode = huaweiSystemModeEx(n[5])
+	s.SystemSubmode = UnknownOpt
+	if len(fields) > 7 {
+		s.SystemSubmode = huaweiSystemSubmodeEx(n[7])
+	}
+	return nil
+}
+
+// huaweiSystemModeEx maps the system mode of AT^SYSINFOEX to SystemModes.
+func huaweiSystemModeEx(n int) Opt {
+	switch n {
+	case 0:
+		return SystemModes.NoService
+	case 1:
+		return SystemModes.GsmGprs
+	case 2:
+		return SystemModes.CDMA
+	case 3:
+		return SystemModes.WCDMA
+	case 4:
+		return SystemModes.SCDMA
+	case 6:
+		return SystemModes.LTE
+	}
+	return UnknownOpt
+}
+
+// huaweiSystemSubmodeEx maps the system submode of AT^SYSINFOEX to SystemSubmodes.
+func huaweiSystemSubmodeEx(n int) Opt {
+	switch n {
+	case 0:
+		return SystemSubmodes.NoService
+	case 1:
+		return SystemSubmodes.GSM
+	case 2:
+		return SystemSubmodes.GPRS
+	case 3:
+		return SystemSubmodes.EDGE
+	case 41:
+		return SystemSubmodes.WCDMA
+	case 42:
+		return SystemSubmodes.HSDPA
+	case 43:
+		return SystemSubmodes.HSUPA
+	case 44:
+		return SystemSubmodes.HsdpaHsupa
+	case 45, 46:
+		return SystemSubmodes.HspaPlus
+	case 61, 62, 63, 64, 65:
+		return SystemSubmodes.SCDMA
+	}
+	return UnknownOpt
+}
+
+// sysCfgExModes maps AccessTechnologies to the acquisition orders of AT^SYSCFGEX.
+var sysCfgExModes = map[Opt]string{
+	AccessTechnologies.Auto:     "00",
+	AccessTechnologies.GSM:      "01",
+	AccessTechnologies.WCDMA:    "02",
+	AccessTechnologies.LTE:      "03",
+	AccessTechnologies.GsmWcdma: "0201",
+	AccessTechnologies.WcdmaLte: "0302",
+	AccessTechnologies.GsmLte:   "0301",
+}
+
+// SYSCFG sends AT^SYSCFGEX with the given parameters to the device,
+// the network mode and the bands are left unchanged.
+func (p *E3372Profile) SYSCFG(roaming, cellular bool) (err error) {
+	var roam int
+	if roaming {
+		roam = 1
+	}
+	var cell int
+	if cellular {
+		cell = 2
+	} else {
+		cell = 1
+	}
+	req := fmt.Sprintf(`AT^SYSCFGEX="99",40000000,%d,%d,40000000,,`, roam, cell)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// SelectRAT sends AT^SYSCFGEX to the device in order to select the radio access technology,
+// see AccessTechnologies. The other settings are left unchanged.
+func (p *E3372Profile) SelectRAT(tech Opt) (err error) {
+	mode, ok := sysCfgExModes[tech]
+	if !ok {
+		return ErrNotSupported
+	}
+	req := fmt.Sprintf(`AT^SYSCFGEX="%s",40000000,2,4,40000000,,`, mode)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// RAT sends AT^SYSCFGEX? to the device and gets the selected radio access technology.
+func (p *E3372Profile) RAT() (tech Opt, err error) {
+	reply, err := p.dev.Send(`AT^SYSCFGEX?`)
+	if err != nil {
+		return UnknownOpt, err
+	}
+	fields := splitFields(strings.TrimPrefix(reply, `^SYSCFGEX:`))
+	for opt, mode := range sysCfgExModes {
+		if mode == fields[0] {
+			return opt, nil
+		}
+	}
+	return UnknownOpt, nil
+}
+
+// HCSQReport represents the signal quality report of Huawei LTE sticks.
+type HCSQReport struct {
+	SystemMode Opt
+	// Values contain the reported values, the first one is RSSI, the others
+	// depend on the system mode, e.g. RSRP, SINR and RSRQ for LTE.
+	Values []int
+}
+
+// Parse scans the ^HCSQ report into a non-nil HCSQReport struct.
+// The report looks like "LTE",46,43,161,26.
+func (r *HCSQReport) Parse(str string) error {
+	fields := splitFields(str)
+	if len(fields) < 1 || len(fields[0]) == 0 {
+		return ErrParseReport
+	}
+	switch fields[0] {
+	case "NOSERVICE":
+		r.SystemMode = SystemModes.NoService
+	case "GSM":
+		r.SystemMode = SystemModes.GsmGprs
+	case "WCDMA":
+		r.SystemMode = SystemModes.WCDMA
+	case "TD-SCDMA":
+		r.SystemMode = SystemModes.SCDMA
+	case "LTE":
+		r.SystemMode = SystemModes.LTE
+	default:
+		r.SystemMode = UnknownOpt
+	}
+	r.Values = r.Values[:0]
+	for _, field := range fields[1:] {
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return ErrParseReport
+		}
+		r.Values = append(r.Values, v)
+	}
+	return nil
+}
+
+// SignalStrength converts the reported RSSI to the scale of AT+CSQ (0-31),
+// the RSSI is reported in the range 0-96 that stands for -120dBm to -25dBm.
+func (r *HCSQReport) SignalStrength() int {
+	if len(r.Values) == 0 || r.Values[0] == 255 {
+		return 0
+	}
+	rssi := (r.Values[0] - 120 + 113) / 2
+	switch {
+	case rssi < 0:
+		return 0
+	case rssi > 31:
+		return 31
+	}
+	return rssi
+}
+
+// HCSQ sends AT^HCSQ? to the device and parses the output.
+func (p *E3372Profile) HCSQ() (report *HCSQReport, err error) {
+	reply, err := p.dev.Send(`AT^HCSQ?`)
+	if err != nil {
+		return nil, err
+	}
+	report = new(HCSQReport)
+	err = report.Parse(strings.TrimPrefix(reply, `^HCSQ:`))
+	return
+}
+
+// CSQ reads the signal strength using AT^HCSQ?, AT+CSQ is used if it fails.
+func (p *E3372Profile) CSQ() (rssi int, err error) {
+	report, err := p.HCSQ()
+	if err != nil {
+		return p.GenericProfile.CSQ()
+	}
+	return report.SignalStrength(), nil
+}
+
+// HandleReport handles the ^HCSQ signal quality reports and the ^SYSSTART boot report.
+func (p *E3372Profile) HandleReport(report string) (handled bool, err error) {
+	switch {
+	case report == "^SYSSTART":
+		return true, nil
+	case strings.HasPrefix(report, "^HCSQ:"):
+		var hcsq HCSQReport
+		if err = hcsq.Parse(strings.TrimPrefix(report, "^HCSQ:")); err != nil {
+			return true, err
+		}
+		p.dev.UpdateState(func(s *DeviceState) bool {
+			s.SignalStrength = hcsq.SignalStrength()
+			if hcsq.SystemMode != UnknownOpt {
+				s.SystemMode = hcsq.SystemMode
+			}
+			return true
+		})
+		return true, nil
+	}
+	return false, nil
+}
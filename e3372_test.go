@@ -0,0 +1,46 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestE3372Profile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT^SYSINFOEX", `^SYSINFOEX: 2,3,0,1,,6,"LTE",101,"LTE"`, "OK")
+	modem.Reply("AT^HCSQ?", `^HCSQ: "LTE",63,43,161,26`, "OK")
+	modem.Reply(`AT^SYSCFGEX="03",40000000,2,4,40000000,,`, "OK")
+	require.NoError(t, dev.Init(DeviceE3372()))
+
+	state := dev.State()
+	assert.Equal(t, SystemModes.LTE, state.SystemMode)
+	assert.Equal(t, ServiceStates.Valid, state.ServiceState)
+	assert.Equal(t, SimStates.Valid, state.SimState)
+	assert.Equal(t, 28, state.SignalStrength)
+
+	require.NoError(t, dev.SelectRAT(AccessTechnologies.LTE))
+	require.NoError(t, dev.handleReport("^SYSSTART"))
+	require.NoError(t, dev.handleReport(`^HCSQ: "WCDMA",30,30,58`))
+	state = dev.State()
+	assert.Equal(t, SystemModes.WCDMA, state.SystemMode)
+	assert.Equal(t, 11, state.SignalStrength)
+	assert.NotContains(t, modem.Sent(), "AT^SYSINFO")
+}
+
+func TestDetectHuaweiLTE(t *testing.T) {
+	t.Parallel()
+
+	name, profile := matchProfile("huawei", "E3372")
+	assert.Equal(t, "huawei-lte", name)
+	assert.IsType(t, &E3372Profile{}, profile)
+	name, _ = matchProfile("huawei", "E173")
+	assert.Equal(t, "huawei", name)
+}
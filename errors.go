@@ -0,0 +1,81 @@
+package at
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CmeError represents the +CME ERROR final result, an error related
+// to mobile equipment or network (3GPP TS 27.007).
+type CmeError struct {
+	// Code is the numeric error code, it's -1 if the code is unknown.
+	Code int
+	// Text contains the whole result line as it was reported by the device.
+	Text string
+}
+
+func (e *CmeError) Error() string {
+	return e.Text
+}
+
+// CmsError represents the +CMS ERROR final result, an error related
+// to message service or network (3GPP TS 27.005).
+type CmsError struct {
+	// Code is the numeric error code, it's -1 if the code is unknown.
+	Code int
+	// Text contains the whole result line as it was reported by the device.
+	Text string
+}
+
+func (e *CmsError) Error() string {
+	return e.Text
+}
+
+// verboseCme maps the verbose causes of +CME ERROR onto the numeric codes,
+// see 3GPP TS 27.007, section 9.2.
+var verboseCme = map[string]int{
+	"phone failure":           0,
+	"no connection to phone":  1,
+	"operation not allowed":   3,
+	"operation not supported": 4,
+	"ph-sim pin required":     5,
+	"sim not inserted":        10,
+	"sim pin required":        11,
+	"sim puk required":        12,
+	"sim failure":             13,
+	"sim busy":                14,
+	"sim wrong":               15,
+	"incorrect password":      16,
+	"sim pin2 required":       17,
+	"sim puk2 required":       18,
+	"memory full":             20,
+	"invalid index":           21,
+	"not found":               22,
+	"memory failure":          23,
+	"text string too long":    24,
+	"no network service":      30,
+	"network timeout":         31,
+	"unknown":                 100,
+}
+
+// errorCode extracts the numeric code from the error result line, the verbose
+// causes of +CME ERROR are mapped onto the codes.
+func errorCode(text string, result StringOpt) int {
+	str := strings.TrimSpace(strings.TrimPrefix(text, result.ID))
+	code, err := strconv.Atoi(str)
+	if err == nil {
+		return code
+	}
+	if code, ok := verboseCme[strings.ToLower(str)]; ok && result == FinalResults.CmeError {
+		return code
+	}
+	return -1
+}
+
+// newResultError constructs a typed error from the given +CME ERROR or +CMS ERROR line.
+func newResultError(text string, result StringOpt) error {
+	if result == FinalResults.CmsError {
+		return &CmsError{Code: errorCode(text, result), Text: text}
+	}
+	return &CmeError{Code: errorCode(text, result), Text: text}
+}
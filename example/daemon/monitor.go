@@ -39,8 +39,8 @@ type Monitor struct {
 	checkTimer   *time.Timer
 }
 
-func (m *Monitor) DeviceState() *at.DeviceState {
-	return m.dev.State
+func (m *Monitor) DeviceState() at.DeviceState {
+	return m.dev.State()
 }
 
 func NewMonitor(cmdPort, notifyPort string) *Monitor {
@@ -97,6 +97,12 @@ func (m *Monitor) Run() (err error) {
 					m.dev.Watch()
 					m.stateChanged <- NoDeviceState
 				}()
+				m.dev.OnUSSD(func(ussd at.Ussd) {
+					m.Balance = string(ussd)
+				})
+				m.dev.OnSMS(func(msg *sms.Message) {
+					m.Messages = append(m.Messages, msg)
+				})
 				go func() {
 					m.dev.SendUSSD(BalanceUSSD)
 					t := time.NewTicker(BalanceCheckInterval)
@@ -105,14 +111,6 @@ func (m *Monitor) Run() (err error) {
 						select {
 						case <-m.dev.Closed():
 							return
-						case ussd, ok := <-m.dev.UssdReply():
-							if ok {
-								m.Balance = string(ussd)
-							}
-						case msg, ok := <-m.dev.IncomingSms():
-							if ok {
-								m.Messages = append(m.Messages, msg)
-							}
 						case <-t.C:
 							m.dev.SendUSSD(BalanceUSSD)
 						}
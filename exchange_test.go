@@ -0,0 +1,325 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+func TestExchangeConnect(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("ATD*99#", "CONNECT 150000000", "~data~")
+	resp, err := dev.Exchange("ATD*99#")
+	require.NoError(t, err)
+	assert.True(t, resp.Intermediate)
+	assert.Equal(t, IntermediateResults.Connect, resp.Result)
+
+	data, err := bufio.NewReader(dev.DataPort()).ReadString('~')
+	require.NoError(t, err)
+	assert.Equal(t, "~", data)
+}
+
+func TestExchangeFinal(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CGMI", "huawei", "OK")
+	resp, err := dev.Exchange("AT+CGMI")
+	require.NoError(t, err)
+	assert.False(t, resp.Intermediate)
+	assert.Equal(t, FinalResults.Ok, resp.Result)
+	assert.Equal(t, []string{"huawei"}, resp.Lines)
+
+	_, err = dev.Send("ATD*99#")
+	assert.EqualError(t, err, "Error")
+}
+
+func TestSendInteractive(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CMGS=5", "> ")
+	modem.Reply("0001000000"+Sub, "+CMGS: 7", "OK")
+	ref, err := dev.Commands.CMGS(5, []byte{0x00, 0x01, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, ref)
+
+	modem.Reply("AT+CMGS=6", "+CMS ERROR: 304")
+	_, err = dev.Commands.CMGS(6, []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0x00})
+	var cms *CmsError
+	require.ErrorAs(t, err, &cms)
+	assert.Equal(t, 304, cms.Code)
+}
+
+func TestConcurrentTransactions(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	dev.SendRetry = NoRetry
+	modem.Reply("AT+CMGR=3", "+CMGR: 0,,24", "0001000000", "OK")
+	modem.Reply("AT+CMGS=5", "> ")
+	modem.Reply("0001000000"+Sub, "+CMGS: 7", "OK")
+
+	// e.g. Watch reads the incoming messages while Outbox sends
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				reply, err := dev.Send("AT+CMGR=3")
+				assert.NoError(t, err)
+				assert.Equal(t, "+CMGR: 0,,24\n0001000000", reply)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				ref, err := dev.Commands.CMGS(5, []byte{0x00, 0x01, 0x00, 0x00, 0x00})
+				assert.NoError(t, err)
+				assert.EqualValues(t, 7, ref)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStoreAndSend(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CMGW=5,2", "> ")
+	modem.Reply("0001000000"+Sub, "+CMGW: 12", "OK")
+	modem.Reply("AT+CMSS=12", "+CMSS: 8", "OK")
+	index, err := dev.Commands.CMGW(5, []byte{0x00, 0x01, 0x00, 0x00, 0x00}, MessageFlags.Unsent)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, index)
+	ref, err := dev.Commands.CMSS(index)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, ref)
+}
+
+func TestSendLongSMS(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	text := strings.Repeat("0123456789", 20)
+	parts := []string{text[:153], text[153:]}
+	for i, part := range parts {
+		msg := sms.Message{
+			Text:                     part,
+			Type:                     sms.MessageTypes.Submit,
+			Encoding:                 sms.Encodings.Gsm7Bit,
+			Address:                  "+79997654321",
+			VPFormat:                 sms.ValidityPeriodFormats.Relative,
+			VP:                       sms.ValidityPeriod(24 * time.Hour * 4),
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           sms.UserDataHeader{TotalNumber: 2, Sequence: i + 1, Tag: 1},
+		}
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+		modem.Reply(fmt.Sprintf("%02X", octets)+Sub, fmt.Sprintf("+CMGS: %d", 20+i), "OK")
+	}
+	modem.Reply("AT+CMMS=1", "OK")
+	refs, err := dev.SendSMS(text, "+79997654321")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{20, 21}, refs)
+	// the link is kept open after the first part only
+	sent := modem.Sent()
+	require.Len(t, sent, 5)
+	assert.Equal(t, "AT+CMMS=1", sent[0])
+	assert.True(t, strings.HasPrefix(sent[3], "AT+CMGS="), sent[3])
+}
+
+func TestSendLatin1(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	// a character per octet, the 8-bit data takes 134 octets with the concatenation header
+	parts := []string{strings.Repeat("é", 134), strings.Repeat("à", 10)}
+	for i, part := range parts {
+		msg := sms.Message{
+			Text:                     part,
+			Type:                     sms.MessageTypes.Submit,
+			Address:                  "+79997654321",
+			VPFormat:                 sms.ValidityPeriodFormats.Relative,
+			VP:                       sms.ValidityPeriod(24 * time.Hour * 4),
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           sms.UserDataHeader{TotalNumber: 2, Sequence: i + 1, Tag: 1},
+		}
+		msg.SetDCS(sms.DCS{Alphabet: sms.Alphabets.Latin1})
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+		modem.Reply(fmt.Sprintf("%02X", octets)+Sub, fmt.Sprintf("+CMGS: %d", 40+i), "OK")
+	}
+	modem.Reply("AT+CMMS=1", "OK")
+	refs, err := dev.SendSMSWithOptions(parts[0]+parts[1], "+79997654321", SendOptions{Latin1: true})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{40, 41}, refs)
+
+	_, err = dev.SendSMSWithOptions("café →", "+79997654321", SendOptions{Latin1: true})
+	assert.ErrorIs(t, err, sms.ErrNotEncodable)
+	ucs2 := sms.Encodings.UCS2
+	_, err = dev.SendSMSWithOptions("café", "+79997654321", SendOptions{Latin1: true, Encoding: &ucs2})
+	assert.ErrorIs(t, err, sms.ErrEncodingMismatch)
+}
+
+func TestSendBinary(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	data := bytes.Repeat([]byte{0xab}, 200)
+	parts := [][]byte{data[:128], data[128:]}
+	for i, part := range parts {
+		msg := sms.Message{
+			Data:                     part,
+			Type:                     sms.MessageTypes.Submit,
+			Encoding:                 sms.Encodings.Data8Bit,
+			Address:                  "+79997654321",
+			VPFormat:                 sms.ValidityPeriodFormats.Relative,
+			VP:                       sms.ValidityPeriod(24 * time.Hour * 4),
+			UserDataStartsWithHeader: true,
+			UserDataHeader: sms.UserDataHeader{
+				TotalNumber: 2, Sequence: i + 1, Tag: 1,
+				DestinationPort: 2948, SourcePort: 2948,
+			},
+		}
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+		modem.Reply(fmt.Sprintf("%02X", octets)+Sub, fmt.Sprintf("+CMGS: %d", 30+i), "OK")
+	}
+	refs, err := dev.SendBinary(data, "+79997654321", 2948, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{30, 31}, refs)
+}
+
+func TestSendCommand(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CMGC=14", "> ")
+	modem.Reply("00220500022A0B919799214365F700"+Sub, "+CMGC: 6", "OK")
+	ref, err := dev.SendCommand(&sms.Command{
+		Type:                sms.CommandTypes.Delete,
+		MessageReference:    0x05,
+		MessageNumber:       0x2A,
+		Address:             "+79991234567",
+		StatusReportRequest: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, byte(6), ref)
+}
+
+func TestSendSMSWithOptions(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	msg := sms.Message{
+		Text:                 "hi",
+		Type:                 sms.MessageTypes.Submit,
+		Encoding:             sms.Encodings.UCS2Flash,
+		Address:              "+79997654321",
+		ServiceCenterAddress: "+79262909090",
+		VPFormat:             sms.ValidityPeriodFormats.Relative,
+		VP:                   sms.ValidityPeriod(time.Hour),
+		StatusReportRequest:  true,
+	}
+	n, octets, err := msg.PDU()
+	require.NoError(t, err)
+	modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+	modem.Reply(fmt.Sprintf("%02X", octets)+Sub, "+CMGS: 3", "OK")
+
+	ucs2 := sms.Encodings.UCS2
+	refs, err := dev.SendSMSWithOptions("hi", "+79997654321", SendOptions{
+		ValidityPeriod:      time.Hour,
+		StatusReportRequest: true,
+		Flash:               true,
+		Encoding:            &ucs2,
+		ServiceCenter:       "+79262909090",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{3}, refs)
+
+	gsm7 := sms.Encodings.Gsm7Bit
+	_, err = dev.SendSMSWithOptions("hi ü→", "+79997654321", SendOptions{Encoding: &gsm7})
+	assert.ErrorIs(t, err, sms.ErrNotEncodable)
+	assert.EqualError(t, err, `sms: text can't be encoded with GSM 7-bit alphabet: '→' at offset 5`)
+
+	// the flash class replaces the class of the chosen encoding
+	msg = sms.Message{
+		Text:     "hi",
+		Type:     sms.MessageTypes.Submit,
+		Encoding: sms.Encodings.Gsm7BitFlash,
+		Address:  "+79997654321",
+		VPFormat: sms.ValidityPeriodFormats.Relative,
+		VP:       sms.ValidityPeriod(24 * time.Hour * 4),
+	}
+	n, octets, err = msg.PDU()
+	require.NoError(t, err)
+	modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+	modem.Reply(fmt.Sprintf("%02X", octets)+Sub, "+CMGS: 4", "OK")
+	class1 := sms.Encodings.Gsm7Bit_2
+	refs, err = dev.SendSMSWithOptions("hi", "+79997654321", SendOptions{Flash: true, Encoding: &class1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4}, refs)
+
+	data := sms.Encodings.Data8Bit
+	_, err = dev.SendSMSWithOptions("hi", "+79997654321", SendOptions{Encoding: &data})
+	assert.ErrorIs(t, err, sms.ErrEncodingMismatch)
+	mwi := sms.Encoding(0xC8)
+	_, err = dev.SendSMSWithOptions("hi", "+79997654321", SendOptions{Flash: true, Encoding: &mwi})
+	assert.ErrorIs(t, err, sms.ErrEncodingMismatch)
+	// the message is validated before it's sent
+	_, err = dev.SendSMS("hi", "VeryLongSenderName")
+	assert.ErrorIs(t, err, sms.ErrAddressTooLong)
+}
+
+func TestListMessages(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CMGL=4", "+CMGL: 3,1,,24", testDeliverPDU, "+CMGL: 5,0,,24", testDeliverPDU, "OK")
+	list, err := dev.ListMessages(MessageFlags.Any)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.EqualValues(t, 3, list[0].Index)
+	assert.Equal(t, MessageFlags.Read, list[0].Status)
+	assert.Equal(t, MessageFlags.Unread, list[1].Status)
+	assert.Equal(t, "crap Δ", list[1].Message.Text)
+	for _, cmd := range modem.Sent() {
+		assert.False(t, strings.HasPrefix(cmd, "AT+CMGD"), cmd)
+	}
+}
+
+func TestStorageFullPolicies(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply("AT+CMGD=1,1", "OK")
+	dev.StorageFullPolicy = StorageFullPolicies.DeleteRead
+	require.NoError(t, dev.handleReport(`^SMMEMFULL: "SM"`))
+	assert.Contains(t, modem.Sent(), "AT+CMGD=1,1")
+
+	modem.Reply("AT+CPMS?", `+CPMS: "SM",20,20,"SM",20,20,"SM",20,20`, "OK")
+	modem.Reply(`AT+CPMS="SM","SM","ME"`, "OK")
+	dev.StorageFullPolicy = StorageFullPolicies.SwitchMemory
+	require.NoError(t, dev.handleReport(`+CIEV: "smsfull",1`))
+	assert.Contains(t, modem.Sent(), `AT+CPMS="SM","SM","ME"`)
+	assert.Len(t, dev.StorageFull(), 2)
+}
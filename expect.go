@@ -0,0 +1,111 @@
+package at
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// waiter is a pending Expect call that waits for a matching line.
+type waiter struct {
+	match  func(line string) []string
+	result chan []string
+}
+
+// dispatch offers the line received from any of the ports to the pending waiters.
+// Each waiter receives only the first line it matches and is removed afterwards.
+func (d *Device) dispatch(line string) {
+	d.waitersMux.Lock()
+	defer d.waitersMux.Unlock()
+	pending := d.waiters[:0]
+	for _, w := range d.waiters {
+		if m := w.match(line); m != nil {
+			w.result <- m
+			continue
+		}
+		pending = append(pending, w)
+	}
+	d.waiters = pending
+}
+
+func (d *Device) addWaiter(match func(line string) []string) *waiter {
+	w := &waiter{
+		match:  match,
+		result: make(chan []string, 1),
+	}
+	d.waitersMux.Lock()
+	d.waiters = append(d.waiters, w)
+	d.waitersMux.Unlock()
+	return w
+}
+
+func (d *Device) removeWaiter(w *waiter) {
+	d.waitersMux.Lock()
+	defer d.waitersMux.Unlock()
+	for i := range d.waiters {
+		if d.waiters[i] == w {
+			d.waiters = append(d.waiters[:i], d.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (d *Device) wait(ctx context.Context, w *waiter) ([]string, error) {
+	defer d.removeWaiter(w)
+	select {
+	case m := <-w.result:
+		return m, nil
+	case <-d.closed:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func matchRegexp(re *regexp.Regexp) func(string) []string {
+	return re.FindStringSubmatch
+}
+
+func matchPrefix(prefix string) func(string) []string {
+	return func(line string) []string {
+		if strings.HasPrefix(line, prefix) {
+			return []string{line}
+		}
+		return nil
+	}
+}
+
+// Expect waits for a line matching the given regular expression on either the
+// command or the notification port and returns the match with its submatches.
+// This is useful for vendor-specific flows where a command completes asynchronously
+// with a custom report (e.g. +QMTOPEN: or ^SYSSTART) that is unknown to the core parser.
+//
+// Lines of the notification port are observed while Watch is running, lines of the
+// command port are observed while a command is being sent. Use SendExpect to avoid
+// missing a report that arrives right after the command.
+func (d *Device) Expect(ctx context.Context, pattern *regexp.Regexp) ([]string, error) {
+	return d.wait(ctx, d.addWaiter(matchRegexp(pattern)))
+}
+
+// ExpectPrefix is like Expect, but matches lines that start with the given prefix.
+// Returns the whole matched line.
+func (d *Device) ExpectPrefix(ctx context.Context, prefix string) (string, error) {
+	m, err := d.wait(ctx, d.addWaiter(matchPrefix(prefix)))
+	if err != nil {
+		return "", err
+	}
+	return m[0], nil
+}
+
+// SendExpect sends the command and then waits for a line matching the given
+// regular expression. The expectation is set up before the command is sent,
+// so a report that comes along with the reply won't be missed.
+func (d *Device) SendExpect(ctx context.Context, req string, pattern *regexp.Regexp) (reply string, match []string, err error) {
+	w := d.addWaiter(matchRegexp(pattern))
+	if reply, err = d.Send(req); err != nil {
+		d.removeWaiter(w)
+		return
+	}
+	match, err = d.wait(ctx, w)
+	return
+}
@@ -0,0 +1,66 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectNotifyPort(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	go dev.Watch()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := make(chan string, 1)
+	go func() {
+		line, err := dev.ExpectPrefix(ctx, "^SYSSTART")
+		assert.NoError(t, err)
+		result <- line
+	}()
+	time.Sleep(50 * time.Millisecond)
+	modem.Notify("^RSSI: 17", "^SYSSTART")
+	assert.Equal(t, "^SYSSTART", <-result)
+}
+
+func TestSendExpect(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	modem.Reply(`AT+QMTOPEN=0,"host",1883`, "OK", "+QMTOPEN: 0,0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		// the report will be read by the next command
+		time.Sleep(50 * time.Millisecond)
+		modem.Reply("AT", "OK")
+		dev.Send("AT")
+	}()
+	re := regexp.MustCompile(`^\+QMTOPEN: (\d+),(\d+)$`)
+	_, match, err := dev.SendExpect(ctx, `AT+QMTOPEN=0,"host",1883`, re)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"+QMTOPEN: 0,0", "0", "0"}, match)
+}
+
+func TestExpectCanceled(t *testing.T) {
+	t.Parallel()
+
+	dev, _ := newTestDevice(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := dev.Expect(ctx, regexp.MustCompile(`never`))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, dev.waiters)
+}
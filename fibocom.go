@@ -0,0 +1,94 @@
+package at
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceFibocom returns an instance of DeviceProfile implementation for Fibocom
+// modules such as L850, L860 and NL668.
+func DeviceFibocom() DeviceProfile {
+	return &FibocomProfile{}
+}
+
+// FibocomProfile is a DeviceProfile implementation for Fibocom modules. It handles
+// the +SIM reports and implements RATSelector using the AT+GTRAT command.
+type FibocomProfile struct {
+	GenericProfile
+}
+
+// gtrat maps AccessTechnologies to the modes of AT+GTRAT.
+var gtrat = map[Opt]int{
+	AccessTechnologies.GSM:      0,
+	AccessTechnologies.GsmWcdma: 1,
+	AccessTechnologies.WCDMA:    2,
+	AccessTechnologies.LTE:      3,
+	AccessTechnologies.WcdmaLte: 4,
+	AccessTechnologies.GsmLte:   5,
+	AccessTechnologies.Auto:     10,
+}
+
+// Init makes the initial setup of the Fibocom module.
+func (p *FibocomProfile) Init(d *Device) (err error) {
+	return p.init(d, p)
+}
+
+// SelectRAT sends AT+GTRAT to the device in order to select the radio access technology,
+// see AccessTechnologies.
+func (p *FibocomProfile) SelectRAT(tech Opt) (err error) {
+	mode, ok := gtrat[tech]
+	if !ok {
+		return ErrNotSupported
+	}
+	req := fmt.Sprintf(`AT+GTRAT=%d`, mode)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// RAT sends AT+GTRAT? to the device and gets the selected radio access technology.
+func (p *FibocomProfile) RAT() (tech Opt, err error) {
+	reply, err := p.dev.Send(`AT+GTRAT?`)
+	if err != nil {
+		return UnknownOpt, err
+	}
+	fields := strings.Split(strings.TrimPrefix(reply, `+GTRAT:`), ",")
+	mode, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return UnknownOpt, ErrParseReport
+	}
+	for opt, id := range gtrat {
+		if id == mode {
+			return opt, nil
+		}
+	}
+	return UnknownOpt, nil
+}
+
+// HandleReport handles the +SIM reports of Fibocom modules, they are sent
+// when the SIM is hot-swapped or becomes ready.
+func (p *FibocomProfile) HandleReport(report string) (handled bool, err error) {
+	if !strings.HasPrefix(report, "+SIM") {
+		return false, nil
+	}
+	status := strings.ToUpper(strings.TrimSpace(strings.TrimLeft(report[len("+SIM"):], ": ")))
+	sim := SimStates.Invalid
+	switch status {
+	case "READY":
+		sim = SimStates.Valid
+	case "REMOVED", "DROP", "NOT INSERTED":
+		sim = SimStates.NoCard
+	case "INSERTED":
+		// the PIN state is unknown yet
+	default:
+		return false, nil
+	}
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		if s.SimState == sim {
+			return false
+		}
+		s.SimState = sim
+		return true
+	})
+	return true, nil
+}
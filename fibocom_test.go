@@ -0,0 +1,41 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFibocomProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+GTRAT=3", "OK")
+	modem.Reply("AT+GTRAT?", "+GTRAT: 10,6,3", "OK")
+	require.NoError(t, dev.Init(DeviceFibocom()))
+
+	require.NoError(t, dev.SelectRAT(AccessTechnologies.LTE))
+	assert.Contains(t, modem.Sent(), "AT+GTRAT=3")
+	tech, err := dev.Commands.(RATSelector).RAT()
+	require.NoError(t, err)
+	assert.Equal(t, AccessTechnologies.Auto, tech)
+
+	require.NoError(t, dev.handleReport("+SIM: Removed"))
+	assert.Equal(t, SimStates.NoCard, dev.State().SimState)
+	require.NoError(t, dev.handleReport("+SIM READY"))
+	assert.Equal(t, SimStates.Valid, dev.State().SimState)
+}
+
+func TestSelectRATNotSupported(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	assert.Equal(t, ErrNotSupported, dev.SelectRAT(AccessTechnologies.LTE))
+}
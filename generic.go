@@ -0,0 +1,168 @@
+package at
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeviceGeneric returns an instance of DeviceProfile implementation that uses
+// only the commands standardized in 3GPP TS 27.007 and 27.005. It should work
+// with most of the modems, but lacks the vendor-specific features.
+func DeviceGeneric() DeviceProfile {
+	return &GenericProfile{}
+}
+
+// GenericProfile is a DeviceProfile implementation that never issues vendor-specific
+// commands, e.g. Huawei ^-commands. It could be embedded in vendor profiles as well.
+type GenericProfile struct {
+	DefaultProfile
+}
+
+// Init invokes a set of standard commands that will make the initial setup of the modem.
+func (p *GenericProfile) Init(d *Device) (err error) {
+	return p.init(d, p)
+}
+
+// init makes the initial setup of the modem, the state is read using the methods
+// of the given profile, so the profiles that embed GenericProfile can override them.
+func (p *GenericProfile) init(d *Device, profile DeviceProfile) (err error) {
+	p.dev = d
+	p.dev.Send(NoopCmd)   // kinda flush
+	p.ProbeCapabilities() // optional, all commands are considered supported otherwise
+	p.errorReporting()    // optional, the errors carry no causes otherwise
+	if p.supports(`AT+COPS`) {
+		p.COPS(true, true) // optional, the numeric format is used otherwise
+	}
+	deadline := time.Now().Add(p.readyTimeout())
+	if err = p.waitSimReady(deadline); err != nil {
+		return fmt.Errorf("at init: SIM is not ready: %w", err)
+	}
+	if err = p.initState(profile); err != nil {
+		return err
+	}
+	if err = p.messageFormat(); err != nil {
+		return fmt.Errorf("at init: unable to switch message format: %w", err)
+	}
+	if err = p.waitStorage(deadline, p.storage(MemoryTypes.Sim)); err != nil {
+		return fmt.Errorf("at init: unable to set messages storage: %w", err)
+	}
+	if err = p.messageService(); err != nil {
+		return fmt.Errorf("at init: unable to select message service: %w", err)
+	}
+	if err = p.notifications(); err != nil {
+		return fmt.Errorf("at init: unable to turn on message notifications: %w", err)
+	}
+	return p.initFinish()
+}
+
+// initState reads the device state using the standard commands of the given profile.
+func (p *GenericProfile) initState(profile DeviceProfile) (err error) {
+	if p.supports(`AT+CREG`) {
+		p.CREG(true) // optional, the state won't be updated if not supported
+	}
+	var info *SystemInfoReport
+	if info, err = profile.SYSINFO(); err != nil {
+		return fmt.Errorf("at init: unable to read system info: %w", err)
+	}
+	state := *NewDeviceState()
+	state.ServiceState = info.ServiceState
+	state.ServiceDomain = info.ServiceDomain
+	state.RoamingState = info.RoamingState
+	state.SystemMode = info.SystemMode
+	state.SystemSubmode = info.SystemSubmode
+	state.SimState = info.SimState
+	state.Registration, _ = profile.RegistrationState()
+	state.SignalStrength, _ = profile.CSQ()
+	state.Charset = p.charset() // optional, the strings are reported as is otherwise
+	if !p.Options.SkipOperatorName {
+		// the operator is unknown until the network is found
+		state.OperatorName, _ = profile.OperatorName()
+	}
+	if state.Manufacturer, err = profile.Manufacturer(); err != nil {
+		return fmt.Errorf("at init: unable to read modem's manufacturer: %w", err)
+	}
+	if state.ModelName, err = profile.ModelName(); err != nil {
+		return fmt.Errorf("at init: unable to read modem's model name: %w", err)
+	}
+	if state.IMEI, err = profile.IMEI(); err != nil {
+		return fmt.Errorf("at init: unable to read modem's IMEI code: %w", err)
+	}
+	if state.ServiceCenter, err = p.serviceCenter(); err != nil {
+		return fmt.Errorf("at init: unable to set service center address: %w", err)
+	}
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		state.Capabilities, state.Commands = s.Capabilities, s.Commands
+		*s = state
+		return true
+	})
+	return nil
+}
+
+// waitStorage selects the message storage, it is retried until the deadline is exceeded,
+// since the messaging of some modems is not ready for a while after the SIM is.
+func (p *GenericProfile) waitStorage(deadline time.Time, mem [3]StringOpt) error {
+	for {
+		err := p.CPMS(mem[0], mem[1], mem[2])
+		if err == nil || !p.sleepUntil(deadline) {
+			return err
+		}
+	}
+}
+
+// SYSINFO emulates the Huawei's AT^SYSINFO report using the network registration
+// state and the SIM state. The system mode and the service domain are unknown.
+func (p *GenericProfile) SYSINFO() (info *SystemInfoReport, err error) {
+	info = &SystemInfoReport{
+		ServiceState:  ServiceStates.None,
+		ServiceDomain: UnknownOpt,
+		RoamingState:  RoamingStates.NotRoaming,
+		SystemMode:    UnknownOpt,
+		SystemSubmode: UnknownOpt,
+		SimState:      SimStates.Invalid,
+	}
+	reg, err := p.RegistrationState()
+	if err != nil {
+		return nil, err
+	}
+	switch reg {
+	case RegistrationStates.Home:
+		info.ServiceState = ServiceStates.Valid
+	case RegistrationStates.Roaming:
+		info.ServiceState = ServiceStates.Valid
+		info.RoamingState = RoamingStates.Roaming
+	case RegistrationStates.Denied:
+		info.ServiceState = ServiceStates.Restricted
+	}
+	if pin, err := p.CPIN(); err == nil && pin == SimPinStates.Ready {
+		info.SimState = SimStates.Valid
+	}
+	return info, nil
+}
+
+// SYSCFG is not supported by the generic profile.
+func (p *GenericProfile) SYSCFG(roaming, cellular bool) error {
+	return ErrNotSupported
+}
+
+// BOOT is not supported by the generic profile.
+func (p *GenericProfile) BOOT(token uint64) error {
+	return ErrNotSupported
+}
+
+// Manufacturer sends AT+CGMI to the device and gets the modem's manufacturer.
+func (p *GenericProfile) Manufacturer() (str string, err error) {
+	str, err = p.dev.Send(`AT+CGMI`)
+	return
+}
+
+// ModelName sends AT+CGMM to the device and gets the modem's model name.
+func (p *GenericProfile) ModelName() (str string, err error) {
+	str, err = p.dev.Send(`AT+CGMM`)
+	return
+}
+
+// IMEI sends AT+CGSN to the device and gets the modem's IMEI code.
+func (p *GenericProfile) IMEI() (str string, err error) {
+	str, err = p.dev.Send(`AT+CGSN`)
+	return
+}
@@ -0,0 +1,339 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+// replyGeneric sets up the fake modem to reply to the standard commands.
+func replyGeneric(modem *fakeModem) {
+	modem.Reply("AT", "OK")
+	modem.Reply("AT+COPS=0,0", "OK")
+	modem.Reply("AT+CPIN?", "+CPIN: READY", "OK")
+	modem.Reply("AT+CREG=1", "OK")
+	modem.Reply("AT+CREG?", "+CREG: 1,5", "OK")
+	modem.Reply("AT+CSQ", "+CSQ: 21,99", "OK")
+	modem.Reply("AT+COPS?", `+COPS: 0,0,"Vodafone"`, "OK")
+	modem.Reply("AT+CGMI", "Quectel", "OK")
+	modem.Reply("AT+CGMM", "EC25", "OK")
+	modem.Reply("AT+CGSN", "861111111111111", "OK")
+	modem.Reply("AT+CMGF=0", "OK")
+	modem.Reply(`AT+CPMS="SM","SM","SM"`, "OK")
+	modem.Reply("AT+CNMI=2,1,0,0,0", "OK")
+	modem.Reply("AT+CLIP=1", "OK")
+	modem.Reply("AT+CMGL=4", "OK")
+}
+
+func TestGenericProfileInit(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	require.NoError(t, dev.Init(DeviceGeneric()))
+
+	state := dev.State()
+	assert.Equal(t, "Vodafone", state.OperatorName)
+	assert.Equal(t, "Quectel", state.Manufacturer)
+	assert.Equal(t, "EC25", state.ModelName)
+	assert.Equal(t, "861111111111111", state.IMEI)
+	assert.Equal(t, 21, state.SignalStrength)
+	assert.Equal(t, RegistrationStates.Roaming, state.Registration)
+	assert.Equal(t, RoamingStates.Roaming, state.RoamingState)
+	assert.Equal(t, ServiceStates.Valid, state.ServiceState)
+	assert.Equal(t, SimStates.Valid, state.SimState)
+	for _, cmd := range modem.Sent() {
+		assert.False(t, strings.HasPrefix(cmd, "AT^"), cmd)
+	}
+
+	dev.handleReport("+CREG: 2")
+	assert.Equal(t, RegistrationStates.Searching, dev.State().Registration)
+}
+
+func TestGenericProfileCapabilities(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CLAC", "AT+CPIN", "AT+CREG", "AT+CSQ", "AT+COPS", "AT+CGMI",
+		"AT+CGMM", "AT+CGSN", "AT+CMGF", "AT+CPMS", "AT+CNMI", "AT+CMGL", "OK")
+	modem.Reply("AT+GCAP", "+GCAP: +CGSM,+DS", "OK")
+	require.NoError(t, dev.Init(DeviceGeneric()))
+
+	state := dev.State()
+	assert.Equal(t, []string{"+CGSM", "+DS"}, state.Capabilities)
+	assert.True(t, state.Supports("AT+CREG=1"))
+	assert.False(t, state.Supports("AT+CLIP=1"))
+	assert.NotContains(t, modem.Sent(), "AT+CLIP=1")
+}
+
+func TestGenericProfileInitOptions(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply(`AT+CPMS="ME","SM","SM"`, "OK")
+	modem.Reply("AT+CNMI=2,1,0,2,1", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{
+		SkipOperatorName:   true,
+		SkipCLIP:           true,
+		KeepStoredMessages: true,
+		Notifications: &NotificationConfig{
+			StatusReports: true,
+			BufferFlush:   BufferFlushPolicies.Clear,
+		},
+		Storage: []StringOpt{MemoryTypes.NvRAM, MemoryTypes.Sim},
+	}}}
+	require.NoError(t, dev.Init(profile))
+	assert.Empty(t, dev.State().OperatorName)
+
+	sent := modem.Sent()
+	assert.Contains(t, sent, `AT+CPMS="ME","SM","SM"`)
+	assert.Contains(t, sent, "AT+CNMI=2,1,0,2,1")
+	for _, cmd := range []string{"AT+COPS?", "AT+CLIP=1", "AT+CMGL=4"} {
+		assert.NotContains(t, sent, cmd)
+	}
+}
+
+func TestGenericProfileInitCNMI(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CNMI=1,1,0,1,0", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{
+		CNMI:          &CNMIParams{Mode: 1, MT: 1, DS: 1},
+		Notifications: &NotificationConfig{DeliverTo: DeliveryTargets.TE},
+	}}}
+	require.NoError(t, dev.Init(profile))
+	assert.Contains(t, modem.Sent(), "AT+CNMI=1,1,0,1,0")
+	assert.NotContains(t, modem.Sent(), "AT+CNMI=?")
+}
+
+func TestGenericProfileServiceCenter(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSCA?", `+CSCA: "",129`, "OK")
+	modem.Reply(`AT+CSCA="+79262909090",145`, "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{ServiceCenter: "+79262909090"}}}
+	require.NoError(t, dev.Init(profile))
+	assert.EqualValues(t, "+79262909090", dev.State().ServiceCenter)
+	assert.Contains(t, modem.Sent(), `AT+CSCA="+79262909090",145`)
+
+	modem.Reply("AT+CSCA?", `+CSCA: "+79168999100",145`, "OK")
+	addr, err := profile.CSCA()
+	require.NoError(t, err)
+	assert.EqualValues(t, "+79168999100", addr)
+}
+
+func TestGenericProfileAckDeliveries(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSMS=1", "+CSMS: 1,1,1", "OK")
+	modem.Reply("AT+CNMA=1", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{AckDeliveries: true}}}
+	require.NoError(t, dev.Init(profile))
+	require.NoError(t, dev.AckDelivery(true))
+	assert.Contains(t, modem.Sent(), "AT+CSMS=1")
+	assert.Contains(t, modem.Sent(), "AT+CNMA=1")
+
+	modem.Reply("AT+CNMA=2,3", "> ")
+	modem.Reply("00D300"+Sub, "OK")
+	report := &sms.DeliverReport{FailureCause: sms.FailureCauses.MemoryCapacityExceeded}
+	require.NoError(t, dev.ackDeliveryReport(report))
+	assert.Contains(t, modem.Sent(), "00D300"+Sub)
+
+	mt, mo, bm, err := profile.CSMS(1)
+	require.NoError(t, err)
+	assert.True(t, mt && mo && bm)
+}
+
+func TestGenericProfileDirectMessage(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSMS=1", "+CSMS: 1,1,1", "OK")
+	modem.Reply("AT+CNMI=2,2,0,0,0", "OK")
+	modem.Reply("AT+CNMA=1", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{
+		AckDeliveries: true,
+		Notifications: &NotificationConfig{DeliverTo: DeliveryTargets.TE},
+	}}}
+	require.NoError(t, dev.Init(profile))
+	go dev.Watch()
+	modem.Notify("+CMT: ,24", "07919762020033F1040B919762995696F0000041606291401561066379180E8200")
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "crap Δ", msg.Text)
+	require.Eventually(t, func() bool {
+		for _, cmd := range modem.Sent() {
+			if cmd == "AT+CNMA=1" {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGenericProfileRejectDirectMessage(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSMS=1", "+CSMS: 1,1,1", "OK")
+	modem.Reply("AT+CNMI=2,2,0,0,0", "OK")
+	modem.Reply("AT+CNMA=2,3", "> ")
+	modem.Reply("00D300"+Sub, "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{
+		AckDeliveries: true,
+		Notifications: &NotificationConfig{DeliverTo: DeliveryTargets.TE},
+	}}}
+	require.NoError(t, dev.Init(profile))
+	dev.AddFilter(func(msg *sms.Message) error {
+		return Reject(sms.FailureCauses.MemoryCapacityExceeded)
+	})
+	go dev.Watch()
+	modem.Notify("+CMT: ,24", "07919762020033F1040B919762995696F0000041606291401561066379180E8200")
+	// the message is acknowledged once, with RP-ERROR
+	require.Eventually(t, func() bool {
+		for _, cmd := range modem.Sent() {
+			if cmd == "00D300"+Sub {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, modem.Sent(), "AT+CNMA=1")
+	assert.Empty(t, dev.IncomingSms())
+	assert.ErrorIs(t, Reject(sms.FailureCauses.MemoryCapacityExceeded), ErrDropMessage)
+}
+
+func TestNotificationParams(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	profile := dev.Commands.(*nopProfile)
+	modem.Reply("AT+CNMI=?", "+CNMI: (0-2),(0-3),(0,2),(0,2),(0,1)", "OK")
+	params, err := profile.NotificationParams(NotificationConfig{
+		DeliverTo:     DeliveryTargets.TE,
+		StatusReports: true,
+		CellBroadcast: true,
+	})
+	require.NoError(t, err)
+	// +CDS is not supported, so the status reports are stored
+	assert.Equal(t, CNMIParams{Mode: 2, MT: 2, BM: 2, DS: 2}, params)
+
+	modem.Reply("AT+CNMI=?", "+CNMI: (1),(1),(0),(0),(0)", "OK")
+	params, err = profile.NotificationParams(NotificationConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, CNMIParams{Mode: 1, MT: 1}, params)
+	_, err = profile.NotificationParams(NotificationConfig{DeliverTo: DeliveryTargets.TE})
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestGenericProfileStatusReportRequest(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	dev.Tracker = NewDeliveryTracker()
+
+	msg := sms.Message{
+		Text:                "hi",
+		Type:                sms.MessageTypes.Submit,
+		Encoding:            sms.Encodings.Gsm7Bit,
+		Address:             "+79997654321",
+		VPFormat:            sms.ValidityPeriodFormats.Relative,
+		VP:                  sms.ValidityPeriod(24 * time.Hour * 4),
+		StatusReportRequest: true,
+	}
+	n, octets, err := msg.PDU()
+	require.NoError(t, err)
+	modem.Reply("AT+CNMI=2,1,0,2,0", "OK")
+	modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+	modem.Reply(fmt.Sprintf("%02X", octets)+Sub, "+CMGS: 54", "OK")
+	refs, err := dev.SendSMS("hi", "+79997654321")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{54}, refs)
+	assert.Contains(t, modem.Sent(), "AT+CNMI=2,1,0,2,0")
+
+	modem.Notify("+CDS: 27", "079194710600400706360d91947106000000f122206151457440222061514584400000")
+	select {
+	case update := <-dev.Tracker.Updates():
+		assert.Equal(t, DeliveryStates.Pending, update.State)
+	case <-time.After(time.Second):
+		t.Fatal("no delivery update")
+	}
+	go dev.Watch()
+	select {
+	case update := <-dev.Tracker.Updates():
+		assert.Equal(t, DeliveryStates.Delivered, update.State)
+		assert.EqualValues(t, 54, update.Reference)
+	case <-time.After(time.Second):
+		t.Fatal("no delivery update")
+	}
+	require.Len(t, dev.StatusReports(), 1)
+
+	// the status reports are already on
+	_, err = dev.SendSMS("hi", "+79997654321")
+	require.NoError(t, err)
+	var cnmi int
+	for _, cmd := range modem.Sent() {
+		if cmd == "AT+CNMI=2,1,0,2,0" {
+			cnmi++
+		}
+	}
+	assert.Equal(t, 1, cnmi)
+}
+
+func TestGenericProfileCharset(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSCS?", `+CSCS: "UCS2"`, "OK")
+	modem.Reply("AT+COPS?", `+COPS: 0,0,"041C04220421"`, "OK")
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	state := dev.State()
+	assert.Equal(t, Charsets.UCS2, state.Charset)
+	assert.Equal(t, "МТС", state.OperatorName)
+
+	modem.Reply(`AT+CSCS="GSM"`, "OK")
+	modem.Reply("AT+COPS?", `+COPS: 0,0,"MTS"`, "OK")
+	profile := dev.Commands.(*GenericProfile)
+	require.NoError(t, profile.SetCSCS(Charsets.GSM))
+	assert.Equal(t, Charsets.GSM, dev.State().Charset)
+	name, err := profile.OperatorName()
+	require.NoError(t, err)
+	assert.Equal(t, "MTS", name)
+}
+
+func TestGenericProfileErrorReporting(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMEE=2", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{VerboseErrors: true}}}
+	require.NoError(t, dev.Init(profile))
+	assert.Contains(t, modem.Sent(), "AT+CMEE=2")
+
+	modem.Reply("AT+CSCA?", "+CME ERROR: SIM PIN required")
+	_, err := profile.CSCA()
+	var cme *CmeError
+	require.ErrorAs(t, err, &cme)
+	assert.Equal(t, 11, cme.Code)
+}
@@ -1,6 +1,9 @@
 package at
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 func parseUint8(str string) (uint8, error) {
 	i, err := strconv.ParseUint(str, 10, 8)
@@ -11,3 +14,52 @@ func parseUint16(str string) (uint16, error) {
 	i, err := strconv.ParseUint(str, 10, 16)
 	return uint16(i), err
 }
+
+// splitFields splits the comma-separated fields of a report, the commas within
+// quoted strings are respected. The spaces and quotes around fields are trimmed.
+func splitFields(str string) []string {
+	var fields []string
+	var quoted bool
+	var start int
+	for i, r := range str {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			fields = append(fields, trimField(str[start:i]))
+			start = i + 1
+		}
+	}
+	return append(fields, trimField(str[start:]))
+}
+
+func trimField(str string) string {
+	return strings.Trim(strings.TrimSpace(str), `"`)
+}
+
+// supportedValues parses the list of supported values reported by a test command,
+// e.g. (0-2),(0,1),(0). Each group is a list of values and ranges.
+func supportedValues(str string) (groups [][]int) {
+	for _, group := range strings.Split(str, "(")[1:] {
+		var values []int
+		group = group[:strings.IndexByte(group+")", ')')]
+		for _, item := range strings.Split(group, ",") {
+			bounds := strings.SplitN(strings.TrimSpace(item), "-", 2)
+			lo, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				continue
+			}
+			hi := lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					continue
+				}
+			}
+			for v := lo; v <= hi; v++ {
+				values = append(values, v)
+			}
+		}
+		groups = append(groups, values)
+	}
+	return
+}
@@ -0,0 +1,436 @@
+// Package hilink implements the at.Modem interface for Huawei USB sticks in the HiLink mode,
+// e.g. E3372h, that expose an HTTP/XML API instead of the serial AT ports.
+package hilink
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"github.com/xlab/at"
+	"github.com/xlab/at/sms"
+)
+
+// DefaultURL is the default address of the HiLink web interface.
+const DefaultURL = "http://192.168.8.1"
+
+// DefaultPollInterval is the default interval between the polls of the inbox and the state.
+const DefaultPollInterval = 5 * time.Second
+
+// dateLayout is the layout of the message dates used by the API.
+const dateLayout = "2006-01-02 15:04:05"
+
+var (
+	ErrNotInitialized = errors.New("hilink: not initialized")
+	ErrClosed         = errors.New("hilink: device is closed")
+)
+
+// Error represents an error reported by the API.
+type Error struct {
+	Code    int    `xml:"code"`
+	Message string `xml:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("hilink: error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("hilink: error %d", e.Code)
+}
+
+// Device represents a HiLink device, it implements the at.Modem interface.
+type Device struct {
+	// URL is the address of the web interface, DefaultURL is used if it's empty.
+	URL string
+	// Client is used to make the requests, http.DefaultClient is used if it's nil.
+	Client *http.Client
+	// PollInterval is the interval between the polls of the inbox and the state,
+	// DefaultPollInterval is used if it's zero.
+	PollInterval time.Duration
+
+	messages  chan *sms.Message
+	updated   chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	stateMux sync.RWMutex
+	state    *at.DeviceState
+}
+
+var _ at.Modem = (*Device)(nil)
+
+// Init checks whether the device is reachable, initializes event channels
+// and reads the initial state.
+func (d *Device) Init() error {
+	d.messages = make(chan *sms.Message, 100)
+	d.updated = make(chan struct{}, 100)
+	d.closed = make(chan struct{})
+	d.closeOnce = sync.Once{}
+	state := at.NewDeviceState()
+	state.Manufacturer = "Huawei"
+	d.stateMux.Lock()
+	d.state = state
+	d.stateMux.Unlock()
+	var info struct {
+		DeviceName string `xml:"DeviceName"`
+		Imei       string `xml:"Imei"`
+	}
+	// optional, the information may require a login
+	if err := d.get("/api/device/information", &info); err == nil {
+		d.updateState(func(s *at.DeviceState) {
+			s.ModelName = info.DeviceName
+			s.IMEI = info.Imei
+		})
+	}
+	return d.Refresh()
+}
+
+// IncomingSms fires when an SMS was received.
+func (d *Device) IncomingSms() <-chan *sms.Message {
+	return d.messages
+}
+
+// StateUpdate fires when the device state was changed.
+func (d *Device) StateUpdate() <-chan struct{} {
+	return d.updated
+}
+
+// Closed fires when the device was closed.
+func (d *Device) Closed() <-chan struct{} {
+	return d.closed
+}
+
+// Close stops the Watch routine. Close is a no-op if already closed.
+func (d *Device) Close() error {
+	if d.closed == nil {
+		return nil
+	}
+	d.closeOnce.Do(func() {
+		close(d.closed)
+	})
+	return nil
+}
+
+// State returns a snapshot of the device state.
+func (d *Device) State() at.DeviceState {
+	d.stateMux.RLock()
+	defer d.stateMux.RUnlock()
+	if d.state == nil {
+		return *at.NewDeviceState()
+	}
+	return *d.state
+}
+
+func (d *Device) updateState(update func(state *at.DeviceState)) {
+	d.stateMux.Lock()
+	prev := *d.state
+	update(d.state)
+	changed := !reflect.DeepEqual(prev, *d.state)
+	d.stateMux.Unlock()
+	if changed {
+		select {
+		case d.updated <- struct{}{}:
+		default:
+			// there is a pending event already
+		}
+	}
+}
+
+// Watch polls the inbox and the state of the device until it's closed.
+func (d *Device) Watch() error {
+	if d.closed == nil {
+		return ErrNotInitialized
+	}
+	interval := d.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		d.FetchInbox() // ignore errors
+		d.Refresh()
+		select {
+		case <-d.closed:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// statusResponse is the reply of /api/monitoring/status.
+type statusResponse struct {
+	ServiceStatus      int `xml:"ServiceStatus"`
+	ServiceDomain      int `xml:"ServiceDomain"`
+	RoamingStatus      int `xml:"RoamingStatus"`
+	SimStatus          int `xml:"SimStatus"`
+	CurrentNetworkType int `xml:"CurrentNetworkType"`
+}
+
+// signalResponse is the reply of /api/device/signal.
+type signalResponse struct {
+	RSSI string `xml:"rssi"`
+}
+
+// Refresh reads the state of the device.
+func (d *Device) Refresh() error {
+	var status statusResponse
+	if err := d.get("/api/monitoring/status", &status); err != nil {
+		return err
+	}
+	var plmn struct {
+		FullName string `xml:"FullName"`
+	}
+	d.get("/api/net/current-plmn", &plmn) // optional
+	rssi := -1
+	var signal signalResponse
+	if err := d.get("/api/device/signal", &signal); err == nil {
+		rssi = signalStrength(signal.RSSI)
+	}
+	mode, submode := networkType(status.CurrentNetworkType)
+	d.updateState(func(s *at.DeviceState) {
+		s.ServiceState = at.ServiceStates.Resolve(status.ServiceStatus)
+		s.ServiceDomain = at.ServiceDomains.Resolve(status.ServiceDomain)
+		s.RoamingState = at.RoamingStates.Resolve(status.RoamingStatus)
+		s.SimState = at.SimStates.Resolve(status.SimStatus)
+		s.SystemMode = mode
+		s.SystemSubmode = submode
+		s.OperatorName = plmn.FullName
+		if rssi >= 0 {
+			s.SignalStrength = rssi
+		}
+	})
+	return nil
+}
+
+// signalStrength converts the RSSI reported in dBm, e.g. "-67dBm", to the
+// scale of AT+CSQ (0-31).
+func signalStrength(str string) int {
+	str = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(str, ">="), "dBm"))
+	dbm, err := strconv.Atoi(str)
+	if err != nil {
+		return -1
+	}
+	rssi := (dbm + 113) / 2
+	if rssi < 0 {
+		return 0
+	} else if rssi > 31 {
+		return 31
+	}
+	return rssi
+}
+
+// networkType maps the network type reported by the API to the system mode and submode.
+func networkType(n int) (mode, submode at.Opt) {
+	switch n {
+	case 0:
+		return at.SystemModes.NoService, at.SystemSubmodes.NoService
+	case 1:
+		return at.SystemModes.GsmGprs, at.SystemSubmodes.GSM
+	case 2:
+		return at.SystemModes.GsmGprs, at.SystemSubmodes.GPRS
+	case 3:
+		return at.SystemModes.GsmGprs, at.SystemSubmodes.EDGE
+	case 4:
+		return at.SystemModes.WCDMA, at.SystemSubmodes.WCDMA
+	case 5:
+		return at.SystemModes.WCDMA, at.SystemSubmodes.HSDPA
+	case 6:
+		return at.SystemModes.WCDMA, at.SystemSubmodes.HSUPA
+	case 7:
+		return at.SystemModes.WCDMA, at.SystemSubmodes.HsdpaHsupa
+	case 8:
+		return at.SystemModes.SCDMA, at.SystemSubmodes.SCDMA
+	case 9, 17, 18:
+		return at.SystemModes.WCDMA, at.SystemSubmodes.HspaPlus
+	case 19, 101:
+		return at.SystemModes.LTE, at.UnknownOpt
+	}
+	return at.UnknownOpt, at.UnknownOpt
+}
+
+// SendSMS sends an SMS message with given text to the given address.
+// The API doesn't report the message references, so refs is always nil.
+func (d *Device) SendSMS(text string, address sms.PhoneNumber) (refs []byte, err error) {
+	req := struct {
+		XMLName  xml.Name `xml:"request"`
+		Index    int      `xml:"Index"`
+		Phones   []string `xml:"Phones>Phone"`
+		Sca      string   `xml:"Sca"`
+		Content  string   `xml:"Content"`
+		Length   int      `xml:"Length"`
+		Reserved int      `xml:"Reserved"`
+		Date     string   `xml:"Date"`
+	}{
+		Index:    -1,
+		Phones:   []string{string(address)},
+		Content:  text,
+		Length:   len(utf16.Encode([]rune(text))), // in UTF-16 code units as the web UI counts
+		Reserved: 1,
+		Date:     time.Now().Format(dateLayout),
+	}
+	return nil, d.post("/api/sms/send-sms", req, nil)
+}
+
+// message is a message in the reply of /api/sms/sms-list.
+type message struct {
+	Smstat  int    `xml:"Smstat"`
+	Index   int    `xml:"Index"`
+	Phone   string `xml:"Phone"`
+	Content string `xml:"Content"`
+	Date    string `xml:"Date"`
+	Sca     string `xml:"Sca"`
+}
+
+// FetchInbox reads the unread messages from the inbox of the device, sends them
+// over the IncomingSms channel and deletes them from the device.
+func (d *Device) FetchInbox() error {
+	req := struct {
+		XMLName         xml.Name `xml:"request"`
+		PageIndex       int      `xml:"PageIndex"`
+		ReadCount       int      `xml:"ReadCount"`
+		BoxType         int      `xml:"BoxType"`
+		SortType        int      `xml:"SortType"`
+		Ascending       int      `xml:"Ascending"`
+		UnreadPreferred int      `xml:"UnreadPreferred"`
+	}{
+		PageIndex:       1,
+		ReadCount:       20,
+		BoxType:         1,
+		UnreadPreferred: 1,
+	}
+	var resp struct {
+		Messages []message `xml:"Messages>Message"`
+	}
+	if err := d.post("/api/sms/sms-list", req, &resp); err != nil {
+		return fmt.Errorf("hilink: unable to check message inbox: %w", err)
+	}
+	for _, m := range resp.Messages {
+		if m.Smstat != 0 {
+			continue
+		}
+		msg := &sms.Message{
+			Type:                 sms.MessageTypes.Deliver,
+			Address:              sms.PhoneNumber(m.Phone),
+			ServiceCenterAddress: sms.PhoneNumber(m.Sca),
+			Text:                 m.Content,
+		}
+		if t, err := time.ParseInLocation(dateLayout, m.Date, time.Local); err == nil {
+			msg.ServiceCenterTime = sms.Timestamp(t)
+		}
+		select {
+		case d.messages <- msg:
+		case <-d.closed:
+			return ErrClosed
+		}
+		if err := d.DeleteSMS(m.Index); err != nil {
+			return fmt.Errorf("hilink: unable to delete message: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteSMS deletes the message with the given index from the device.
+func (d *Device) DeleteSMS(index int) error {
+	req := struct {
+		XMLName xml.Name `xml:"request"`
+		Index   int      `xml:"Index"`
+	}{Index: index}
+	return d.post("/api/sms/delete-sms", req, nil)
+}
+
+func (d *Device) url(path string) string {
+	if d.URL == "" {
+		return DefaultURL + path
+	}
+	return strings.TrimSuffix(d.URL, "/") + path
+}
+
+func (d *Device) client() *http.Client {
+	if d.Client == nil {
+		return http.DefaultClient
+	}
+	return d.Client
+}
+
+// token obtains the session ID and the verification token required by the POST requests.
+func (d *Device) token() (session, token string, err error) {
+	var resp struct {
+		SesInfo string `xml:"SesInfo"`
+		TokInfo string `xml:"TokInfo"`
+	}
+	if err = d.get("/api/webserver/SesTokInfo", &resp); err != nil {
+		return
+	}
+	return resp.SesInfo, resp.TokInfo, nil
+}
+
+func (d *Device) get(path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, d.url(path), nil)
+	if err != nil {
+		return err
+	}
+	return d.do(req, v)
+}
+
+func (d *Device) post(path string, body, v interface{}) error {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return err
+	}
+	session, token, err := d.token()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, d.url(path), bytes.NewReader(append([]byte(xml.Header), data...)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
+	if session != "" {
+		req.Header.Set("Cookie", session)
+	}
+	req.Header.Set("__RequestVerificationToken", token)
+	return d.do(req, v)
+}
+
+// do makes the request and decodes the response into v, an error
+// response is returned as *Error.
+func (d *Device) do(req *http.Request, v interface{}) error {
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("hilink: unexpected status: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var reply struct {
+		XMLName xml.Name
+		Code    int    `xml:"code"`
+		Message string `xml:"message"`
+	}
+	if err = xml.Unmarshal(data, &reply); err != nil {
+		return err
+	}
+	if reply.XMLName.Local == "error" {
+		return &Error{Code: reply.Code, Message: reply.Message}
+	}
+	if v == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, v)
+}
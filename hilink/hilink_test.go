@@ -0,0 +1,109 @@
+package hilink
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	var mux sync.Mutex
+	var posted []string
+	replies := map[string]string{
+		"/api/webserver/SesTokInfo": `<response><SesInfo>SessionID=abc</SesInfo><TokInfo>tok</TokInfo></response>`,
+		"/api/device/information":   `<error><code>100003</code><message></message></error>`,
+		"/api/monitoring/status": `<response><ServiceStatus>2</ServiceStatus><ServiceDomain>3</ServiceDomain>` +
+			`<RoamingStatus>0</RoamingStatus><SimStatus>1</SimStatus><CurrentNetworkType>19</CurrentNetworkType></response>`,
+		"/api/net/current-plmn": `<response><FullName>Vodafone</FullName></response>`,
+		"/api/device/signal":    `<response><rssi>-67dBm</rssi></response>`,
+		"/api/sms/send-sms":     `<response>OK</response>`,
+		"/api/sms/delete-sms":   `<response>OK</response>`,
+		"/api/sms/sms-list": `<response><Count>2</Count><Messages>` +
+			`<Message><Smstat>0</Smstat><Index>40001</Index><Phone>+79991234567</Phone>` +
+			`<Content>hello</Content><Date>2021-03-04 10:20:30</Date><Sca></Sca></Message>` +
+			`<Message><Smstat>1</Smstat><Index>40000</Index><Phone>+79991234567</Phone>` +
+			`<Content>old</Content><Date>2021-03-03 10:20:30</Date><Sca></Sca></Message>` +
+			`</Messages></response>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			if r.Header.Get("__RequestVerificationToken") != "tok" {
+				reply = `<error><code>125002</code><message></message></error>`
+			}
+			body, _ := io.ReadAll(r.Body)
+			mux.Lock()
+			posted = append(posted, r.URL.Path+" "+string(body))
+			mux.Unlock()
+		}
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &posted
+}
+
+func TestDevice(t *testing.T) {
+	t.Parallel()
+
+	srv, posted := newTestServer(t)
+	dev := &Device{URL: srv.URL}
+	require.NoError(t, dev.Init())
+
+	state := dev.State()
+	assert.Equal(t, at.ServiceStates.Valid, state.ServiceState)
+	assert.Equal(t, at.SimStates.Valid, state.SimState)
+	assert.Equal(t, at.SystemModes.LTE, state.SystemMode)
+	assert.Equal(t, "Vodafone", state.OperatorName)
+	assert.Equal(t, 23, state.SignalStrength)
+
+	refs, err := dev.SendSMS("hi", "+79997654321")
+	require.NoError(t, err)
+	require.Nil(t, refs)
+	require.NoError(t, dev.FetchInbox())
+	require.Len(t, dev.IncomingSms(), 1)
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "hello", msg.Text)
+	assert.EqualValues(t, "+79991234567", msg.Address)
+
+	require.Len(t, *posted, 3)
+	assert.Contains(t, (*posted)[0], "<Phone>+79997654321</Phone></Phones><Sca></Sca><Content>hi</Content>")
+	assert.True(t, strings.HasPrefix((*posted)[2], "/api/sms/delete-sms"))
+	assert.Contains(t, (*posted)[2], "<Index>40001</Index>")
+	require.NoError(t, dev.Close())
+	require.NoError(t, dev.Watch())
+}
+
+func TestSendEmoji(t *testing.T) {
+	t.Parallel()
+
+	srv, posted := newTestServer(t)
+	dev := &Device{URL: srv.URL}
+	require.NoError(t, dev.Init())
+	// the emoji is a surrogate pair in UCS-2, i.e. two characters
+	_, err := dev.SendSMS("hi 😀", "+79997654321")
+	require.NoError(t, err)
+	require.Len(t, *posted, 1)
+	assert.Contains(t, (*posted)[0], "<Content>hi 😀</Content><Length>5</Length>")
+	require.NoError(t, dev.Close())
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+	dev := &Device{URL: srv.URL}
+	err := dev.get("/api/device/information", nil)
+	require.Error(t, err)
+	assert.Equal(t, 100003, err.(*Error).Code)
+}
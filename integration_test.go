@@ -4,9 +4,11 @@
 package at
 
 import (
+	"log"
 	"testing"
+	"time"
 
-	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/xlab/at/pdu"
 )
 
@@ -57,8 +59,9 @@ func waitDevice(n int) {
 				log.Printf("USSD result: %s", ussd)
 			}
 		case <-dev.StateUpdate():
-			log.Printf("Signal strength: %d (%s/%s)", dev.State.SignalStrength, dev.State.OperatorName,
-				dev.State.SystemSubmode.Description)
+			state := dev.State()
+			log.Printf("Signal strength: %d (%s/%s)", state.SignalStrength, state.OperatorName,
+				state.SystemSubmode.Description)
 		}
 	}
 }
@@ -0,0 +1,134 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeModem emulates the modem side of the command and notification ports.
+type fakeModem struct {
+	t      *testing.T
+	cmd    *os.File
+	notify *os.File
+
+	mux     sync.Mutex
+	replies map[string][][]string
+	sent    []string
+}
+
+// socketPair returns two connected ends of a bidirectional pollable stream.
+func socketPair(t *testing.T, name string) (*os.File, *os.File) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	require.NoError(t, err)
+	for _, fd := range fds {
+		require.NoError(t, syscall.SetNonblock(fd, true))
+	}
+	return os.NewFile(uintptr(fds[0]), name), os.NewFile(uintptr(fds[1]), name+"-modem")
+}
+
+// newTestDevice returns a device with the opened ports connected to a fake modem.
+// The device is initialized with a profile that does nothing on Init.
+func newTestDevice(t *testing.T) (*Device, *fakeModem) {
+	dev, m := openTestDevice(t)
+	require.NoError(t, dev.Init(&nopProfile{}))
+	return dev, m
+}
+
+// openTestDevice returns a device with the opened ports connected to a fake modem.
+func openTestDevice(t *testing.T) (*Device, *fakeModem) {
+	dev := &Device{CommandPort: "cmd", NotifyPort: "notify"}
+	m := &fakeModem{t: t, replies: make(map[string][][]string)}
+	dev.cmdPort, m.cmd = socketPair(t, "cmd")
+	dev.cmdReader = bufio.NewReader(dev.cmdPort)
+	dev.notifyPort, m.notify = socketPair(t, "notify")
+	go m.serve()
+	t.Cleanup(func() {
+		dev.Close()
+		m.cmd.Close()
+		m.notify.Close()
+	})
+	return dev, m
+}
+
+// nopProfile is a DefaultProfile with no-op initialization.
+type nopProfile struct {
+	DefaultProfile
+}
+
+func (p *nopProfile) Init(d *Device) error {
+	p.dev = d
+	return nil
+}
+
+// Reply sets the lines to be sent back when the given command is received.
+// The final result (e.g. OK) must be included explicitly.
+func (m *fakeModem) Reply(cmd string, lines ...string) {
+	m.ReplySequence(cmd, lines)
+}
+
+// ReplySequence sets the replies for the consecutive receipts of the given command,
+// the last reply is repeated for all the following receipts.
+func (m *fakeModem) ReplySequence(cmd string, replies ...[]string) {
+	m.mux.Lock()
+	m.replies[cmd] = replies
+	m.mux.Unlock()
+}
+
+// Sent returns the commands received by the modem.
+func (m *fakeModem) Sent() []string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]string(nil), m.sent...)
+}
+
+// Notify writes the given report to the notification port.
+func (m *fakeModem) Notify(lines ...string) {
+	for _, line := range lines {
+		m.notify.Write([]byte(line + Sep))
+	}
+}
+
+func (m *fakeModem) serve() {
+	buf := bufio.NewReader(m.cmd)
+	for {
+		line, err := buf.ReadString('\r')
+		if err != nil {
+			return
+		}
+		// the escape character that cancels the interactive mode is discarded
+		cmd := strings.TrimLeft(strings.TrimSpace(line), "\x1b")
+		if len(cmd) == 0 {
+			continue
+		}
+		m.mux.Lock()
+		m.sent = append(m.sent, cmd)
+		var lines []string
+		replies, ok := m.replies[cmd]
+		if ok {
+			lines = replies[0]
+			if len(replies) > 1 {
+				m.replies[cmd] = replies[1:]
+			}
+		}
+		m.mux.Unlock()
+		if cmd == KillCmd {
+			continue
+		}
+		if !ok {
+			lines = []string{"ERROR"}
+		}
+		m.cmd.Write([]byte(cmd + Sep))
+		for _, l := range lines {
+			m.cmd.Write([]byte(l + Sep))
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package at
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/xlab/at/sms"
+)
 
 // Opt represents a numerical option.
 type Opt struct {
@@ -62,10 +66,62 @@ type DeviceState struct {
 	SystemMode     Opt
 	SystemSubmode  Opt
 	SimState       Opt
+	Registration   Opt
+	Manufacturer   string
 	ModelName      string
 	OperatorName   string
 	IMEI           string
 	SignalStrength int
+	// ServiceCenter is the SMSC address configured in the device, see AT+CSCA.
+	ServiceCenter sms.PhoneNumber
+	// Charset is the character set selected with AT+CSCS, see Charsets.
+	Charset StringOpt
+
+	// Capabilities contain the capabilities reported by AT+GCAP, e.g. "+CGSM".
+	Capabilities []string
+	// Commands contain the commands reported by AT+CLAC, the list is nil
+	// if the device doesn't report the supported commands.
+	Commands []string
+}
+
+// Supports checks whether the command is reported by the device as supported,
+// the command may include the AT prefix and the parameters, e.g. "AT+CLIP=1".
+// All commands are considered supported if the list is unknown.
+func (s *DeviceState) Supports(cmd string) bool {
+	if s.Commands == nil {
+		return true
+	}
+	cmd = commandName(cmd)
+	for _, c := range s.Commands {
+		if commandName(c) == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a copy of the state that doesn't share the lists.
+func (s *DeviceState) clone() DeviceState {
+	c := *s
+	if s.Capabilities != nil {
+		c.Capabilities = append([]string(nil), s.Capabilities...)
+	}
+	if s.Commands != nil {
+		c.Commands = append([]string(nil), s.Commands...)
+	}
+	return c
+}
+
+// commandName strips the AT prefix and the parameters of the command.
+func commandName(cmd string) string {
+	cmd = strings.ToUpper(strings.TrimSpace(cmd))
+	if len(cmd) > 2 && strings.HasPrefix(cmd, "AT") && strings.IndexByte("+^$#!&%*", cmd[2]) >= 0 {
+		cmd = cmd[2:]
+	}
+	if i := strings.IndexAny(cmd, "=?"); i > 0 {
+		cmd = cmd[:i]
+	}
+	return cmd
 }
 
 // NewDeviceState returns a clean state with unknown options.
@@ -77,6 +133,7 @@ func NewDeviceState() *DeviceState {
 		SystemMode:    UnknownOpt,
 		SystemSubmode: UnknownOpt,
 		SimState:      UnknownOpt,
+		Registration:  UnknownOpt,
 	}
 }
 
@@ -169,6 +226,32 @@ var RoamingStates = struct {
 	roaming[0], roaming[1],
 }
 
+var registration = optMap{
+	0: Opt{0, "Not registered"},
+	1: Opt{1, "Registered, home network"},
+	2: Opt{2, "Not registered, searching"},
+	3: Opt{3, "Registration denied"},
+	4: Opt{4, "Unknown"},
+	5: Opt{5, "Registered, roaming"},
+}
+
+// RegistrationStates represent the possible states of the network registration.
+var RegistrationStates = struct {
+	Resolve func(int) Opt
+
+	NotRegistered Opt
+	Home          Opt
+	Searching     Opt
+	Denied        Opt
+	Unknown       Opt
+	Roaming       Opt
+}{
+	func(id int) Opt { return registration.Resolve(id) },
+
+	registration[0], registration[1], registration[2],
+	registration[3], registration[4], registration[5],
+}
+
 var mode = optMap{
 	0:  Opt{0, "No service"},
 	1:  Opt{1, "AMPS"},
@@ -180,6 +263,7 @@ var mode = optMap{
 	7:  Opt{7, "GSM/WCDMA"},
 	8:  Opt{8, "CDMA/HDR HYBRID"},
 	15: Opt{15, "TD-SCDMA"},
+	17: Opt{17, "LTE"},
 }
 
 // SystemModes represent the possible system operating modes.
@@ -196,11 +280,13 @@ var SystemModes = struct {
 	GsmWcdma  Opt
 	CdmaHdr   Opt
 	SCDMA     Opt
+	LTE       Opt
 }{
 	func(id int) Opt { return mode.Resolve(id) },
 
 	mode[0], mode[1], mode[2], mode[3], mode[4],
 	mode[5], mode[6], mode[7], mode[8], mode[15],
+	mode[17],
 }
 
 var submode = optMap{
@@ -286,6 +372,24 @@ var FinalResults = struct {
 	result[12], result[13],
 }
 
+var intermediate = stringOpts{
+	{"CONNECT", "Connect"},
+	{">", "Prompt"},
+}
+
+// IntermediateResults represent the possible intermediate replies from a modem,
+// the command is not finished when one of these is received.
+var IntermediateResults = struct {
+	Resolve func(string) StringOpt
+
+	Connect StringOpt
+	Prompt  StringOpt
+}{
+	func(str string) StringOpt { return intermediate.Resolve(str) },
+
+	intermediate[0], intermediate[1],
+}
+
 var resultReporting = optMap{
 	0: Opt{0, "Disabled"},
 	1: Opt{1, "Enabled"},
@@ -317,6 +421,12 @@ var reports = stringOpts{
 	{"^SIMST:", "Sim state"},
 	{"^STIN:", "STIN"},
 	{"+CLIP:", "Incoming Caller ID"},
+	{"+CREG:", "Network registration"},
+	{"+CMT:", "Incoming SMS delivered directly"},
+	{"+CDS:", "Status report delivered directly"},
+	{"+CDSI:", "Incoming status report"},
+	{"^SMMEMFULL:", "Message storage is full"},
+	{"+CIEV:", "Indicator event"},
 }
 
 // Reports represent the possible state reports from a modem.
@@ -332,11 +442,19 @@ var Reports = struct {
 	SimState       StringOpt
 	Stin           StringOpt
 	CallerID       StringOpt
+	Registration   StringOpt
+	DirectMessage  StringOpt
+	StatusReport   StringOpt
+	StoredReport   StringOpt
+	StorageFull    StringOpt
+	Indicator      StringOpt
 }{
 	func(str string) StringOpt { return reports.Resolve(str) },
 
 	reports[0], reports[1], reports[2], reports[3],
 	reports[4], reports[5], reports[6], reports[7], reports[8],
+	reports[9], reports[10], reports[11], reports[12],
+	reports[13], reports[14],
 }
 
 var mem = stringOpts{
@@ -360,6 +478,52 @@ var MemoryTypes = struct {
 	mem[0], mem[1], mem[2], mem[3],
 }
 
+var charsets = stringOpts{
+	{"GSM", "GSM 7-bit default alphabet"},
+	{"UCS2", "UCS2 as hex-encoded octets"},
+	{"IRA", "International reference alphabet"},
+}
+
+// Charsets represent the character sets of the strings exchanged with a device, see AT+CSCS.
+var Charsets = struct {
+	Resolve func(string) StringOpt
+
+	GSM  StringOpt
+	UCS2 StringOpt
+	IRA  StringOpt
+}{
+	func(str string) StringOpt { return charsets.Resolve(str) },
+
+	charsets[0], charsets[1], charsets[2],
+}
+
+var pin = stringOpts{
+	{"READY", "Ready"},
+	{"SIM PIN2", "SIM PIN2 is required"},
+	{"SIM PUK2", "SIM PUK2 is required"},
+	{"SIM PIN", "SIM PIN is required"},
+	{"SIM PUK", "SIM PUK is required"},
+	{"PH-SIM PIN", "Phone-to-SIM password is required"},
+	{"PH-NET PIN", "Network personalization password is required"},
+}
+
+// SimPinStates represent the states of the SIM reported by AT+CPIN.
+var SimPinStates = struct {
+	Resolve func(string) StringOpt
+
+	Ready    StringOpt
+	SimPin2  StringOpt
+	SimPuk2  StringOpt
+	SimPin   StringOpt
+	SimPuk   StringOpt
+	PhSimPin StringOpt
+	PhNetPin StringOpt
+}{
+	func(str string) StringOpt { return pin.Resolve(str) },
+
+	pin[0], pin[1], pin[2], pin[3], pin[4], pin[5], pin[6],
+}
+
 var delOpts = optMap{
 	0: Opt{0, "Delete message by index"},
 	1: Opt{1, "Delete all read messages except MO"},
@@ -401,11 +565,147 @@ var MessageFlags = struct {
 	Sent   Opt
 	Any    Opt
 }{
-	func(id int) Opt { return resultReporting.Resolve(id) },
+	func(id int) Opt { return msgFlags.Resolve(id) },
 
 	msgFlags[0], msgFlags[1], msgFlags[2], msgFlags[3], msgFlags[4],
 }
 
+var retention = optMap{
+	0: Opt{0, "Delete immediately"},
+	1: Opt{1, "Delete after acknowledgement"},
+	2: Opt{2, "Keep"},
+}
+
+// RetentionPolicies represent the policies of deleting the incoming messages from
+// the device memory, see Device.Retention.
+var RetentionPolicies = struct {
+	Resolve func(int) Opt
+
+	DeleteImmediately Opt
+	DeleteAfterAck    Opt
+	Keep              Opt
+}{
+	func(id int) Opt { return retention.Resolve(id) },
+
+	retention[0], retention[1], retention[2],
+}
+
+var deliveryTarget = optMap{
+	0: Opt{0, "Message storage"},
+	1: Opt{1, "Terminal equipment"},
+}
+
+// DeliveryTargets represent the possible routes of the incoming messages, the messages are
+// either stored in the memory and reported with +CMTI or routed directly to the TE with +CMT.
+var DeliveryTargets = struct {
+	Resolve func(int) Opt
+
+	Storage Opt
+	TE      Opt
+}{
+	func(id int) Opt { return deliveryTarget.Resolve(id) },
+
+	deliveryTarget[0], deliveryTarget[1],
+}
+
+var bufferFlush = optMap{
+	0: Opt{0, "Flush buffered reports"},
+	1: Opt{1, "Clear buffered reports"},
+}
+
+// BufferFlushPolicies represent what happens to the reports buffered by the modem
+// when the notifications are turned on.
+var BufferFlushPolicies = struct {
+	Resolve func(int) Opt
+
+	Flush Opt
+	Clear Opt
+}{
+	func(id int) Opt { return bufferFlush.Resolve(id) },
+
+	bufferFlush[0], bufferFlush[1],
+}
+
+var deliveryState = optMap{
+	0: Opt{0, "Pending"},
+	1: Opt{1, "Delivered"},
+	2: Opt{2, "Failed"},
+}
+
+// DeliveryStates represent the delivery states of a sent message, see DeliveryTracker.
+var DeliveryStates = struct {
+	Resolve func(int) Opt
+
+	Pending   Opt
+	Delivered Opt
+	Failed    Opt
+}{
+	func(id int) Opt { return deliveryState.Resolve(id) },
+
+	deliveryState[0], deliveryState[1], deliveryState[2],
+}
+
+var moreMessages = optMap{
+	0: Opt{0, "Disabled"},
+	1: Opt{1, "Keep the link open until the next message"},
+	2: Opt{2, "Keep the link open"},
+}
+
+// MoreMessagesModes represent the modes of keeping the radio link open between the
+// consecutive outgoing messages, see AT+CMMS. The link is closed by the network if
+// the next message is not sent within a few seconds, then the mode Once is disabled.
+var MoreMessagesModes = struct {
+	Resolve func(int) Opt
+
+	Disabled Opt
+	Once     Opt
+	Enabled  Opt
+}{
+	func(id int) Opt { return moreMessages.Resolve(id) },
+
+	moreMessages[0], moreMessages[1], moreMessages[2],
+}
+
+var errorReporting = optMap{
+	0: Opt{0, "Disabled"},
+	1: Opt{1, "Numeric"},
+	2: Opt{2, "Verbose"},
+}
+
+// ErrorReportingModes represent the modes of reporting the errors with +CME ERROR, see AT+CMEE.
+// The device replies with the plain ERROR if it's disabled.
+var ErrorReportingModes = struct {
+	Resolve func(int) Opt
+
+	Disabled Opt
+	Numeric  Opt
+	Verbose  Opt
+}{
+	func(id int) Opt { return errorReporting.Resolve(id) },
+
+	errorReporting[0], errorReporting[1], errorReporting[2],
+}
+
+var storageFullPolicy = optMap{
+	0: Opt{0, "Notify"},
+	1: Opt{1, "Delete read messages"},
+	2: Opt{2, "Switch message storage"},
+}
+
+// StorageFullPolicies represent the actions taken when the message storage is full,
+// see Device.StorageFullPolicy. The event is sent over the StorageFull channel anyway.
+var StorageFullPolicies = struct {
+	Resolve func(int) Opt
+
+	Notify       Opt
+	DeleteRead   Opt
+	SwitchMemory Opt
+}{
+	func(id int) Opt { return storageFullPolicy.Resolve(id) },
+
+	storageFullPolicy[0], storageFullPolicy[1], storageFullPolicy[2],
+}
+
 var callerIDType = optMap{
 	129: Opt{129, "Network Specific Caller ID"},
 	145: Opt{145, "International Caller ID"},
@@ -441,3 +741,32 @@ var CallerIDValidityStates = struct {
 
 	callerIDValidity[0], callerIDValidity[1], callerIDValidity[2],
 }
+
+var accessTech = optMap{
+	0: Opt{0, "Automatic"},
+	1: Opt{1, "GSM only"},
+	2: Opt{2, "WCDMA only"},
+	3: Opt{3, "LTE only"},
+	4: Opt{4, "GSM and WCDMA"},
+	5: Opt{5, "WCDMA and LTE"},
+	6: Opt{6, "GSM and LTE"},
+}
+
+// AccessTechnologies represent the radio access technologies that could be selected
+// by the profiles that implement RATSelector.
+var AccessTechnologies = struct {
+	Resolve func(int) Opt
+
+	Auto     Opt
+	GSM      Opt
+	WCDMA    Opt
+	LTE      Opt
+	GsmWcdma Opt
+	WcdmaLte Opt
+	GsmLte   Opt
+}{
+	func(id int) Opt { return accessTech.Resolve(id) },
+
+	accessTech[0], accessTech[1], accessTech[2], accessTech[3],
+	accessTech[4], accessTech[5], accessTech[6],
+}
@@ -0,0 +1,319 @@
+package at
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xlab/at/sms"
+)
+
+// OutgoingMessage represents a message queued in the outbox.
+type OutgoingMessage struct {
+	// ID is assigned by the store when the message is pushed.
+	ID      string
+	Text    string
+	Address sms.PhoneNumber
+	Options SendOptions
+	// Attempts is the number of failed attempts to send the message.
+	Attempts int
+	Created  time.Time
+	// SendAt is the time the message is scheduled for, zero means it's sent right away.
+	SendAt time.Time
+	// Refs are the message references of the parts sent so far and Tag is the concatenation
+	// reference number of the parts. The message that failed after some of its parts were sent
+	// is resumed from the first unsent part with the same Tag, so the parts aren't duplicated.
+	Refs []byte
+	Tag  int
+}
+
+// due returns the time the message should be sent at.
+func (m *OutgoingMessage) due() time.Time {
+	if m.SendAt.IsZero() {
+		return m.Created
+	}
+	return m.SendAt
+}
+
+// OutboxStore keeps the queued messages, a persistent store allows the messages
+// to survive restarts of the process. The store must be safe for concurrent use.
+type OutboxStore interface {
+	// Push appends the message to the queue and assigns its ID.
+	Push(msg *OutgoingMessage) error
+	// Peek returns the message to be sent next or nil if the queue is empty, i.e. the one
+	// scheduled for the earliest time. The messages that aren't scheduled are due when created.
+	Peek() (*OutgoingMessage, error)
+	// Update saves the changed message.
+	Update(msg *OutgoingMessage) error
+	// Remove deletes the message with the given ID from the queue.
+	Remove(id string) error
+}
+
+// MemoryOutboxStore is an OutboxStore that keeps the messages in memory.
+type MemoryOutboxStore struct {
+	mux      sync.Mutex
+	messages []OutgoingMessage
+	lastID   int
+}
+
+// Push implements OutboxStore.
+func (s *MemoryOutboxStore) Push(msg *OutgoingMessage) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.lastID++
+	msg.ID = strconv.Itoa(s.lastID)
+	s.messages = append(s.messages, *msg)
+	return nil
+}
+
+// Peek implements OutboxStore.
+func (s *MemoryOutboxStore) Peek() (*OutgoingMessage, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if len(s.messages) == 0 {
+		return nil, nil
+	}
+	next := 0
+	for i := range s.messages {
+		if s.messages[i].due().Before(s.messages[next].due()) {
+			next = i
+		}
+	}
+	msg := s.messages[next]
+	return &msg, nil
+}
+
+// Update implements OutboxStore.
+func (s *MemoryOutboxStore) Update(msg *OutgoingMessage) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for i := range s.messages {
+		if s.messages[i].ID == msg.ID {
+			s.messages[i] = *msg
+		}
+	}
+	return nil
+}
+
+// Remove implements OutboxStore.
+func (s *MemoryOutboxStore) Remove(id string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for i := range s.messages {
+		if s.messages[i].ID == id {
+			s.messages = append(s.messages[:i], s.messages[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Len returns the number of queued messages.
+func (s *MemoryOutboxStore) Len() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.messages)
+}
+
+// Outbox queues the outgoing messages and sends them with a background worker, see Run.
+// It must be created with NewOutbox.
+// The rate limit and the retry policy of the message submission are the ones of the device.
+// The messages that failed with a transient error or because the device was closed stay
+// in the store, so they are sent once the device is reconnected and Run is called again,
+// the concatenated messages are resumed from the first unsent part.
+// The same applies to the scheduled messages which time came while the device was unavailable.
+type Outbox struct {
+	// Store keeps the queued messages, MemoryOutboxStore is used if it's nil.
+	Store OutboxStore
+	// MaxAttempts is the maximum number of failed attempts before the message is dropped,
+	// zero means no limit.
+	MaxAttempts int
+	// Backoff is the delay before sending a message again after a transient failure.
+	Backoff time.Duration
+	// Transient reports whether a message failed with the given error should stay in the queue.
+	// IsSendTransient is used if it's nil, so the messages that might have been submitted
+	// aren't sent again unless IsSendUnconfirmed errors are opted in.
+	Transient func(err error) bool
+	// MaxDelay is the maximum delay of a scheduled message past its time, e.g. when
+	// the device was unavailable, the message is dropped with ErrExpired if it's exceeded.
+	// Zero means the overdue messages are sent anyway.
+	MaxDelay time.Duration
+	// OnSent is called when a message was sent if not nil, the references of all parts are given.
+	OnSent func(msg *OutgoingMessage, refs []byte)
+	// OnFailed is called when a message was dropped from the queue if not nil,
+	// the Refs of the message are the parts that were sent anyway.
+	OnFailed func(msg *OutgoingMessage, err error)
+
+	dev     *Device
+	initMux sync.Mutex
+	queued  chan struct{}
+}
+
+// NewOutbox returns an outbox that sends the messages using the given device.
+func NewOutbox(dev *Device, store OutboxStore) *Outbox {
+	return &Outbox{
+		Store:   store,
+		Backoff: 10 * time.Second,
+		dev:     dev,
+	}
+}
+
+func (o *Outbox) init() error {
+	if o.dev == nil {
+		return ErrNoDevice
+	}
+	if o.dev.Closed() == nil {
+		return ErrNotInitialized
+	}
+	o.initMux.Lock()
+	defer o.initMux.Unlock()
+	if o.Store == nil {
+		o.Store = &MemoryOutboxStore{}
+	}
+	if o.queued == nil {
+		o.queued = make(chan struct{}, 1)
+	}
+	return nil
+}
+
+// SendSMS queues a message with given text to the given address.
+func (o *Outbox) SendSMS(text string, address sms.PhoneNumber) (id string, err error) {
+	return o.Enqueue(text, address, SendOptions{})
+}
+
+// SendSMSAt queues a message with given text to the given address to be sent at the given time.
+func (o *Outbox) SendSMSAt(text string, address sms.PhoneNumber, at time.Time) (id string, err error) {
+	return o.EnqueueAt(text, address, SendOptions{}, at)
+}
+
+// Enqueue queues a message with given text to the given address using the given options.
+// Returns the ID of the queued message.
+func (o *Outbox) Enqueue(text string, address sms.PhoneNumber, opts SendOptions) (id string, err error) {
+	return o.EnqueueAt(text, address, opts, time.Time{})
+}
+
+// EnqueueAt queues a message with given text to the given address using the given options
+// to be sent at the given time, zero time means it's sent right away.
+// Returns the ID of the queued message.
+func (o *Outbox) EnqueueAt(text string, address sms.PhoneNumber, opts SendOptions,
+	at time.Time) (id string, err error) {
+	if err = o.init(); err != nil {
+		return
+	}
+	msg := &OutgoingMessage{
+		Text:    text,
+		Address: address,
+		Options: opts,
+		Created: time.Now(),
+		SendAt:  at,
+	}
+	if err = o.Store.Push(msg); err != nil {
+		return
+	}
+	select {
+	case o.queued <- struct{}{}:
+	default:
+	}
+	return msg.ID, nil
+}
+
+// Run sends the queued messages until the device is closed.
+func (o *Outbox) Run() error {
+	if err := o.init(); err != nil {
+		return err
+	}
+	for {
+		msg, err := o.Store.Peek()
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			select {
+			case <-o.queued:
+				continue
+			case <-o.dev.Closed():
+				return nil
+			}
+		}
+		if wait := time.Until(msg.SendAt); wait > 0 {
+			// a message scheduled for an earlier time could be queued meanwhile
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-o.queued:
+				timer.Stop()
+			case <-o.dev.Closed():
+				timer.Stop()
+				return nil
+			}
+			continue
+		}
+		if o.MaxDelay > 0 && !msg.SendAt.IsZero() && time.Since(msg.SendAt) > o.MaxDelay {
+			if err = o.Store.Remove(msg.ID); err != nil {
+				return err
+			}
+			if o.OnFailed != nil {
+				o.OnFailed(msg, ErrExpired)
+			}
+			continue
+		}
+		err = o.send(msg)
+		if err == nil {
+			if err = o.Store.Remove(msg.ID); err != nil {
+				return err
+			}
+			if o.OnSent != nil {
+				o.OnSent(msg, msg.Refs)
+			}
+			continue
+		}
+		if errors.Is(err, ErrClosed) {
+			// the parts sent so far are kept to resume the message
+			return o.Store.Update(msg)
+		}
+		msg.Attempts++
+		transient := o.Transient
+		if transient == nil {
+			transient = IsSendTransient
+		}
+		if !transient(err) || o.MaxAttempts > 0 && msg.Attempts >= o.MaxAttempts {
+			if rmErr := o.Store.Remove(msg.ID); rmErr != nil {
+				return rmErr
+			}
+			if o.OnFailed != nil {
+				o.OnFailed(msg, err)
+			}
+			continue
+		}
+		if err = o.Store.Update(msg); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(o.Backoff):
+		case <-o.dev.Closed():
+			return nil
+		}
+	}
+}
+
+// send sends the parts of the message that weren't sent yet, the references
+// of the sent parts are appended to Refs.
+func (o *Outbox) send(msg *OutgoingMessage) error {
+	parts, err := o.dev.textParts(msg.Text, msg.Address, msg.Options)
+	if err != nil {
+		return err
+	}
+	if len(msg.Refs) == 0 {
+		msg.Tag = parts[0].UserDataHeader.Tag
+	}
+	if len(msg.Refs) >= len(parts) {
+		return nil
+	}
+	for i := range parts {
+		parts[i].UserDataHeader.Tag = msg.Tag
+	}
+	refs, err := o.dev.sendTextParts(parts[len(msg.Refs):], msg.Options.MoreToSend)
+	msg.Refs = append(msg.Refs, refs...)
+	return err
+}
@@ -0,0 +1,197 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	dev.SendRetry = NoRetry
+	msg := sms.Message{
+		Text:     "hi",
+		Type:     sms.MessageTypes.Submit,
+		Encoding: sms.Encodings.Gsm7Bit,
+		Address:  "+79997654321",
+		VPFormat: sms.ValidityPeriodFormats.Relative,
+		VP:       sms.ValidityPeriod(24 * time.Hour * 4),
+	}
+	n, octets, err := msg.PDU()
+	require.NoError(t, err)
+	modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+	modem.ReplySequence(fmt.Sprintf("%02X", octets)+Sub,
+		[]string{"+CMS ERROR: 331"},
+		[]string{"+CMGS: 9", "OK"},
+		[]string{"+CMS ERROR: 304"},
+	)
+
+	store := &MemoryOutboxStore{}
+	outbox := NewOutbox(dev, store)
+	outbox.Backoff = time.Millisecond
+	sent := make(chan []byte, 1)
+	failed := make(chan error, 1)
+	outbox.OnSent = func(_ *OutgoingMessage, refs []byte) { sent <- refs }
+	outbox.OnFailed = func(_ *OutgoingMessage, err error) { failed <- err }
+	go outbox.Run()
+
+	id, err := outbox.SendSMS("hi", "+79997654321")
+	require.NoError(t, err)
+	assert.Equal(t, "1", id)
+	select {
+	case refs := <-sent:
+		assert.Equal(t, []byte{9}, refs)
+	case <-time.After(time.Second):
+		t.Fatal("the message was not sent")
+	}
+
+	_, err = outbox.SendSMS("hi", "+79997654321")
+	require.NoError(t, err)
+	select {
+	case err := <-failed:
+		var cms *CmsError
+		require.ErrorAs(t, err, &cms)
+		assert.Equal(t, 304, cms.Code)
+	case <-time.After(time.Second):
+		t.Fatal("the message was not dropped")
+	}
+	assert.Zero(t, store.Len())
+}
+
+func TestOutboxScheduled(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	for i, text := range []string{"later", "now"} {
+		msg := sms.Message{
+			Text:     text,
+			Type:     sms.MessageTypes.Submit,
+			Encoding: sms.Encodings.Gsm7Bit,
+			Address:  "+79997654321",
+			VPFormat: sms.ValidityPeriodFormats.Relative,
+			VP:       sms.ValidityPeriod(24 * time.Hour * 4),
+		}
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+		modem.Reply(fmt.Sprintf("%02X", octets)+Sub, fmt.Sprintf("+CMGS: %d", i+1), "OK")
+	}
+
+	store := &MemoryOutboxStore{}
+	outbox := NewOutbox(dev, store)
+	outbox.MaxDelay = time.Minute
+	sent := make(chan string, 2)
+	failed := make(chan error, 1)
+	outbox.OnSent = func(msg *OutgoingMessage, _ []byte) { sent <- msg.Text }
+	outbox.OnFailed = func(_ *OutgoingMessage, err error) { failed <- err }
+
+	// the device was unavailable at the time of this one
+	_, err := outbox.SendSMSAt("expired", "+79997654321", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	_, err = outbox.SendSMSAt("later", "+79997654321", time.Now().Add(100*time.Millisecond))
+	require.NoError(t, err)
+	go outbox.Run()
+	select {
+	case err := <-failed:
+		assert.Equal(t, ErrExpired, err)
+	case <-time.After(time.Second):
+		t.Fatal("the message was not dropped")
+	}
+	_, err = outbox.SendSMS("now", "+79997654321")
+	require.NoError(t, err)
+
+	for _, text := range []string{"now", "later"} {
+		select {
+		case got := <-sent:
+			assert.Equal(t, text, got)
+		case <-time.After(time.Second):
+			t.Fatal("the message was not sent")
+		}
+	}
+	assert.Zero(t, store.Len())
+}
+
+func TestOutboxResume(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	dev.SendRetry = NoRetry
+	text := strings.Repeat("0123456789", 20)
+	var pdus []string
+	for i, part := range []string{text[:153], text[153:]} {
+		msg := sms.Message{
+			Text:                     part,
+			Type:                     sms.MessageTypes.Submit,
+			Encoding:                 sms.Encodings.Gsm7Bit,
+			Address:                  "+79997654321",
+			VPFormat:                 sms.ValidityPeriodFormats.Relative,
+			VP:                       sms.ValidityPeriod(24 * time.Hour * 4),
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           sms.UserDataHeader{TotalNumber: 2, Sequence: i + 1, Tag: 1},
+		}
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGS=%d", n), "> ")
+		pdus = append(pdus, fmt.Sprintf("%02X", octets)+Sub)
+	}
+	modem.Reply("AT+CMMS=1", "OK")
+	modem.Reply(pdus[0], "+CMGS: 20", "OK")
+	// the second part fails with a transient error once
+	modem.ReplySequence(pdus[1],
+		[]string{"+CMS ERROR: 331"},
+		[]string{"+CMGS: 21", "OK"},
+	)
+
+	store := &MemoryOutboxStore{}
+	outbox := NewOutbox(dev, store)
+	outbox.Backoff = time.Millisecond
+	sent := make(chan *OutgoingMessage, 1)
+	outbox.OnSent = func(msg *OutgoingMessage, _ []byte) { sent <- msg }
+	go outbox.Run()
+
+	_, err := outbox.SendSMS(text, "+79997654321")
+	require.NoError(t, err)
+	select {
+	case msg := <-sent:
+		assert.Equal(t, []byte{20, 21}, msg.Refs)
+		assert.Equal(t, 1, msg.Attempts)
+	case <-time.After(time.Second):
+		t.Fatal("the message was not sent")
+	}
+	// the first part isn't sent again
+	var first, second int
+	for _, cmd := range modem.Sent() {
+		switch cmd {
+		case pdus[0]:
+			first++
+		case pdus[1]:
+			second++
+		}
+	}
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+}
+
+func TestOutboxNoDevice(t *testing.T) {
+	t.Parallel()
+
+	var outbox Outbox
+	_, err := outbox.SendSMS("hi", "+79997654321")
+	assert.ErrorIs(t, err, ErrNoDevice)
+	assert.ErrorIs(t, outbox.Run(), ErrNoDevice)
+
+	// the device wasn't initialized, so it's never closed
+	_, err = NewOutbox(&Device{}, nil).SendSMS("hi", "+79997654321")
+	assert.ErrorIs(t, err, ErrNotInitialized)
+	assert.ErrorIs(t, NewOutbox(&Device{}, nil).Run(), ErrNotInitialized)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -25,61 +27,256 @@ const (
 // ErrUnexpectedByte happens when someone tries to decode non GSM 7-bit encoded string.
 var ErrUnexpectedByte = errors.New("7bit decode: met an unexpected byte")
 
+// ErrUnexpectedRune happens when the text can't be encoded in strict mode, see Encode7BitStrict.
+var ErrUnexpectedRune = errors.New("7bit encode: met an unencodable character")
+
 // Is7BitEncodable reports whether s can be encoded using GSM 7-bit
 // encoding with default alphabet, without replacing or omitting characters.
 func Is7BitEncodable(s string) bool {
-	for _, r := range s {
-		if i := gsmTable.Index(r); i < 0 {
-			if gsmEscapes.to7Bit(r) == byte(unknown) {
-				return false
-			}
+	return is7BitEncodable(s, &gsmTable, gsmEscapes)
+}
+
+func is7BitEncodable(s string, table *runeTable, escapes escapeTable) bool {
+	_, invalid := check7Bit(s, table, escapes)
+	return invalid < 0
+}
+
+// Len7Bit returns the number of septets required to encode the given text
+// using GSM 7-bit encoding, the characters of the shift table take two septets.
+func Len7Bit(str string) (n int) {
+	return len7Bit(str, &gsmTable, gsmEscapes)
+}
+
+func len7Bit(str string, table *runeTable, escapes escapeTable) (n int) {
+	n, _ = check7Bit(str, table, escapes)
+	return
+}
+
+// Check7Bit combines Len7Bit and Is7BitEncodable in a single pass over the text, e.g. to choose
+// the encoding and split the text at once. The invalid is the byte offset of the first character
+// that can't be encoded, it's -1 if all of them can. Such characters take a septet each since
+// they're replaced with "?".
+func Check7Bit(str string) (septets, invalid int) {
+	return check7Bit(str, &gsmTable, gsmEscapes)
+}
+
+func check7Bit(str string, table *runeTable, escapes escapeTable) (septets, invalid int) {
+	invalid = -1
+	for i, r := range str {
+		septets++
+		if table.Index(r) >= 0 {
+			continue
+		}
+		if _, ok := escapes.to7Bit(r); ok {
+			septets++
+		} else if invalid < 0 {
+			invalid = i
 		}
 	}
-	return true
+	return
 }
 
 // Encode7Bit encodes the given UTF-8 text into GSM 7-bit (3GPP TS 23.038)
 // encoding with packing. Invalid characters outside the 7-bit encoding
 // and shift table are replaced with "?".
 func Encode7Bit(str string) []byte {
-	raw7 := make([]byte, 0, len(str))
-	for _, r := range str {
-		if i := gsmTable.Index(r); i >= 0 {
-			raw7 = append(raw7, byte(i))
-		} else {
-			b := gsmEscapes.to7Bit(r)
-			if b == byte(unknown) {
-				raw7 = append(raw7, b)
-			} else {
-				raw7 = append(raw7, Esc, b)
+	return encode7Bit(make([]byte, 0, len(str)+1), str, 0, &gsmTable, gsmEscapes)
+}
+
+// AppendEncode7Bit is like Encode7Bit, but appends the octets to dst and returns the extended
+// buffer, e.g. to reuse the buffer for many messages.
+func AppendEncode7Bit(dst []byte, str string) []byte {
+	return encode7Bit(dst, str, 0, &gsmTable, gsmEscapes)
+}
+
+// Encode7BitStrict is like Encode7Bit, but fails with ErrUnexpectedRune on the first character
+// that can't be encoded instead of replacing it, the error tells the character and its byte offset.
+func Encode7BitStrict(str string) ([]byte, error) {
+	var p packer
+	octets, invalid := p.encode(make([]byte, 0, len(str)+1), str, &gsmTable, gsmEscapes, nil)
+	if invalid >= 0 {
+		r, _ := utf8.DecodeRuneInString(str[invalid:])
+		return nil, fmt.Errorf("%w: %q at offset %d", ErrUnexpectedRune, r, invalid)
+	}
+	return p.flush(octets), nil
+}
+
+// Encode7BitReplace is like Encode7Bit, but replaces the characters that can't be encoded
+// with the given one, e.g. ' ' or '¿'. The replacement is "?" if it can't be encoded itself.
+// Len7Bit counts the replaced characters as "?", so the replacement of the extension table
+// takes one septet more each.
+func Encode7BitReplace(str string, replacement rune) []byte {
+	repl := runeSeptets(replacement, &gsmTable, gsmEscapes)
+	if repl == nil {
+		repl = []byte{byte(unknown)}
+	}
+	var p packer
+	octets, _ := p.encode(make([]byte, 0, len(str)+1), str, &gsmTable, gsmEscapes, repl)
+	return p.flush(octets)
+}
+
+// encode7Bit appends the packed septets of the text that follow the fill bits to dst,
+// the characters that can't be encoded are replaced with "?".
+func encode7Bit(dst []byte, str string, fill int, table *runeTable, escapes escapeTable) []byte {
+	p := packer{bits: uint(fill % 8)}
+	dst, _ = p.encode(dst, str, table, escapes, []byte{byte(unknown)})
+	return p.flush(dst)
+}
+
+// runeSeptets returns the septets of the character, nil if it can't be encoded.
+func runeSeptets(r rune, table *runeTable, escapes escapeTable) []byte {
+	if i := table.Index(r); i >= 0 {
+		return []byte{byte(i)}
+	}
+	if b, ok := escapes.to7Bit(r); ok {
+		return []byte{Esc, b}
+	}
+	return nil
+}
+
+// packer packs the septets into octets as they're added, see Pack7Bit.
+type packer struct {
+	acc  uint // the bits that don't fill an octet yet
+	bits uint // the number of bits in acc
+	last byte // the last septet
+	n    int  // the number of septets
+}
+
+// add appends the octets filled up by the septet to dst.
+func (p *packer) add(dst []byte, b byte) []byte {
+	p.acc |= uint(b&0x7F) << p.bits
+	for p.bits += 7; p.bits >= 8; p.bits -= 8 {
+		dst = append(dst, byte(p.acc))
+		p.acc >>= 8
+	}
+	p.last = b
+	p.n++
+	return dst
+}
+
+// encode adds the septets of the text, the characters that can't be encoded are replaced
+// with the given septets. The byte offset of the first such character is returned if there's
+// no replacement, the rest of the text isn't encoded then. It's -1 if all of them are encoded.
+func (p *packer) encode(dst []byte, str string, table *runeTable, escapes escapeTable,
+	repl []byte) ([]byte, int) {
+	for i, r := range str {
+		if j := table.Index(r); j >= 0 {
+			dst = p.add(dst, byte(j))
+		} else if b, ok := escapes.to7Bit(r); ok {
+			dst = p.add(p.add(dst, Esc), b)
+		} else if repl != nil {
+			for _, b := range repl {
+				dst = p.add(dst, b)
 			}
+		} else {
+			return dst, i
 		}
 	}
-	return pack7Bit(raw7)
+	return dst, -1
+}
+
+// flush appends the padding and the last octet to dst.
+func (p *packer) flush(dst []byte) []byte {
+	// N.B. in order to not confuse 7 zero-bits with @
+	// <CR> code is added to the packed bits,
+	// and if data ends with <CR> on the octet boundary,
+	// then we add an additional octet with <CR>. See (3GPP TS 23.038).
+	if p.bits == 1 || p.bits == 0 && p.n > 0 && p.last == CR {
+		dst = p.add(dst, CR)
+	}
+	if p.bits > 0 {
+		dst = append(dst, byte(p.acc))
+		p.acc, p.bits = 0, 0
+	}
+	return dst
 }
 
 // Decode7Bit decodes the given GSM 7-bit packed octet data (3GPP TS 23.038)
-// into an UTF-8 encoded string.
+// into an UTF-8 encoded string. As the specification requires, the escaped septet
+// missing from the extension table is decoded as the character of the default alphabet,
+// while the escape to another extension table and the dangling escape are decoded as space.
+//
+// The trailing <CR> that might be padding is removed, see Encode7Bit, use Decode7BitLen
+// if the number of septets is known.
 func Decode7Bit(octets []byte) (str string, err error) {
-	raw7 := unpack7Bit(octets)
-	var escaped bool
-	var r rune
-	for _, b := range raw7 {
+	return decode7Bit(octets, 0, -1, &gsmTable, gsmEscapes)
+}
+
+// Decode7BitLen is like Decode7Bit, but decodes the given number of septets exactly, e.g. the
+// trailing '@' isn't taken for the zero padding. The septets missing from the octets are ignored.
+func Decode7BitLen(octets []byte, septets int) (string, error) {
+	return decode7Bit(octets, 0, septets, &gsmTable, gsmEscapes)
+}
+
+// Decode7BitCBS is like Decode7Bit, but removes all the trailing <CR>, since the cell broadcast
+// pages are filled up with <CR> after the text (3GPP TS 23.038, section 6.1.2.2). The <CR>
+// that follows the escape is kept.
+func Decode7BitCBS(octets []byte) (string, error) {
+	raw7 := Unpack7Bit(octets, 0)
+	for n := len(raw7); n > 0 && raw7[n-1] == CR && !(n > 1 && raw7[n-2] == Esc); n-- {
+		raw7 = raw7[:n-1]
+	}
+	var str strings.Builder
+	str.Grow(len(raw7))
+	err := decodeSeptets(&str, raw7, &gsmTable, gsmEscapes)
+	return str.String(), err
+}
+
+// Decode7BitUSSD is like Decode7Bit, but follows the packing of the USSD strings (3GPP TS 23.038,
+// section 6.1.2.3.1) that doesn't tell the number of characters. Besides the <CR> padding, the
+// 7 spare bits that some networks leave as zeros are removed, so the text doesn't end with '@'.
+func Decode7BitUSSD(octets []byte) (string, error) {
+	raw7 := Unpack7Bit(octets, 0)
+	if n := len(raw7); n > 0 && n%8 == 0 && raw7[n-1] == 0x00 {
+		raw7 = raw7[:n-1]
+	} else {
+		raw7 = trimPadding(raw7, n, 0)
+	}
+	var str strings.Builder
+	str.Grow(len(raw7))
+	err := decodeSeptets(&str, raw7, &gsmTable, gsmEscapes)
+	return str.String(), err
+}
+
+// decode7Bit decodes the septets that follow the fill bits, the negative number
+// of septets means the number is unknown.
+func decode7Bit(octets []byte, fill, septets int, table *runeTable, escapes escapeTable) (string, error) {
+	raw7 := Unpack7Bit(octets, fill)
+	if septets >= 0 {
+		if septets < len(raw7) {
+			raw7 = raw7[:septets]
+		}
+	} else {
+		raw7 = trimPadding(raw7, len(raw7), fill)
+	}
+	var str strings.Builder
+	str.Grow(len(raw7))
+	err := decodeSeptets(&str, raw7, table, escapes)
+	return str.String(), err
+}
+
+// decodeSeptets writes the characters of the unpacked septets to the builder.
+func decodeSeptets(str *strings.Builder, raw7 []byte, table *runeTable, escapes escapeTable) error {
+	for i := 0; i < len(raw7); i++ {
+		b := raw7[i]
 		if b > max {
-			err = ErrUnexpectedByte
-			return
-		} else if escaped {
-			r = gsmEscapes.from7Bit(b)
-			escaped = false
-		} else if b == Esc {
-			escaped = true
+			return ErrUnexpectedByte
+		}
+		if b != Esc {
+			str.WriteRune(table.Rune(int(b)))
 			continue
-		} else {
-			r = gsmTable.Rune(int(b))
 		}
-		str += string(r)
+		if i++; i == len(raw7) || raw7[i] == Esc {
+			str.WriteByte(' ')
+			continue
+		}
+		r := escapes.from7Bit(raw7[i])
+		if r == unknown {
+			r = table.Rune(int(raw7[i]))
+		}
+		str.WriteRune(r)
 	}
-	return
+	return nil
 }
 
 func pad(n, block int) int {
@@ -96,56 +293,55 @@ func blocks(n, block int) int {
 	return n/block + 1
 }
 
-func pack7Bit(raw7 []byte) []byte {
-	pack7 := make([]byte, blocks(len(raw7)*7, 8))
-	pack := func(out []byte, b byte, oct int, bit uint8) (int, uint8) {
-		for i := uint8(0); i < 7; i++ {
-			out[oct] |= b >> i & 1 << bit
-			bit++
-			if bit == 8 {
-				oct++
-				bit = 0
-			}
-		}
-		return oct, bit
-	}
-	var oct int   // current octet in pack7
-	var bit uint8 // current bit in octet
-	var b byte    // current byte in raw7
-	for i := range raw7 {
-		b = raw7[i]
-		oct, bit = pack(pack7, b, oct, bit)
+// Pack7Bit packs the septets into octets starting at the given bit offset, the bits before it are
+// zero, e.g. to pack the text of a cell broadcast page or a SIM toolkit string that isn't encoded
+// with the GSM default alphabet. The high bit of the septets is ignored. The last 7 bits of the
+// octets and the <CR> that ends on the octet boundary are padded with <CR> (3GPP TS 23.038).
+func Pack7Bit(raw7 []byte, offset int) []byte {
+	p := packer{bits: uint(offset % 8)}
+	pack7 := make([]byte, offset/8, blocks(offset+len(raw7)*7, 8)+1)
+	for _, b := range raw7 {
+		pack7 = p.add(pack7, b)
 	}
-	// N.B. in order to not confuse 7 zero-bits with @
-	// <CR> code is added to the packed bits.
-	if 8-bit == 7 {
-		pack(pack7, CR, oct, bit)
-	} else if bit == 0 && b == CR {
-		// and if data ends with <CR> on the octet boundary,
-		// then we add an additional octet with <CR>. See (3GPP TS 23.038).
-		pack7 = append(pack7, 0x00)
-		pack(pack7, CR, oct, bit)
+	return p.flush(pack7)
+}
+
+// Unpack7Bit unpacks the septets that start at the given bit offset of the octets, see Pack7Bit.
+// The padding is kept, since it can't be told from the <CR> of the text without the number
+// of septets.
+func Unpack7Bit(pack7 []byte, offset int) []byte {
+	if offset/8 >= len(pack7) {
+		return []byte{}
 	}
-	return pack7
-}
-
-func unpack7Bit(pack7 []byte) []byte {
-	raw7 := make([]byte, 0, len(pack7))
-	var sep byte  // current septet
-	var bit uint8 // current bit in septet
-	for _, oct := range pack7 {
-		for i := uint8(0); i < 8; i++ {
-			sep |= oct >> i & 1 << bit
-			bit++
-			if bit == 7 {
-				raw7 = append(raw7, sep)
-				sep = 0
-				bit = 0
-			}
+	pack7 = pack7[offset/8:]
+	fill := offset % 8
+	raw7 := make([]byte, 0, len(pack7)*8/7+1)
+	var acc uint  // the bits that don't fill a septet yet
+	var bits uint // the number of bits in acc
+	for j, oct := range pack7 {
+		acc |= uint(oct) << bits
+		bits += 8
+		if j == 0 {
+			acc >>= uint(fill)
+			bits -= uint(fill)
+		}
+		for ; bits >= 7; bits -= 7 {
+			raw7 = append(raw7, byte(acc&0x7F))
+			acc >>= 7
 		}
 	}
-	if bytes.HasSuffix(raw7, crcr) || bytes.HasSuffix(raw7, cr) {
-		raw7 = raw7[:len(raw7)-1]
+	return raw7
+}
+
+// trimPadding removes the <CR> that pads the last 7 bits of the septets unpacked after the fill bits
+// and the one added to the <CR> that ends on the octet boundary, see Pack7Bit. The raw7 are the last
+// of the n septets unpacked.
+func trimPadding(raw7 []byte, n, fill int) []byte {
+	switch {
+	case (fill+n*7)%8 == 0 && bytes.HasSuffix(raw7, cr):
+		return raw7[:len(raw7)-1]
+	case (fill+(n-1)*7)%8 == 0 && bytes.HasSuffix(raw7, crcr):
+		return raw7[:len(raw7)-1]
 	}
 	return raw7
 }
@@ -167,18 +363,20 @@ type escape struct {
 	to   rune
 }
 
-type escapeTable [0x0A]escape
+type escapeTable []escape
 
-func (et *escapeTable) to7Bit(r rune) byte {
+// to7Bit returns the septet of the character, ok is false if there's none,
+// e.g. the septet 0x3F of the Urdu table is a character.
+func (et escapeTable) to7Bit(r rune) (b byte, ok bool) {
 	for _, esc := range et {
 		if esc.to == r {
-			return esc.from
+			return esc.from, true
 		}
 	}
-	return byte(unknown)
+	return 0, false
 }
 
-func (et *escapeTable) from7Bit(b byte) rune {
+func (et escapeTable) from7Bit(b byte) rune {
 	for _, esc := range et {
 		if esc.from == b {
 			return esc.to
@@ -202,9 +400,51 @@ var gsmEscapes = escapeTable{
 
 type runeTable [0x80]rune
 
+// runeIndex is the reverse lookup of a table, the ASCII characters are looked up
+// in the array and the rest of them in the map.
+type runeIndex struct {
+	ascii [0x80]int8
+	other map[rune]byte
+}
+
+// runeIndexes are the indexes of the known tables, Index scans the other ones.
+var runeIndexes = map[*runeTable]*runeIndex{}
+
+func init() {
+	for _, rt := range []*runeTable{&gsmTable, &turkishTable, &portugueseTable, &bengaliTable, &gujaratiTable,
+		&hindiTable, &kannadaTable, &malayalamTable, &oriyaTable, &punjabiTable, &tamilTable, &teluguTable,
+		&urduTable} {
+		index := &runeIndex{other: make(map[rune]byte)}
+		for i := range index.ascii {
+			index.ascii[i] = -1
+		}
+		// the first of the equal characters is found as the scan does
+		for i := len(rt) - 1; i >= 0; i-- {
+			switch r := rt[i]; {
+			case byte(i) == Esc, r == reserved:
+			case r < 0x80:
+				index.ascii[r] = int8(i)
+			default:
+				index.other[r] = byte(i)
+			}
+		}
+		runeIndexes[rt] = index
+	}
+}
+
 func (rt *runeTable) Index(r rune) int {
+	if index, ok := runeIndexes[rt]; ok {
+		if r >= 0 && r < 0x80 {
+			return int(index.ascii[r])
+		}
+		if i, ok := index.other[r]; ok {
+			return int(i)
+		}
+		return -1
+	}
 	for i, c := range rt {
-		if c == r {
+		// the escape code and the reserved septets don't stand for a character
+		if c == r && byte(i) != Esc && c != reserved {
 			return i
 		}
 	}
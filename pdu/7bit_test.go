@@ -1,17 +1,24 @@
 package pdu
 
 import (
+	"bytes"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/xlab/at/util"
 )
 
 func TestIs7BitEncodable(t *testing.T) {
 	t.Parallel()
 
-	for _, r := range gsmTable {
+	for i, r := range gsmTable {
+		if byte(i) == Esc {
+			// the escape code doesn't stand for a character
+			continue
+		}
 		ok := Is7BitEncodable(string(r))
 		assert.True(t, ok, "'%c' should be 7bit-encodable, but wasn't", r)
 	}
@@ -64,7 +71,7 @@ func TestPack7Bit(t *testing.T) {
 
 	raw7 := []byte{Esc, 0x3c, Esc, 0x3e}
 	exp := []byte{0x1b, 0xde, 0xc6, 0x7}
-	assert.Equal(t, exp, pack7Bit(raw7))
+	assert.Equal(t, exp, Pack7Bit(raw7, 0))
 }
 
 func TestUnpack7Bit(t *testing.T) {
@@ -72,5 +79,280 @@ func TestUnpack7Bit(t *testing.T) {
 
 	pack7 := []byte{0x1b, 0xde, 0xc6, 0x7}
 	exp := []byte{Esc, 0x3c, Esc, 0x3e}
-	assert.Equal(t, exp, unpack7Bit(pack7))
+	assert.Equal(t, exp, Unpack7Bit(pack7, 0))
+}
+
+func TestPack7BitOffset(t *testing.T) {
+	t.Parallel()
+
+	raw7 := []byte{0x68, 0x69, 0x20, 0x7F, 0x00, 0x41}
+	for offset := 0; offset < 20; offset++ {
+		pack7 := Pack7Bit(raw7, offset)
+		assert.Len(t, pack7, (offset+len(raw7)*7+7)/8, "offset %d", offset)
+		assert.Equal(t, make([]byte, offset/8), pack7[:offset/8], "offset %d", offset)
+		out := Unpack7Bit(pack7, offset)
+		assert.Equal(t, raw7, out[:len(raw7)], "offset %d", offset)
+	}
+	assert.Equal(t, Pack7Bit(raw7, 3), Pack7Bit([]byte{0xE8, 0x69, 0xA0, 0xFF, 0x80, 0x41}, 3))
+	assert.Equal(t, []byte{0x68, 0x69}, Unpack7Bit(Pack7Bit([]byte{0x68, 0x69}, 9), 9)[:2])
+	assert.Empty(t, Unpack7Bit([]byte{0x68}, 8))
+}
+
+func TestDecode7BitCBS(t *testing.T) {
+	t.Parallel()
+
+	// the page of 82 octets holds 93 septets
+	page := func(text string) []byte {
+		raw7 := bytes.Repeat([]byte{CR}, 93)
+		copy(raw7, Unpack7Bit(Encode7Bit(text), 0)[:Len7Bit(text)])
+		return Pack7Bit(raw7, 0)
+	}
+	for _, tc := range []struct {
+		text, exp string
+	}{
+		{"", ""},
+		{"Storm warning", "Storm warning"},
+		{"Storm\r\rwarning\r", "Storm\r\rwarning"},
+		{"{tornado} @", "{tornado} @"},
+		{strings.Repeat("1234567", 13) + "12", strings.Repeat("1234567", 13) + "12"},
+	} {
+		pack7 := page(tc.text)
+		require.Len(t, pack7, 82)
+		str, err := Decode7BitCBS(pack7)
+		require.NoError(t, err)
+		assert.Equal(t, tc.exp, str, "%q", tc.text)
+	}
+
+	str, err := Decode7BitCBS(Pack7Bit([]byte{0x41, Esc, CR, CR, CR}, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "A\r", str)
+}
+
+func TestDecode7BitUSSD(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{"", "Balance", "Balance: 10", "1234567", "12345678", "123456\r", "1234567\r", "@home @"} {
+		str, err := Decode7BitUSSD(Encode7Bit(text))
+		require.NoError(t, err)
+		assert.Equal(t, text, str)
+	}
+
+	// the network leaves the spare bits of the 8n-1 characters as zeros
+	pack7 := Pack7Bit(Unpack7Bit(Encode7Bit("Balance: 1.50$ "), 0)[:15], 0)
+	require.Len(t, pack7, 14)
+	pack7[13] &= 0x01
+	str, err := Decode7BitUSSD(pack7)
+	require.NoError(t, err)
+	assert.Equal(t, "Balance: 1.50$ ", str)
+}
+
+func TestGsmAlphabetRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for i, r := range gsmTable {
+		if byte(i) == Esc {
+			continue
+		}
+		assert.Equal(t, 1, Len7Bit(string(r)), "%U", r)
+		assert.Equal(t, Pack7Bit([]byte{byte(i)}, 0), Encode7Bit(string(r)), "%U", r)
+		str, err := Decode7Bit(Encode7Bit(string(r)))
+		require.NoError(t, err)
+		assert.Equal(t, string(r), str, "%U", r)
+	}
+	for _, esc := range gsmEscapes {
+		assert.Equal(t, 2, Len7Bit(string(esc.to)), "%U", esc.to)
+		assert.Equal(t, Pack7Bit([]byte{Esc, esc.from}, 0), Encode7Bit(string(esc.to)), "%U", esc.to)
+		str, err := Decode7Bit(Encode7Bit(string(esc.to)))
+		require.NoError(t, err)
+		assert.Equal(t, string(esc.to), str, "%U", esc.to)
+	}
+
+	// all of the characters at once, the escaped ones are interleaved
+	var all []rune
+	for i, r := range gsmTable {
+		if byte(i) != Esc {
+			all = append(all, r)
+		}
+		if i < len(gsmEscapes) {
+			all = append(all, gsmEscapes[i].to)
+		}
+	}
+	str, err := Decode7Bit(Encode7Bit(string(all)))
+	require.NoError(t, err)
+	assert.Equal(t, string(all), str)
+	assert.Equal(t, len(all)+len(gsmEscapes), Len7Bit(string(all)))
+
+	assert.Equal(t, "ΔΦΓΛΩΠΨΣΘΞ€^{}[]~|\\\f", decodeRaw(t, 0x10, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1A,
+		Esc, 0x65, Esc, 0x14, Esc, 0x28, Esc, 0x29, Esc, 0x3C, Esc, 0x3E, Esc, 0x3D, Esc, 0x40, Esc, 0x2F, Esc, 0x0A))
+}
+
+func TestDecode7BitEscapes(t *testing.T) {
+	t.Parallel()
+
+	// the escaped septet missing from the extension table is the one of the default alphabet
+	assert.Equal(t, "aA", decodeRaw(t, Esc, 0x61, 0x41))
+	// the escape to another extension table
+	assert.Equal(t, " a", decodeRaw(t, Esc, Esc, 0x61))
+	// the dangling escape
+	assert.Equal(t, "a ", decodeRaw(t, 0x61, Esc))
+
+	// the escape code isn't the no-break space
+	assert.False(t, Is7BitEncodable(" "))
+	assert.Equal(t, Pack7Bit([]byte{byte(unknown)}, 0), Encode7Bit(" "))
+}
+
+func decodeRaw(t *testing.T, raw7 ...byte) string {
+	t.Helper()
+	str, err := Decode7Bit(Pack7Bit(raw7, 0))
+	require.NoError(t, err)
+	return str
+}
+
+func TestFill7Bit(t *testing.T) {
+	t.Parallel()
+
+	// the text that follows the 4-octet user data header, the 7 spare bits are padded with <CR>
+	octets := Encode7BitWithFill("hi", 3, Languages.Default, Languages.Default)
+	assert.Equal(t, []byte{0x40, 0xA7, 0x1B}, octets)
+
+	for fill := 0; fill < 7; fill++ {
+		for _, str := range []string{"", "hi", "hello\r", "1234567\r", "{hello world}"} {
+			octets := Encode7BitWithFill(str, fill, Languages.Default, Languages.Default)
+			out, err := Decode7BitWithFill(octets, fill, Languages.Default, Languages.Default)
+			require.NoError(t, err)
+			assert.Equal(t, str, out, "%q with %d fill bits", str, fill)
+			out, err = Decode7BitWithFillLen(octets, fill, Len7Bit(str), Languages.Default, Languages.Default)
+			require.NoError(t, err)
+			assert.Equal(t, str, out, "%q with %d fill bits", str, fill)
+		}
+	}
+}
+
+func TestDecode7BitLen(t *testing.T) {
+	t.Parallel()
+
+	// the 7 spare bits are zeroes instead of <CR>
+	octets := util.MustBytes("31D98C56B30100")
+	str, err := Decode7Bit(octets)
+	require.NoError(t, err)
+	assert.Equal(t, "123456@@", str)
+	str, err = Decode7BitLen(octets, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "123456@", str)
+
+	for _, tc := range []struct {
+		str     string
+		septets int
+	}{
+		{"1234567@", 8},
+		{"1234567\r", 8},
+		{"123456\r", 7},
+		{"hello", 3},
+		{"hello", 10},
+	} {
+		str, err := Decode7BitLen(Encode7Bit(tc.str), tc.septets)
+		require.NoError(t, err)
+		exp := tc.str
+		if tc.septets < len(exp) {
+			exp = exp[:tc.septets]
+		}
+		assert.Equal(t, exp, str)
+	}
+}
+
+func TestCheck7Bit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		str     string
+		septets int
+		invalid int
+	}{
+		{"", 0, -1},
+		{"hello", 5, -1},
+		{"{hi}", 6, -1},
+		{"€1 ü", 5, -1},
+		{"hi →{", 6, 3},
+		{"→→", 2, 0},
+	} {
+		septets, invalid := Check7Bit(tc.str)
+		assert.Equal(t, tc.septets, septets, tc.str)
+		assert.Equal(t, tc.invalid, invalid, tc.str)
+		assert.Equal(t, tc.septets, Len7Bit(tc.str), tc.str)
+		assert.Equal(t, tc.invalid < 0, Is7BitEncodable(tc.str), tc.str)
+	}
+
+	_, invalid := Check7BitWithTables("ğ", Languages.Turkish, Languages.Default)
+	assert.Equal(t, -1, invalid)
+	_, invalid = Check7BitWithTables("hi ए", Languages.Hindi, Languages.Default)
+	assert.Equal(t, -1, invalid)
+	_, invalid = Check7BitWithTables("hi ए", Languages.Default, Languages.Hindi)
+	assert.Equal(t, 3, invalid)
+}
+
+func TestEncode7BitModes(t *testing.T) {
+	t.Parallel()
+
+	octets, err := Encode7BitStrict("hi {€}")
+	require.NoError(t, err)
+	assert.Equal(t, Encode7Bit("hi {€}"), octets)
+
+	_, err = Encode7BitStrict("hi ü→")
+	assert.ErrorIs(t, err, ErrUnexpectedRune)
+	assert.EqualError(t, err, `7bit encode: met an unencodable character: '→' at offset 5`)
+
+	for _, tc := range []struct {
+		replacement rune
+		exp         string
+	}{
+		{' ', "hi ü "},
+		{'¿', "hi ü¿"},
+		{'€', "hi ü€"},
+		{'→', "hi ü?"},
+	} {
+		str, err := Decode7Bit(Encode7BitReplace("hi ü→", tc.replacement))
+		require.NoError(t, err)
+		assert.Equal(t, tc.exp, str)
+	}
+	assert.Equal(t, Encode7Bit("hi ü→"), Encode7BitReplace("hi ü→", '?'))
+}
+
+func TestAppendEncoders(t *testing.T) {
+	t.Parallel()
+
+	prefix := []byte{0xAA, 0xBB}
+	appended := func(octets []byte) []byte {
+		return append(append([]byte{}, prefix...), octets...)
+	}
+	for _, str := range []string{"", "hi", "1234567", "123456\r", "{hello} €", "hi ü→", "Этот"} {
+		assert.Equal(t, appended(Encode7Bit(str)), AppendEncode7Bit(prefix[:2:2], str), str)
+		for fill := 0; fill < 7; fill++ {
+			exp := Encode7BitWithFill(str, fill, Languages.Turkish, Languages.Portuguese)
+			out := AppendEncode7BitWithFill(prefix[:2:2], str, fill, Languages.Turkish, Languages.Portuguese)
+			assert.Equal(t, appended(exp), out, "%q fill %d", str, fill)
+		}
+		assert.Equal(t, appended(EncodeUcs2(str)), AppendUcs2(prefix[:2:2], str), str)
+	}
+	assert.Equal(t, appended([]byte{0x41, 0x60, 0x21, 0xF3}), AppendSemi(prefix[:2:2], 14, 6, 123))
+
+	octets, err := AppendBCD(prefix[:2:2], "12345")
+	require.NoError(t, err)
+	assert.Equal(t, appended([]byte{0x21, 0x43, 0xF5}), octets)
+	octets, err = AppendBCD(prefix[:2:2], "12+")
+	assert.ErrorIs(t, err, ErrUnexpectedDigit)
+	assert.Equal(t, prefix, octets)
+}
+
+func TestRuneIndexes(t *testing.T) {
+	t.Parallel()
+
+	for rt := range runeIndexes {
+		scan := *rt // the copy isn't indexed
+		for _, r := range rt {
+			assert.Equal(t, scan.Index(r), rt.Index(r), "%q", r)
+		}
+		for _, r := range "→Ж😀" {
+			assert.Equal(t, -1, rt.Index(r), "%q", r)
+		}
+	}
 }
@@ -0,0 +1,44 @@
+package pdu
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	benchText  = strings.Repeat("Hello {world}, ", 10)
+	benchUcs2  = strings.Repeat("Привет, мир 😀 ", 5)
+	benchPack7 = Encode7Bit(benchText)
+)
+
+func BenchmarkEncode7Bit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Encode7Bit(benchText)
+	}
+}
+
+func BenchmarkDecode7Bit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decode7Bit(benchPack7); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeUcs2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		EncodeUcs2(benchUcs2)
+	}
+}
+
+func BenchmarkEncodeBCD(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeBCD("79997654321"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
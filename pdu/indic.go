@@ -0,0 +1,2136 @@
+package pdu
+
+// The shift tables of the Indic languages and Urdu, 3GPP TS 23.038, annex A.2 and A.3.
+// The reserved septets of the locking shift tables are decoded as U+FFFD, they are never encoded.
+// The single shift tables are complete, they include the characters of the extension table.
+
+var bengaliTable = runeTable{
+	/* 0x00 */ 0x0981, /* BENGALI SIGN CANDRABINDU */
+	/* 0x01 */ 0x0982, /* BENGALI SIGN ANUSVARA */
+	/* 0x02 */ 0x0983, /* BENGALI SIGN VISARGA */
+	/* 0x03 */ 0x0985, /* BENGALI LETTER A */
+	/* 0x04 */ 0x0986, /* BENGALI LETTER AA */
+	/* 0x05 */ 0x0987, /* BENGALI LETTER I */
+	/* 0x06 */ 0x0988, /* BENGALI LETTER II */
+	/* 0x07 */ 0x0989, /* BENGALI LETTER U */
+	/* 0x08 */ 0x098A, /* BENGALI LETTER UU */
+	/* 0x09 */ 0x098B, /* BENGALI LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x098C, /* BENGALI LETTER VOCALIC L */
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ reserved,
+	/* 0x0F */ 0x098F, /* BENGALI LETTER E */
+	/* 0x10 */ 0x0990, /* BENGALI LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ reserved,
+	/* 0x13 */ 0x0993, /* BENGALI LETTER O */
+	/* 0x14 */ 0x0994, /* BENGALI LETTER AU */
+	/* 0x15 */ 0x0995, /* BENGALI LETTER KA */
+	/* 0x16 */ 0x0996, /* BENGALI LETTER KHA */
+	/* 0x17 */ 0x0997, /* BENGALI LETTER GA */
+	/* 0x18 */ 0x0998, /* BENGALI LETTER GHA */
+	/* 0x19 */ 0x0999, /* BENGALI LETTER NGA */
+	/* 0x1A */ 0x099A, /* BENGALI LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x099B, /* BENGALI LETTER CHA */
+	/* 0x1D */ 0x099C, /* BENGALI LETTER JA */
+	/* 0x1E */ 0x099D, /* BENGALI LETTER JHA */
+	/* 0x1F */ 0x099E, /* BENGALI LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x099F, /* BENGALI LETTER TTA */
+	/* 0x23 */ 0x09A0, /* BENGALI LETTER TTHA */
+	/* 0x24 */ 0x09A1, /* BENGALI LETTER DDA */
+	/* 0x25 */ 0x09A2, /* BENGALI LETTER DDHA */
+	/* 0x26 */ 0x09A3, /* BENGALI LETTER NNA */
+	/* 0x27 */ 0x09A4, /* BENGALI LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x09A5, /* BENGALI LETTER THA */
+	/* 0x2B */ 0x09A6, /* BENGALI LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x09A7, /* BENGALI LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x09A8, /* BENGALI LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x09AA, /* BENGALI LETTER PA */
+	/* 0x3E */ 0x09AB, /* BENGALI LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x09AC, /* BENGALI LETTER BA */
+	/* 0x41 */ 0x09AD, /* BENGALI LETTER BHA */
+	/* 0x42 */ 0x09AE, /* BENGALI LETTER MA */
+	/* 0x43 */ 0x09AF, /* BENGALI LETTER YA */
+	/* 0x44 */ 0x09B0, /* BENGALI LETTER RA */
+	/* 0x45 */ reserved,
+	/* 0x46 */ 0x09B2, /* BENGALI LETTER LA */
+	/* 0x47 */ reserved,
+	/* 0x48 */ reserved,
+	/* 0x49 */ reserved,
+	/* 0x4A */ 0x09B6, /* BENGALI LETTER SHA */
+	/* 0x4B */ 0x09B7, /* BENGALI LETTER SSA */
+	/* 0x4C */ 0x09B8, /* BENGALI LETTER SA */
+	/* 0x4D */ 0x09B9, /* BENGALI LETTER HA */
+	/* 0x4E */ 0x09BC, /* BENGALI SIGN NUKTA */
+	/* 0x4F */ 0x09BD, /* BENGALI SIGN AVAGRAHA */
+	/* 0x50 */ 0x09BE, /* BENGALI VOWEL SIGN AA */
+	/* 0x51 */ 0x09BF, /* BENGALI VOWEL SIGN I */
+	/* 0x52 */ 0x09C0, /* BENGALI VOWEL SIGN II */
+	/* 0x53 */ 0x09C1, /* BENGALI VOWEL SIGN U */
+	/* 0x54 */ 0x09C2, /* BENGALI VOWEL SIGN UU */
+	/* 0x55 */ 0x09C3, /* BENGALI VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x09C4, /* BENGALI VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ reserved,
+	/* 0x58 */ reserved,
+	/* 0x59 */ 0x09C7, /* BENGALI VOWEL SIGN E */
+	/* 0x5A */ 0x09C8, /* BENGALI VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ reserved,
+	/* 0x5D */ 0x09CB, /* BENGALI VOWEL SIGN O */
+	/* 0x5E */ 0x09CC, /* BENGALI VOWEL SIGN AU */
+	/* 0x5F */ 0x09CD, /* BENGALI SIGN VIRAMA */
+	/* 0x60 */ 0x09CE, /* BENGALI LETTER KHANDA TA */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x09D7, /* BENGALI AU LENGTH MARK */
+	/* 0x7C */ 0x09DC, /* BENGALI LETTER RRA */
+	/* 0x7D */ 0x09DD, /* BENGALI LETTER RHA */
+	/* 0x7E */ 0x09F0, /* BENGALI LETTER RA WITH MIDDLE DIAGONAL */
+	/* 0x7F */ 0x09F1, /* BENGALI LETTER RA WITH LOWER DIAGONAL */
+}
+
+var gujaratiTable = runeTable{
+	/* 0x00 */ 0x0A81, /* GUJARATI SIGN CANDRABINDU */
+	/* 0x01 */ 0x0A82, /* GUJARATI SIGN ANUSVARA */
+	/* 0x02 */ 0x0A83, /* GUJARATI SIGN VISARGA */
+	/* 0x03 */ 0x0A85, /* GUJARATI LETTER A */
+	/* 0x04 */ 0x0A86, /* GUJARATI LETTER AA */
+	/* 0x05 */ 0x0A87, /* GUJARATI LETTER I */
+	/* 0x06 */ 0x0A88, /* GUJARATI LETTER II */
+	/* 0x07 */ 0x0A89, /* GUJARATI LETTER U */
+	/* 0x08 */ 0x0A8A, /* GUJARATI LETTER UU */
+	/* 0x09 */ 0x0A8B, /* GUJARATI LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0A8C, /* GUJARATI LETTER VOCALIC L */
+	/* 0x0C */ 0x0A8D, /* GUJARATI VOWEL CANDRA E */
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ reserved,
+	/* 0x0F */ 0x0A8F, /* GUJARATI LETTER E */
+	/* 0x10 */ 0x0A90, /* GUJARATI LETTER AI */
+	/* 0x11 */ 0x0A91, /* GUJARATI VOWEL CANDRA O */
+	/* 0x12 */ reserved,
+	/* 0x13 */ 0x0A93, /* GUJARATI LETTER O */
+	/* 0x14 */ 0x0A94, /* GUJARATI LETTER AU */
+	/* 0x15 */ 0x0A95, /* GUJARATI LETTER KA */
+	/* 0x16 */ 0x0A96, /* GUJARATI LETTER KHA */
+	/* 0x17 */ 0x0A97, /* GUJARATI LETTER GA */
+	/* 0x18 */ 0x0A98, /* GUJARATI LETTER GHA */
+	/* 0x19 */ 0x0A99, /* GUJARATI LETTER NGA */
+	/* 0x1A */ 0x0A9A, /* GUJARATI LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0A9B, /* GUJARATI LETTER CHA */
+	/* 0x1D */ 0x0A9C, /* GUJARATI LETTER JA */
+	/* 0x1E */ 0x0A9D, /* GUJARATI LETTER JHA */
+	/* 0x1F */ 0x0A9E, /* GUJARATI LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0A9F, /* GUJARATI LETTER TTA */
+	/* 0x23 */ 0x0AA0, /* GUJARATI LETTER TTHA */
+	/* 0x24 */ 0x0AA1, /* GUJARATI LETTER DDA */
+	/* 0x25 */ 0x0AA2, /* GUJARATI LETTER DDHA */
+	/* 0x26 */ 0x0AA3, /* GUJARATI LETTER NNA */
+	/* 0x27 */ 0x0AA4, /* GUJARATI LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0AA5, /* GUJARATI LETTER THA */
+	/* 0x2B */ 0x0AA6, /* GUJARATI LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0AA7, /* GUJARATI LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0AA8, /* GUJARATI LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0AAA, /* GUJARATI LETTER PA */
+	/* 0x3E */ 0x0AAB, /* GUJARATI LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0AAC, /* GUJARATI LETTER BA */
+	/* 0x41 */ 0x0AAD, /* GUJARATI LETTER BHA */
+	/* 0x42 */ 0x0AAE, /* GUJARATI LETTER MA */
+	/* 0x43 */ 0x0AAF, /* GUJARATI LETTER YA */
+	/* 0x44 */ 0x0AB0, /* GUJARATI LETTER RA */
+	/* 0x45 */ reserved,
+	/* 0x46 */ 0x0AB2, /* GUJARATI LETTER LA */
+	/* 0x47 */ 0x0AB3, /* GUJARATI LETTER LLA */
+	/* 0x48 */ reserved,
+	/* 0x49 */ 0x0AB5, /* GUJARATI LETTER VA */
+	/* 0x4A */ 0x0AB6, /* GUJARATI LETTER SHA */
+	/* 0x4B */ 0x0AB7, /* GUJARATI LETTER SSA */
+	/* 0x4C */ 0x0AB8, /* GUJARATI LETTER SA */
+	/* 0x4D */ 0x0AB9, /* GUJARATI LETTER HA */
+	/* 0x4E */ 0x0ABC, /* GUJARATI SIGN NUKTA */
+	/* 0x4F */ 0x0ABD, /* GUJARATI SIGN AVAGRAHA */
+	/* 0x50 */ 0x0ABE, /* GUJARATI VOWEL SIGN AA */
+	/* 0x51 */ 0x0ABF, /* GUJARATI VOWEL SIGN I */
+	/* 0x52 */ 0x0AC0, /* GUJARATI VOWEL SIGN II */
+	/* 0x53 */ 0x0AC1, /* GUJARATI VOWEL SIGN U */
+	/* 0x54 */ 0x0AC2, /* GUJARATI VOWEL SIGN UU */
+	/* 0x55 */ 0x0AC3, /* GUJARATI VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0AC4, /* GUJARATI VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ 0x0AC5, /* GUJARATI VOWEL SIGN CANDRA E */
+	/* 0x58 */ reserved,
+	/* 0x59 */ 0x0AC7, /* GUJARATI VOWEL SIGN E */
+	/* 0x5A */ 0x0AC8, /* GUJARATI VOWEL SIGN AI */
+	/* 0x5B */ 0x0AC9, /* GUJARATI VOWEL SIGN CANDRA O */
+	/* 0x5C */ reserved,
+	/* 0x5D */ 0x0ACB, /* GUJARATI VOWEL SIGN O */
+	/* 0x5E */ 0x0ACC, /* GUJARATI VOWEL SIGN AU */
+	/* 0x5F */ 0x0ACD, /* GUJARATI SIGN VIRAMA */
+	/* 0x60 */ 0x0AD0, /* GUJARATI OM */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0AE0, /* GUJARATI LETTER VOCALIC RR */
+	/* 0x7C */ 0x0AE1, /* GUJARATI LETTER VOCALIC LL */
+	/* 0x7D */ 0x0AE2, /* GUJARATI VOWEL SIGN VOCALIC L */
+	/* 0x7E */ 0x0AE3, /* GUJARATI VOWEL SIGN VOCALIC LL */
+	/* 0x7F */ 0x0AF1, /* GUJARATI RUPEE SIGN */
+}
+
+var hindiTable = runeTable{
+	/* 0x00 */ 0x0901, /* DEVANAGARI SIGN CANDRABINDU */
+	/* 0x01 */ 0x0902, /* DEVANAGARI SIGN ANUSVARA */
+	/* 0x02 */ 0x0903, /* DEVANAGARI SIGN VISARGA */
+	/* 0x03 */ 0x0905, /* DEVANAGARI LETTER A */
+	/* 0x04 */ 0x0906, /* DEVANAGARI LETTER AA */
+	/* 0x05 */ 0x0907, /* DEVANAGARI LETTER I */
+	/* 0x06 */ 0x0908, /* DEVANAGARI LETTER II */
+	/* 0x07 */ 0x0909, /* DEVANAGARI LETTER U */
+	/* 0x08 */ 0x090A, /* DEVANAGARI LETTER UU */
+	/* 0x09 */ 0x090B, /* DEVANAGARI LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x090C, /* DEVANAGARI LETTER VOCALIC L */
+	/* 0x0C */ 0x090D, /* DEVANAGARI LETTER CANDRA E */
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x090E, /* DEVANAGARI LETTER SHORT E */
+	/* 0x0F */ 0x090F, /* DEVANAGARI LETTER E */
+	/* 0x10 */ 0x0910, /* DEVANAGARI LETTER AI */
+	/* 0x11 */ 0x0911, /* DEVANAGARI LETTER CANDRA O */
+	/* 0x12 */ 0x0912, /* DEVANAGARI LETTER SHORT O */
+	/* 0x13 */ 0x0913, /* DEVANAGARI LETTER O */
+	/* 0x14 */ 0x0914, /* DEVANAGARI LETTER AU */
+	/* 0x15 */ 0x0915, /* DEVANAGARI LETTER KA */
+	/* 0x16 */ 0x0916, /* DEVANAGARI LETTER KHA */
+	/* 0x17 */ 0x0917, /* DEVANAGARI LETTER GA */
+	/* 0x18 */ 0x0918, /* DEVANAGARI LETTER GHA */
+	/* 0x19 */ 0x0919, /* DEVANAGARI LETTER NGA */
+	/* 0x1A */ 0x091A, /* DEVANAGARI LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x091B, /* DEVANAGARI LETTER CHA */
+	/* 0x1D */ 0x091C, /* DEVANAGARI LETTER JA */
+	/* 0x1E */ 0x091D, /* DEVANAGARI LETTER JHA */
+	/* 0x1F */ 0x091E, /* DEVANAGARI LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x091F, /* DEVANAGARI LETTER TTA */
+	/* 0x23 */ 0x0920, /* DEVANAGARI LETTER TTHA */
+	/* 0x24 */ 0x0921, /* DEVANAGARI LETTER DDA */
+	/* 0x25 */ 0x0922, /* DEVANAGARI LETTER DDHA */
+	/* 0x26 */ 0x0923, /* DEVANAGARI LETTER NNA */
+	/* 0x27 */ 0x0924, /* DEVANAGARI LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0925, /* DEVANAGARI LETTER THA */
+	/* 0x2B */ 0x0926, /* DEVANAGARI LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0927, /* DEVANAGARI LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0928, /* DEVANAGARI LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ 0x0929, /* DEVANAGARI LETTER NNNA */
+	/* 0x3D */ 0x092A, /* DEVANAGARI LETTER PA */
+	/* 0x3E */ 0x092B, /* DEVANAGARI LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x092C, /* DEVANAGARI LETTER BA */
+	/* 0x41 */ 0x092D, /* DEVANAGARI LETTER BHA */
+	/* 0x42 */ 0x092E, /* DEVANAGARI LETTER MA */
+	/* 0x43 */ 0x092F, /* DEVANAGARI LETTER YA */
+	/* 0x44 */ 0x0930, /* DEVANAGARI LETTER RA */
+	/* 0x45 */ 0x0931, /* DEVANAGARI LETTER RRA */
+	/* 0x46 */ 0x0932, /* DEVANAGARI LETTER LA */
+	/* 0x47 */ 0x0933, /* DEVANAGARI LETTER LLA */
+	/* 0x48 */ 0x0934, /* DEVANAGARI LETTER LLLA */
+	/* 0x49 */ 0x0935, /* DEVANAGARI LETTER VA */
+	/* 0x4A */ 0x0936, /* DEVANAGARI LETTER SHA */
+	/* 0x4B */ 0x0937, /* DEVANAGARI LETTER SSA */
+	/* 0x4C */ 0x0938, /* DEVANAGARI LETTER SA */
+	/* 0x4D */ 0x0939, /* DEVANAGARI LETTER HA */
+	/* 0x4E */ 0x093C, /* DEVANAGARI SIGN NUKTA */
+	/* 0x4F */ 0x093D, /* DEVANAGARI SIGN AVAGRAHA */
+	/* 0x50 */ 0x093E, /* DEVANAGARI VOWEL SIGN AA */
+	/* 0x51 */ 0x093F, /* DEVANAGARI VOWEL SIGN I */
+	/* 0x52 */ 0x0940, /* DEVANAGARI VOWEL SIGN II */
+	/* 0x53 */ 0x0941, /* DEVANAGARI VOWEL SIGN U */
+	/* 0x54 */ 0x0942, /* DEVANAGARI VOWEL SIGN UU */
+	/* 0x55 */ 0x0943, /* DEVANAGARI VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0944, /* DEVANAGARI VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ 0x0945, /* DEVANAGARI VOWEL SIGN CANDRA E */
+	/* 0x58 */ 0x0946, /* DEVANAGARI VOWEL SIGN SHORT E */
+	/* 0x59 */ 0x0947, /* DEVANAGARI VOWEL SIGN E */
+	/* 0x5A */ 0x0948, /* DEVANAGARI VOWEL SIGN AI */
+	/* 0x5B */ 0x0949, /* DEVANAGARI VOWEL SIGN CANDRA O */
+	/* 0x5C */ 0x094A, /* DEVANAGARI VOWEL SIGN SHORT O */
+	/* 0x5D */ 0x094B, /* DEVANAGARI VOWEL SIGN O */
+	/* 0x5E */ 0x094C, /* DEVANAGARI VOWEL SIGN AU */
+	/* 0x5F */ 0x094D, /* DEVANAGARI SIGN VIRAMA */
+	/* 0x60 */ 0x0950, /* DEVANAGARI OM */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0972, /* DEVANAGARI LETTER CANDRA A */
+	/* 0x7C */ 0x097B, /* DEVANAGARI LETTER GGA */
+	/* 0x7D */ 0x097C, /* DEVANAGARI LETTER JJA */
+	/* 0x7E */ 0x097E, /* DEVANAGARI LETTER DDDA */
+	/* 0x7F */ 0x097F, /* DEVANAGARI LETTER BBA */
+}
+
+var kannadaTable = runeTable{
+	/* 0x00 */ reserved,
+	/* 0x01 */ 0x0C82, /* KANNADA SIGN ANUSVARA */
+	/* 0x02 */ 0x0C83, /* KANNADA SIGN VISARGA */
+	/* 0x03 */ 0x0C85, /* KANNADA LETTER A */
+	/* 0x04 */ 0x0C86, /* KANNADA LETTER AA */
+	/* 0x05 */ 0x0C87, /* KANNADA LETTER I */
+	/* 0x06 */ 0x0C88, /* KANNADA LETTER II */
+	/* 0x07 */ 0x0C89, /* KANNADA LETTER U */
+	/* 0x08 */ 0x0C8A, /* KANNADA LETTER UU */
+	/* 0x09 */ 0x0C8B, /* KANNADA LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0C8C, /* KANNADA LETTER VOCALIC L */
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x0C8E, /* KANNADA LETTER E */
+	/* 0x0F */ 0x0C8F, /* KANNADA LETTER EE */
+	/* 0x10 */ 0x0C90, /* KANNADA LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ 0x0C92, /* KANNADA LETTER O */
+	/* 0x13 */ 0x0C93, /* KANNADA LETTER OO */
+	/* 0x14 */ 0x0C94, /* KANNADA LETTER AU */
+	/* 0x15 */ 0x0C95, /* KANNADA LETTER KA */
+	/* 0x16 */ 0x0C96, /* KANNADA LETTER KHA */
+	/* 0x17 */ 0x0C97, /* KANNADA LETTER GA */
+	/* 0x18 */ 0x0C98, /* KANNADA LETTER GHA */
+	/* 0x19 */ 0x0C99, /* KANNADA LETTER NGA */
+	/* 0x1A */ 0x0C9A, /* KANNADA LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0C9B, /* KANNADA LETTER CHA */
+	/* 0x1D */ 0x0C9C, /* KANNADA LETTER JA */
+	/* 0x1E */ 0x0C9D, /* KANNADA LETTER JHA */
+	/* 0x1F */ 0x0C9E, /* KANNADA LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0C9F, /* KANNADA LETTER TTA */
+	/* 0x23 */ 0x0CA0, /* KANNADA LETTER TTHA */
+	/* 0x24 */ 0x0CA1, /* KANNADA LETTER DDA */
+	/* 0x25 */ 0x0CA2, /* KANNADA LETTER DDHA */
+	/* 0x26 */ 0x0CA3, /* KANNADA LETTER NNA */
+	/* 0x27 */ 0x0CA4, /* KANNADA LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0CA5, /* KANNADA LETTER THA */
+	/* 0x2B */ 0x0CA6, /* KANNADA LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0CA7, /* KANNADA LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0CA8, /* KANNADA LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0CAA, /* KANNADA LETTER PA */
+	/* 0x3E */ 0x0CAB, /* KANNADA LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0CAC, /* KANNADA LETTER BA */
+	/* 0x41 */ 0x0CAD, /* KANNADA LETTER BHA */
+	/* 0x42 */ 0x0CAE, /* KANNADA LETTER MA */
+	/* 0x43 */ 0x0CAF, /* KANNADA LETTER YA */
+	/* 0x44 */ 0x0CB0, /* KANNADA LETTER RA */
+	/* 0x45 */ 0x0CB1, /* KANNADA LETTER RRA */
+	/* 0x46 */ 0x0CB2, /* KANNADA LETTER LA */
+	/* 0x47 */ 0x0CB3, /* KANNADA LETTER LLA */
+	/* 0x48 */ reserved,
+	/* 0x49 */ 0x0CB5, /* KANNADA LETTER VA */
+	/* 0x4A */ 0x0CB6, /* KANNADA LETTER SHA */
+	/* 0x4B */ 0x0CB7, /* KANNADA LETTER SSA */
+	/* 0x4C */ 0x0CB8, /* KANNADA LETTER SA */
+	/* 0x4D */ 0x0CB9, /* KANNADA LETTER HA */
+	/* 0x4E */ 0x0CBC, /* KANNADA SIGN NUKTA */
+	/* 0x4F */ 0x0CBD, /* KANNADA SIGN AVAGRAHA */
+	/* 0x50 */ 0x0CBE, /* KANNADA VOWEL SIGN AA */
+	/* 0x51 */ 0x0CBF, /* KANNADA VOWEL SIGN I */
+	/* 0x52 */ 0x0CC0, /* KANNADA VOWEL SIGN II */
+	/* 0x53 */ 0x0CC1, /* KANNADA VOWEL SIGN U */
+	/* 0x54 */ 0x0CC2, /* KANNADA VOWEL SIGN UU */
+	/* 0x55 */ 0x0CC3, /* KANNADA VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0CC4, /* KANNADA VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ reserved,
+	/* 0x58 */ 0x0CC6, /* KANNADA VOWEL SIGN E */
+	/* 0x59 */ 0x0CC7, /* KANNADA VOWEL SIGN EE */
+	/* 0x5A */ 0x0CC8, /* KANNADA VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ 0x0CCA, /* KANNADA VOWEL SIGN O */
+	/* 0x5D */ 0x0CCB, /* KANNADA VOWEL SIGN OO */
+	/* 0x5E */ 0x0CCC, /* KANNADA VOWEL SIGN AU */
+	/* 0x5F */ 0x0CCD, /* KANNADA SIGN VIRAMA */
+	/* 0x60 */ 0x0CD5, /* KANNADA LENGTH MARK */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0CD6, /* KANNADA AI LENGTH MARK */
+	/* 0x7C */ 0x0CE0, /* KANNADA LETTER VOCALIC RR */
+	/* 0x7D */ 0x0CE1, /* KANNADA LETTER VOCALIC LL */
+	/* 0x7E */ 0x0CE2, /* KANNADA VOWEL SIGN VOCALIC L */
+	/* 0x7F */ 0x0CE3, /* KANNADA VOWEL SIGN VOCALIC LL */
+}
+
+var malayalamTable = runeTable{
+	/* 0x00 */ reserved,
+	/* 0x01 */ 0x0D02, /* MALAYALAM SIGN ANUSVARA */
+	/* 0x02 */ 0x0D03, /* MALAYALAM SIGN VISARGA */
+	/* 0x03 */ 0x0D05, /* MALAYALAM LETTER A */
+	/* 0x04 */ 0x0D06, /* MALAYALAM LETTER AA */
+	/* 0x05 */ 0x0D07, /* MALAYALAM LETTER I */
+	/* 0x06 */ 0x0D08, /* MALAYALAM LETTER II */
+	/* 0x07 */ 0x0D09, /* MALAYALAM LETTER U */
+	/* 0x08 */ 0x0D0A, /* MALAYALAM LETTER UU */
+	/* 0x09 */ 0x0D0B, /* MALAYALAM LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0D0C, /* MALAYALAM LETTER VOCALIC L */
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x0D0E, /* MALAYALAM LETTER E */
+	/* 0x0F */ 0x0D0F, /* MALAYALAM LETTER EE */
+	/* 0x10 */ 0x0D10, /* MALAYALAM LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ 0x0D12, /* MALAYALAM LETTER O */
+	/* 0x13 */ 0x0D13, /* MALAYALAM LETTER OO */
+	/* 0x14 */ 0x0D14, /* MALAYALAM LETTER AU */
+	/* 0x15 */ 0x0D15, /* MALAYALAM LETTER KA */
+	/* 0x16 */ 0x0D16, /* MALAYALAM LETTER KHA */
+	/* 0x17 */ 0x0D17, /* MALAYALAM LETTER GA */
+	/* 0x18 */ 0x0D18, /* MALAYALAM LETTER GHA */
+	/* 0x19 */ 0x0D19, /* MALAYALAM LETTER NGA */
+	/* 0x1A */ 0x0D1A, /* MALAYALAM LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0D1B, /* MALAYALAM LETTER CHA */
+	/* 0x1D */ 0x0D1C, /* MALAYALAM LETTER JA */
+	/* 0x1E */ 0x0D1D, /* MALAYALAM LETTER JHA */
+	/* 0x1F */ 0x0D1E, /* MALAYALAM LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0D1F, /* MALAYALAM LETTER TTA */
+	/* 0x23 */ 0x0D20, /* MALAYALAM LETTER TTHA */
+	/* 0x24 */ 0x0D21, /* MALAYALAM LETTER DDA */
+	/* 0x25 */ 0x0D22, /* MALAYALAM LETTER DDHA */
+	/* 0x26 */ 0x0D23, /* MALAYALAM LETTER NNA */
+	/* 0x27 */ 0x0D24, /* MALAYALAM LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0D25, /* MALAYALAM LETTER THA */
+	/* 0x2B */ 0x0D26, /* MALAYALAM LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0D27, /* MALAYALAM LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0D28, /* MALAYALAM LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0D2A, /* MALAYALAM LETTER PA */
+	/* 0x3E */ 0x0D2B, /* MALAYALAM LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0D2C, /* MALAYALAM LETTER BA */
+	/* 0x41 */ 0x0D2D, /* MALAYALAM LETTER BHA */
+	/* 0x42 */ 0x0D2E, /* MALAYALAM LETTER MA */
+	/* 0x43 */ 0x0D2F, /* MALAYALAM LETTER YA */
+	/* 0x44 */ 0x0D30, /* MALAYALAM LETTER RA */
+	/* 0x45 */ 0x0D31, /* MALAYALAM LETTER RRA */
+	/* 0x46 */ 0x0D32, /* MALAYALAM LETTER LA */
+	/* 0x47 */ 0x0D33, /* MALAYALAM LETTER LLA */
+	/* 0x48 */ 0x0D34, /* MALAYALAM LETTER LLLA */
+	/* 0x49 */ 0x0D35, /* MALAYALAM LETTER VA */
+	/* 0x4A */ 0x0D36, /* MALAYALAM LETTER SHA */
+	/* 0x4B */ 0x0D37, /* MALAYALAM LETTER SSA */
+	/* 0x4C */ 0x0D38, /* MALAYALAM LETTER SA */
+	/* 0x4D */ 0x0D39, /* MALAYALAM LETTER HA */
+	/* 0x4E */ reserved,
+	/* 0x4F */ 0x0D3D, /* MALAYALAM SIGN AVAGRAHA */
+	/* 0x50 */ 0x0D3E, /* MALAYALAM VOWEL SIGN AA */
+	/* 0x51 */ 0x0D3F, /* MALAYALAM VOWEL SIGN I */
+	/* 0x52 */ 0x0D40, /* MALAYALAM VOWEL SIGN II */
+	/* 0x53 */ 0x0D41, /* MALAYALAM VOWEL SIGN U */
+	/* 0x54 */ 0x0D42, /* MALAYALAM VOWEL SIGN UU */
+	/* 0x55 */ 0x0D43, /* MALAYALAM VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0D44, /* MALAYALAM VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ reserved,
+	/* 0x58 */ 0x0D46, /* MALAYALAM VOWEL SIGN E */
+	/* 0x59 */ 0x0D47, /* MALAYALAM VOWEL SIGN EE */
+	/* 0x5A */ 0x0D48, /* MALAYALAM VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ 0x0D4A, /* MALAYALAM VOWEL SIGN O */
+	/* 0x5D */ 0x0D4B, /* MALAYALAM VOWEL SIGN OO */
+	/* 0x5E */ 0x0D4C, /* MALAYALAM VOWEL SIGN AU */
+	/* 0x5F */ 0x0D4D, /* MALAYALAM SIGN VIRAMA */
+	/* 0x60 */ 0x0D57, /* MALAYALAM AU LENGTH MARK */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0D60, /* MALAYALAM LETTER VOCALIC RR */
+	/* 0x7C */ 0x0D61, /* MALAYALAM LETTER VOCALIC LL */
+	/* 0x7D */ 0x0D62, /* MALAYALAM VOWEL SIGN VOCALIC L */
+	/* 0x7E */ 0x0D63, /* MALAYALAM VOWEL SIGN VOCALIC LL */
+	/* 0x7F */ 0x0D79, /* MALAYALAM DATE MARK */
+}
+
+var oriyaTable = runeTable{
+	/* 0x00 */ 0x0B01, /* ORIYA SIGN CANDRABINDU */
+	/* 0x01 */ 0x0B02, /* ORIYA SIGN ANUSVARA */
+	/* 0x02 */ 0x0B03, /* ORIYA SIGN VISARGA */
+	/* 0x03 */ 0x0B05, /* ORIYA LETTER A */
+	/* 0x04 */ 0x0B06, /* ORIYA LETTER AA */
+	/* 0x05 */ 0x0B07, /* ORIYA LETTER I */
+	/* 0x06 */ 0x0B08, /* ORIYA LETTER II */
+	/* 0x07 */ 0x0B09, /* ORIYA LETTER U */
+	/* 0x08 */ 0x0B0A, /* ORIYA LETTER UU */
+	/* 0x09 */ 0x0B0B, /* ORIYA LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0B0C, /* ORIYA LETTER VOCALIC L */
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ reserved,
+	/* 0x0F */ 0x0B0F, /* ORIYA LETTER E */
+	/* 0x10 */ 0x0B10, /* ORIYA LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ reserved,
+	/* 0x13 */ 0x0B13, /* ORIYA LETTER O */
+	/* 0x14 */ 0x0B14, /* ORIYA LETTER AU */
+	/* 0x15 */ 0x0B15, /* ORIYA LETTER KA */
+	/* 0x16 */ 0x0B16, /* ORIYA LETTER KHA */
+	/* 0x17 */ 0x0B17, /* ORIYA LETTER GA */
+	/* 0x18 */ 0x0B18, /* ORIYA LETTER GHA */
+	/* 0x19 */ 0x0B19, /* ORIYA LETTER NGA */
+	/* 0x1A */ 0x0B1A, /* ORIYA LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0B1B, /* ORIYA LETTER CHA */
+	/* 0x1D */ 0x0B1C, /* ORIYA LETTER JA */
+	/* 0x1E */ 0x0B1D, /* ORIYA LETTER JHA */
+	/* 0x1F */ 0x0B1E, /* ORIYA LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0B1F, /* ORIYA LETTER TTA */
+	/* 0x23 */ 0x0B20, /* ORIYA LETTER TTHA */
+	/* 0x24 */ 0x0B21, /* ORIYA LETTER DDA */
+	/* 0x25 */ 0x0B22, /* ORIYA LETTER DDHA */
+	/* 0x26 */ 0x0B23, /* ORIYA LETTER NNA */
+	/* 0x27 */ 0x0B24, /* ORIYA LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0B25, /* ORIYA LETTER THA */
+	/* 0x2B */ 0x0B26, /* ORIYA LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0B27, /* ORIYA LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0B28, /* ORIYA LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0B2A, /* ORIYA LETTER PA */
+	/* 0x3E */ 0x0B2B, /* ORIYA LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0B2C, /* ORIYA LETTER BA */
+	/* 0x41 */ 0x0B2D, /* ORIYA LETTER BHA */
+	/* 0x42 */ 0x0B2E, /* ORIYA LETTER MA */
+	/* 0x43 */ 0x0B2F, /* ORIYA LETTER YA */
+	/* 0x44 */ 0x0B30, /* ORIYA LETTER RA */
+	/* 0x45 */ reserved,
+	/* 0x46 */ 0x0B32, /* ORIYA LETTER LA */
+	/* 0x47 */ 0x0B33, /* ORIYA LETTER LLA */
+	/* 0x48 */ reserved,
+	/* 0x49 */ 0x0B35, /* ORIYA LETTER VA */
+	/* 0x4A */ 0x0B36, /* ORIYA LETTER SHA */
+	/* 0x4B */ 0x0B37, /* ORIYA LETTER SSA */
+	/* 0x4C */ 0x0B38, /* ORIYA LETTER SA */
+	/* 0x4D */ 0x0B39, /* ORIYA LETTER HA */
+	/* 0x4E */ 0x0B3C, /* ORIYA SIGN NUKTA */
+	/* 0x4F */ 0x0B3D, /* ORIYA SIGN AVAGRAHA */
+	/* 0x50 */ 0x0B3E, /* ORIYA VOWEL SIGN AA */
+	/* 0x51 */ 0x0B3F, /* ORIYA VOWEL SIGN I */
+	/* 0x52 */ 0x0B40, /* ORIYA VOWEL SIGN II */
+	/* 0x53 */ 0x0B41, /* ORIYA VOWEL SIGN U */
+	/* 0x54 */ 0x0B42, /* ORIYA VOWEL SIGN UU */
+	/* 0x55 */ 0x0B43, /* ORIYA VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0B44, /* ORIYA VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ reserved,
+	/* 0x58 */ reserved,
+	/* 0x59 */ 0x0B47, /* ORIYA VOWEL SIGN E */
+	/* 0x5A */ 0x0B48, /* ORIYA VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ reserved,
+	/* 0x5D */ 0x0B4B, /* ORIYA VOWEL SIGN O */
+	/* 0x5E */ 0x0B4C, /* ORIYA VOWEL SIGN AU */
+	/* 0x5F */ 0x0B4D, /* ORIYA SIGN VIRAMA */
+	/* 0x60 */ 0x0B56, /* ORIYA AI LENGTH MARK */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0B57, /* ORIYA AU LENGTH MARK */
+	/* 0x7C */ 0x0B60, /* ORIYA LETTER VOCALIC RR */
+	/* 0x7D */ 0x0B61, /* ORIYA LETTER VOCALIC LL */
+	/* 0x7E */ 0x0B62, /* ORIYA VOWEL SIGN VOCALIC L */
+	/* 0x7F */ 0x0B63, /* ORIYA VOWEL SIGN VOCALIC LL */
+}
+
+var punjabiTable = runeTable{
+	/* 0x00 */ 0x0A01, /* GURMUKHI SIGN ADAK BINDI */
+	/* 0x01 */ 0x0A02, /* GURMUKHI SIGN BINDI */
+	/* 0x02 */ 0x0A03, /* GURMUKHI SIGN VISARGA */
+	/* 0x03 */ 0x0A05, /* GURMUKHI LETTER A */
+	/* 0x04 */ 0x0A06, /* GURMUKHI LETTER AA */
+	/* 0x05 */ 0x0A07, /* GURMUKHI LETTER I */
+	/* 0x06 */ 0x0A08, /* GURMUKHI LETTER II */
+	/* 0x07 */ 0x0A09, /* GURMUKHI LETTER U */
+	/* 0x08 */ 0x0A0A, /* GURMUKHI LETTER UU */
+	/* 0x09 */ reserved,
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ reserved,
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ reserved,
+	/* 0x0F */ 0x0A0F, /* GURMUKHI LETTER EE */
+	/* 0x10 */ 0x0A10, /* GURMUKHI LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ reserved,
+	/* 0x13 */ 0x0A13, /* GURMUKHI LETTER OO */
+	/* 0x14 */ 0x0A14, /* GURMUKHI LETTER AU */
+	/* 0x15 */ 0x0A15, /* GURMUKHI LETTER KA */
+	/* 0x16 */ 0x0A16, /* GURMUKHI LETTER KHA */
+	/* 0x17 */ 0x0A17, /* GURMUKHI LETTER GA */
+	/* 0x18 */ 0x0A18, /* GURMUKHI LETTER GHA */
+	/* 0x19 */ 0x0A19, /* GURMUKHI LETTER NGA */
+	/* 0x1A */ 0x0A1A, /* GURMUKHI LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0A1B, /* GURMUKHI LETTER CHA */
+	/* 0x1D */ 0x0A1C, /* GURMUKHI LETTER JA */
+	/* 0x1E */ 0x0A1D, /* GURMUKHI LETTER JHA */
+	/* 0x1F */ 0x0A1E, /* GURMUKHI LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0A1F, /* GURMUKHI LETTER TTA */
+	/* 0x23 */ 0x0A20, /* GURMUKHI LETTER TTHA */
+	/* 0x24 */ 0x0A21, /* GURMUKHI LETTER DDA */
+	/* 0x25 */ 0x0A22, /* GURMUKHI LETTER DDHA */
+	/* 0x26 */ 0x0A23, /* GURMUKHI LETTER NNA */
+	/* 0x27 */ 0x0A24, /* GURMUKHI LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0A25, /* GURMUKHI LETTER THA */
+	/* 0x2B */ 0x0A26, /* GURMUKHI LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0A27, /* GURMUKHI LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0A28, /* GURMUKHI LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0A2A, /* GURMUKHI LETTER PA */
+	/* 0x3E */ 0x0A2B, /* GURMUKHI LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0A2C, /* GURMUKHI LETTER BA */
+	/* 0x41 */ 0x0A2D, /* GURMUKHI LETTER BHA */
+	/* 0x42 */ 0x0A2E, /* GURMUKHI LETTER MA */
+	/* 0x43 */ 0x0A2F, /* GURMUKHI LETTER YA */
+	/* 0x44 */ 0x0A30, /* GURMUKHI LETTER RA */
+	/* 0x45 */ reserved,
+	/* 0x46 */ 0x0A32, /* GURMUKHI LETTER LA */
+	/* 0x47 */ 0x0A33, /* GURMUKHI LETTER LLA */
+	/* 0x48 */ reserved,
+	/* 0x49 */ 0x0A35, /* GURMUKHI LETTER VA */
+	/* 0x4A */ 0x0A36, /* GURMUKHI LETTER SHA */
+	/* 0x4B */ reserved,
+	/* 0x4C */ 0x0A38, /* GURMUKHI LETTER SA */
+	/* 0x4D */ 0x0A39, /* GURMUKHI LETTER HA */
+	/* 0x4E */ 0x0A3C, /* GURMUKHI SIGN NUKTA */
+	/* 0x4F */ reserved,
+	/* 0x50 */ 0x0A3E, /* GURMUKHI VOWEL SIGN AA */
+	/* 0x51 */ 0x0A3F, /* GURMUKHI VOWEL SIGN I */
+	/* 0x52 */ 0x0A40, /* GURMUKHI VOWEL SIGN II */
+	/* 0x53 */ 0x0A41, /* GURMUKHI VOWEL SIGN U */
+	/* 0x54 */ 0x0A42, /* GURMUKHI VOWEL SIGN UU */
+	/* 0x55 */ reserved,
+	/* 0x56 */ reserved,
+	/* 0x57 */ reserved,
+	/* 0x58 */ reserved,
+	/* 0x59 */ 0x0A47, /* GURMUKHI VOWEL SIGN EE */
+	/* 0x5A */ 0x0A48, /* GURMUKHI VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ reserved,
+	/* 0x5D */ 0x0A4B, /* GURMUKHI VOWEL SIGN OO */
+	/* 0x5E */ 0x0A4C, /* GURMUKHI VOWEL SIGN AU */
+	/* 0x5F */ 0x0A4D, /* GURMUKHI SIGN VIRAMA */
+	/* 0x60 */ 0x0A51, /* GURMUKHI SIGN UDAAT */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0A70, /* GURMUKHI TIPPI */
+	/* 0x7C */ 0x0A71, /* GURMUKHI ADDAK */
+	/* 0x7D */ 0x0A72, /* GURMUKHI IRI */
+	/* 0x7E */ 0x0A73, /* GURMUKHI URA */
+	/* 0x7F */ 0x0A74, /* GURMUKHI EK ONKAR */
+}
+
+var tamilTable = runeTable{
+	/* 0x00 */ reserved,
+	/* 0x01 */ 0x0B82, /* TAMIL SIGN ANUSVARA */
+	/* 0x02 */ 0x0B83, /* TAMIL SIGN VISARGA */
+	/* 0x03 */ 0x0B85, /* TAMIL LETTER A */
+	/* 0x04 */ 0x0B86, /* TAMIL LETTER AA */
+	/* 0x05 */ 0x0B87, /* TAMIL LETTER I */
+	/* 0x06 */ 0x0B88, /* TAMIL LETTER II */
+	/* 0x07 */ 0x0B89, /* TAMIL LETTER U */
+	/* 0x08 */ 0x0B8A, /* TAMIL LETTER UU */
+	/* 0x09 */ reserved,
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ reserved,
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x0B8E, /* TAMIL LETTER E */
+	/* 0x0F */ 0x0B8F, /* TAMIL LETTER EE */
+	/* 0x10 */ 0x0B90, /* TAMIL LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ 0x0B92, /* TAMIL LETTER O */
+	/* 0x13 */ 0x0B93, /* TAMIL LETTER OO */
+	/* 0x14 */ 0x0B94, /* TAMIL LETTER AU */
+	/* 0x15 */ 0x0B95, /* TAMIL LETTER KA */
+	/* 0x16 */ reserved,
+	/* 0x17 */ reserved,
+	/* 0x18 */ reserved,
+	/* 0x19 */ 0x0B99, /* TAMIL LETTER NGA */
+	/* 0x1A */ 0x0B9A, /* TAMIL LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ reserved,
+	/* 0x1D */ 0x0B9C, /* TAMIL LETTER JA */
+	/* 0x1E */ reserved,
+	/* 0x1F */ 0x0B9E, /* TAMIL LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0B9F, /* TAMIL LETTER TTA */
+	/* 0x23 */ reserved,
+	/* 0x24 */ reserved,
+	/* 0x25 */ reserved,
+	/* 0x26 */ 0x0BA3, /* TAMIL LETTER NNA */
+	/* 0x27 */ 0x0BA4, /* TAMIL LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ reserved,
+	/* 0x2B */ reserved,
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ reserved,
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0BA8, /* TAMIL LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ 0x0BA9, /* TAMIL LETTER NNNA */
+	/* 0x3D */ 0x0BAA, /* TAMIL LETTER PA */
+	/* 0x3E */ reserved,
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ reserved,
+	/* 0x41 */ reserved,
+	/* 0x42 */ 0x0BAE, /* TAMIL LETTER MA */
+	/* 0x43 */ 0x0BAF, /* TAMIL LETTER YA */
+	/* 0x44 */ 0x0BB0, /* TAMIL LETTER RA */
+	/* 0x45 */ 0x0BB1, /* TAMIL LETTER RRA */
+	/* 0x46 */ 0x0BB2, /* TAMIL LETTER LA */
+	/* 0x47 */ 0x0BB3, /* TAMIL LETTER LLA */
+	/* 0x48 */ 0x0BB4, /* TAMIL LETTER LLLA */
+	/* 0x49 */ 0x0BB5, /* TAMIL LETTER VA */
+	/* 0x4A */ 0x0BB6, /* TAMIL LETTER SHA */
+	/* 0x4B */ 0x0BB7, /* TAMIL LETTER SSA */
+	/* 0x4C */ 0x0BB8, /* TAMIL LETTER SA */
+	/* 0x4D */ 0x0BB9, /* TAMIL LETTER HA */
+	/* 0x4E */ reserved,
+	/* 0x4F */ reserved,
+	/* 0x50 */ 0x0BBE, /* TAMIL VOWEL SIGN AA */
+	/* 0x51 */ 0x0BBF, /* TAMIL VOWEL SIGN I */
+	/* 0x52 */ 0x0BC0, /* TAMIL VOWEL SIGN II */
+	/* 0x53 */ 0x0BC1, /* TAMIL VOWEL SIGN U */
+	/* 0x54 */ 0x0BC2, /* TAMIL VOWEL SIGN UU */
+	/* 0x55 */ reserved,
+	/* 0x56 */ reserved,
+	/* 0x57 */ reserved,
+	/* 0x58 */ 0x0BC6, /* TAMIL VOWEL SIGN E */
+	/* 0x59 */ 0x0BC7, /* TAMIL VOWEL SIGN EE */
+	/* 0x5A */ 0x0BC8, /* TAMIL VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ 0x0BCA, /* TAMIL VOWEL SIGN O */
+	/* 0x5D */ 0x0BCB, /* TAMIL VOWEL SIGN OO */
+	/* 0x5E */ 0x0BCC, /* TAMIL VOWEL SIGN AU */
+	/* 0x5F */ 0x0BCD, /* TAMIL SIGN VIRAMA */
+	/* 0x60 */ 0x0BD0, /* TAMIL OM */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0BD7, /* TAMIL AU LENGTH MARK */
+	/* 0x7C */ 0x0BF0, /* TAMIL NUMBER TEN */
+	/* 0x7D */ 0x0BF1, /* TAMIL NUMBER ONE HUNDRED */
+	/* 0x7E */ 0x0BF2, /* TAMIL NUMBER ONE THOUSAND */
+	/* 0x7F */ 0x0BF9, /* TAMIL RUPEE SIGN */
+}
+
+var teluguTable = runeTable{
+	/* 0x00 */ 0x0C01, /* TELUGU SIGN CANDRABINDU */
+	/* 0x01 */ 0x0C02, /* TELUGU SIGN ANUSVARA */
+	/* 0x02 */ 0x0C03, /* TELUGU SIGN VISARGA */
+	/* 0x03 */ 0x0C05, /* TELUGU LETTER A */
+	/* 0x04 */ 0x0C06, /* TELUGU LETTER AA */
+	/* 0x05 */ 0x0C07, /* TELUGU LETTER I */
+	/* 0x06 */ 0x0C08, /* TELUGU LETTER II */
+	/* 0x07 */ 0x0C09, /* TELUGU LETTER U */
+	/* 0x08 */ 0x0C0A, /* TELUGU LETTER UU */
+	/* 0x09 */ 0x0C0B, /* TELUGU LETTER VOCALIC R */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0C0C, /* TELUGU LETTER VOCALIC L */
+	/* 0x0C */ reserved,
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x0C0E, /* TELUGU LETTER E */
+	/* 0x0F */ 0x0C0F, /* TELUGU LETTER EE */
+	/* 0x10 */ 0x0C10, /* TELUGU LETTER AI */
+	/* 0x11 */ reserved,
+	/* 0x12 */ 0x0C12, /* TELUGU LETTER O */
+	/* 0x13 */ 0x0C13, /* TELUGU LETTER OO */
+	/* 0x14 */ 0x0C14, /* TELUGU LETTER AU */
+	/* 0x15 */ 0x0C15, /* TELUGU LETTER KA */
+	/* 0x16 */ 0x0C16, /* TELUGU LETTER KHA */
+	/* 0x17 */ 0x0C17, /* TELUGU LETTER GA */
+	/* 0x18 */ 0x0C18, /* TELUGU LETTER GHA */
+	/* 0x19 */ 0x0C19, /* TELUGU LETTER NGA */
+	/* 0x1A */ 0x0C1A, /* TELUGU LETTER CA */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x0C1B, /* TELUGU LETTER CHA */
+	/* 0x1D */ 0x0C1C, /* TELUGU LETTER JA */
+	/* 0x1E */ 0x0C1D, /* TELUGU LETTER JHA */
+	/* 0x1F */ 0x0C1E, /* TELUGU LETTER NYA */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x0C1F, /* TELUGU LETTER TTA */
+	/* 0x23 */ 0x0C20, /* TELUGU LETTER TTHA */
+	/* 0x24 */ 0x0C21, /* TELUGU LETTER DDA */
+	/* 0x25 */ 0x0C22, /* TELUGU LETTER DDHA */
+	/* 0x26 */ 0x0C23, /* TELUGU LETTER NNA */
+	/* 0x27 */ 0x0C24, /* TELUGU LETTER TA */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0C25, /* TELUGU LETTER THA */
+	/* 0x2B */ 0x0C26, /* TELUGU LETTER DA */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0C27, /* TELUGU LETTER DHA */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0C28, /* TELUGU LETTER NA */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ reserved,
+	/* 0x3D */ 0x0C2A, /* TELUGU LETTER PA */
+	/* 0x3E */ 0x0C2B, /* TELUGU LETTER PHA */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0C2C, /* TELUGU LETTER BA */
+	/* 0x41 */ 0x0C2D, /* TELUGU LETTER BHA */
+	/* 0x42 */ 0x0C2E, /* TELUGU LETTER MA */
+	/* 0x43 */ 0x0C2F, /* TELUGU LETTER YA */
+	/* 0x44 */ 0x0C30, /* TELUGU LETTER RA */
+	/* 0x45 */ 0x0C31, /* TELUGU LETTER RRA */
+	/* 0x46 */ 0x0C32, /* TELUGU LETTER LA */
+	/* 0x47 */ 0x0C33, /* TELUGU LETTER LLA */
+	/* 0x48 */ reserved,
+	/* 0x49 */ 0x0C35, /* TELUGU LETTER VA */
+	/* 0x4A */ 0x0C36, /* TELUGU LETTER SHA */
+	/* 0x4B */ 0x0C37, /* TELUGU LETTER SSA */
+	/* 0x4C */ 0x0C38, /* TELUGU LETTER SA */
+	/* 0x4D */ 0x0C39, /* TELUGU LETTER HA */
+	/* 0x4E */ reserved,
+	/* 0x4F */ 0x0C3D, /* TELUGU SIGN AVAGRAHA */
+	/* 0x50 */ 0x0C3E, /* TELUGU VOWEL SIGN AA */
+	/* 0x51 */ 0x0C3F, /* TELUGU VOWEL SIGN I */
+	/* 0x52 */ 0x0C40, /* TELUGU VOWEL SIGN II */
+	/* 0x53 */ 0x0C41, /* TELUGU VOWEL SIGN U */
+	/* 0x54 */ 0x0C42, /* TELUGU VOWEL SIGN UU */
+	/* 0x55 */ 0x0C43, /* TELUGU VOWEL SIGN VOCALIC R */
+	/* 0x56 */ 0x0C44, /* TELUGU VOWEL SIGN VOCALIC RR */
+	/* 0x57 */ reserved,
+	/* 0x58 */ 0x0C46, /* TELUGU VOWEL SIGN E */
+	/* 0x59 */ 0x0C47, /* TELUGU VOWEL SIGN EE */
+	/* 0x5A */ 0x0C48, /* TELUGU VOWEL SIGN AI */
+	/* 0x5B */ reserved,
+	/* 0x5C */ 0x0C4A, /* TELUGU VOWEL SIGN O */
+	/* 0x5D */ 0x0C4B, /* TELUGU VOWEL SIGN OO */
+	/* 0x5E */ 0x0C4C, /* TELUGU VOWEL SIGN AU */
+	/* 0x5F */ 0x0C4D, /* TELUGU SIGN VIRAMA */
+	/* 0x60 */ 0x0C55, /* TELUGU LENGTH MARK */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0C56, /* TELUGU AI LENGTH MARK */
+	/* 0x7C */ 0x0C60, /* TELUGU LETTER VOCALIC RR */
+	/* 0x7D */ 0x0C61, /* TELUGU LETTER VOCALIC LL */
+	/* 0x7E */ 0x0C62, /* TELUGU VOWEL SIGN VOCALIC L */
+	/* 0x7F */ 0x0C63, /* TELUGU VOWEL SIGN VOCALIC LL */
+}
+
+var urduTable = runeTable{
+	/* 0x00 */ 0x0627, /* ARABIC LETTER ALEF */
+	/* 0x01 */ 0x0622, /* ARABIC LETTER ALEF WITH MADDA ABOVE */
+	/* 0x02 */ 0x0628, /* ARABIC LETTER BEH */
+	/* 0x03 */ 0x067B, /* ARABIC LETTER BEEH */
+	/* 0x04 */ 0x0680, /* ARABIC LETTER BEHEH */
+	/* 0x05 */ 0x067E, /* ARABIC LETTER PEH */
+	/* 0x06 */ 0x06A6, /* ARABIC LETTER PEHEH */
+	/* 0x07 */ 0x062A, /* ARABIC LETTER TEH */
+	/* 0x08 */ 0x06C2, /* ARABIC LETTER HEH GOAL WITH HAMZA ABOVE */
+	/* 0x09 */ 0x067F, /* ARABIC LETTER TEHEH */
+	/* 0x0A */ 0x000A, /* LINE FEED */
+	/* 0x0B */ 0x0679, /* ARABIC LETTER TTEH */
+	/* 0x0C */ 0x067D, /* ARABIC LETTER TEH WITH THREE DOTS ABOVE DOWNWARDS */
+	/* 0x0D */ 0x000D, /* CARRIAGE RETURN */
+	/* 0x0E */ 0x067A, /* ARABIC LETTER TTEHEH */
+	/* 0x0F */ 0x067C, /* ARABIC LETTER TEH WITH RING */
+	/* 0x10 */ 0x062B, /* ARABIC LETTER THEH */
+	/* 0x11 */ 0x062C, /* ARABIC LETTER JEEM */
+	/* 0x12 */ 0x0681, /* ARABIC LETTER HAH WITH HAMZA ABOVE */
+	/* 0x13 */ 0x0684, /* ARABIC LETTER DYEH */
+	/* 0x14 */ 0x0683, /* ARABIC LETTER NYEH */
+	/* 0x15 */ 0x0685, /* ARABIC LETTER HAH WITH THREE DOTS ABOVE */
+	/* 0x16 */ 0x0686, /* ARABIC LETTER TCHEH */
+	/* 0x17 */ 0x0687, /* ARABIC LETTER TCHEHEH */
+	/* 0x18 */ 0x062D, /* ARABIC LETTER HAH */
+	/* 0x19 */ 0x062E, /* ARABIC LETTER KHAH */
+	/* 0x1A */ 0x062F, /* ARABIC LETTER DAL */
+	/* 0x1B */ 0x00A0, /* ESCAPE TO EXTENSION TABLE */
+	/* 0x1C */ 0x068C, /* ARABIC LETTER DAHAL */
+	/* 0x1D */ 0x0688, /* ARABIC LETTER DDAL */
+	/* 0x1E */ 0x0689, /* ARABIC LETTER DAL WITH RING */
+	/* 0x1F */ 0x068A, /* ARABIC LETTER DAL WITH DOT BELOW */
+	/* 0x20 */ 0x0020, /* SPACE */
+	/* 0x21 */ 0x0021, /* EXCLAMATION MARK */
+	/* 0x22 */ 0x068F, /* ARABIC LETTER DAL WITH THREE DOTS ABOVE DOWNWARDS */
+	/* 0x23 */ 0x068D, /* ARABIC LETTER DDAHAL */
+	/* 0x24 */ 0x0630, /* ARABIC LETTER THAL */
+	/* 0x25 */ 0x0631, /* ARABIC LETTER REH */
+	/* 0x26 */ 0x0691, /* ARABIC LETTER RREH */
+	/* 0x27 */ 0x0693, /* ARABIC LETTER REH WITH RING */
+	/* 0x28 */ 0x0029, /* RIGHT PARENTHESIS */
+	/* 0x29 */ 0x0028, /* LEFT PARENTHESIS */
+	/* 0x2A */ 0x0699, /* ARABIC LETTER REH WITH FOUR DOTS ABOVE */
+	/* 0x2B */ 0x0632, /* ARABIC LETTER ZAIN */
+	/* 0x2C */ 0x002C, /* COMMA */
+	/* 0x2D */ 0x0696, /* ARABIC LETTER REH WITH DOT BELOW AND DOT ABOVE */
+	/* 0x2E */ 0x002E, /* FULL STOP */
+	/* 0x2F */ 0x0698, /* ARABIC LETTER JEH */
+	/* 0x30 */ 0x0030, /* DIGIT ZERO */
+	/* 0x31 */ 0x0031, /* DIGIT ONE */
+	/* 0x32 */ 0x0032, /* DIGIT TWO */
+	/* 0x33 */ 0x0033, /* DIGIT THREE */
+	/* 0x34 */ 0x0034, /* DIGIT FOUR */
+	/* 0x35 */ 0x0035, /* DIGIT FIVE */
+	/* 0x36 */ 0x0036, /* DIGIT SIX */
+	/* 0x37 */ 0x0037, /* DIGIT SEVEN */
+	/* 0x38 */ 0x0038, /* DIGIT EIGHT */
+	/* 0x39 */ 0x0039, /* DIGIT NINE */
+	/* 0x3A */ 0x003A, /* COLON */
+	/* 0x3B */ 0x003B, /* SEMICOLON */
+	/* 0x3C */ 0x069A, /* ARABIC LETTER SEEN WITH DOT BELOW AND DOT ABOVE */
+	/* 0x3D */ 0x0633, /* ARABIC LETTER SEEN */
+	/* 0x3E */ 0x0634, /* ARABIC LETTER SHEEN */
+	/* 0x3F */ 0x003F, /* QUESTION MARK */
+	/* 0x40 */ 0x0635, /* ARABIC LETTER SAD */
+	/* 0x41 */ 0x0636, /* ARABIC LETTER DAD */
+	/* 0x42 */ 0x0637, /* ARABIC LETTER TAH */
+	/* 0x43 */ 0x0638, /* ARABIC LETTER ZAH */
+	/* 0x44 */ 0x0639, /* ARABIC LETTER AIN */
+	/* 0x45 */ 0x0641, /* ARABIC LETTER FEH */
+	/* 0x46 */ 0x0642, /* ARABIC LETTER QAF */
+	/* 0x47 */ 0x06A9, /* ARABIC LETTER KEHEH */
+	/* 0x48 */ 0x06AA, /* ARABIC LETTER SWASH KAF */
+	/* 0x49 */ 0x06AB, /* ARABIC LETTER KAF WITH RING */
+	/* 0x4A */ 0x06AF, /* ARABIC LETTER GAF */
+	/* 0x4B */ 0x06B3, /* ARABIC LETTER GUEH */
+	/* 0x4C */ 0x06B1, /* ARABIC LETTER NGOEH */
+	/* 0x4D */ 0x0644, /* ARABIC LETTER LAM */
+	/* 0x4E */ 0x0645, /* ARABIC LETTER MEEM */
+	/* 0x4F */ 0x0646, /* ARABIC LETTER NOON */
+	/* 0x50 */ 0x06BA, /* ARABIC LETTER NOON GHUNNA */
+	/* 0x51 */ 0x06BB, /* ARABIC LETTER RNOON */
+	/* 0x52 */ 0x06BC, /* ARABIC LETTER NOON WITH RING */
+	/* 0x53 */ 0x0648, /* ARABIC LETTER WAW */
+	/* 0x54 */ 0x06C4, /* ARABIC LETTER WAW WITH RING */
+	/* 0x55 */ 0x06D5, /* ARABIC LETTER AE */
+	/* 0x56 */ 0x06C1, /* ARABIC LETTER HEH GOAL */
+	/* 0x57 */ 0x06BE, /* ARABIC LETTER HEH DOACHASHMEE */
+	/* 0x58 */ 0x0621, /* ARABIC LETTER HAMZA */
+	/* 0x59 */ 0x06CC, /* ARABIC LETTER FARSI YEH */
+	/* 0x5A */ 0x06D0, /* ARABIC LETTER E */
+	/* 0x5B */ 0x06D2, /* ARABIC LETTER YEH BARREE */
+	/* 0x5C */ 0x064D, /* ARABIC KASRATAN */
+	/* 0x5D */ 0x0650, /* ARABIC KASRA */
+	/* 0x5E */ 0x064F, /* ARABIC DAMMA */
+	/* 0x5F */ 0x0657, /* ARABIC INVERTED DAMMA */
+	/* 0x60 */ 0x0654, /* ARABIC HAMZA ABOVE */
+	/* 0x61 */ 0x0061, /* LATIN SMALL LETTER A */
+	/* 0x62 */ 0x0062, /* LATIN SMALL LETTER B */
+	/* 0x63 */ 0x0063, /* LATIN SMALL LETTER C */
+	/* 0x64 */ 0x0064, /* LATIN SMALL LETTER D */
+	/* 0x65 */ 0x0065, /* LATIN SMALL LETTER E */
+	/* 0x66 */ 0x0066, /* LATIN SMALL LETTER F */
+	/* 0x67 */ 0x0067, /* LATIN SMALL LETTER G */
+	/* 0x68 */ 0x0068, /* LATIN SMALL LETTER H */
+	/* 0x69 */ 0x0069, /* LATIN SMALL LETTER I */
+	/* 0x6A */ 0x006A, /* LATIN SMALL LETTER J */
+	/* 0x6B */ 0x006B, /* LATIN SMALL LETTER K */
+	/* 0x6C */ 0x006C, /* LATIN SMALL LETTER L */
+	/* 0x6D */ 0x006D, /* LATIN SMALL LETTER M */
+	/* 0x6E */ 0x006E, /* LATIN SMALL LETTER N */
+	/* 0x6F */ 0x006F, /* LATIN SMALL LETTER O */
+	/* 0x70 */ 0x0070, /* LATIN SMALL LETTER P */
+	/* 0x71 */ 0x0071, /* LATIN SMALL LETTER Q */
+	/* 0x72 */ 0x0072, /* LATIN SMALL LETTER R */
+	/* 0x73 */ 0x0073, /* LATIN SMALL LETTER S */
+	/* 0x74 */ 0x0074, /* LATIN SMALL LETTER T */
+	/* 0x75 */ 0x0075, /* LATIN SMALL LETTER U */
+	/* 0x76 */ 0x0076, /* LATIN SMALL LETTER V */
+	/* 0x77 */ 0x0077, /* LATIN SMALL LETTER W */
+	/* 0x78 */ 0x0078, /* LATIN SMALL LETTER X */
+	/* 0x79 */ 0x0079, /* LATIN SMALL LETTER Y */
+	/* 0x7A */ 0x007A, /* LATIN SMALL LETTER Z */
+	/* 0x7B */ 0x0655, /* ARABIC HAMZA BELOW */
+	/* 0x7C */ 0x0651, /* ARABIC SHADDA */
+	/* 0x7D */ 0x0653, /* ARABIC MADDAH ABOVE */
+	/* 0x7E */ 0x0656, /* ARABIC SUBSCRIPT ALEF */
+	/* 0x7F */ 0x0670, /* ARABIC LETTER SUPERSCRIPT ALEF */
+}
+
+var bengaliEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x09E6}, /* BENGALI DIGIT ZERO */
+	{0x1A, 0x09E7}, /* BENGALI DIGIT ONE */
+	{0x1C, 0x09E8}, /* BENGALI DIGIT TWO */
+	{0x1D, 0x09E9}, /* BENGALI DIGIT THREE */
+	{0x1E, 0x09EA}, /* BENGALI DIGIT FOUR */
+	{0x1F, 0x09EB}, /* BENGALI DIGIT FIVE */
+	{0x20, 0x09EC}, /* BENGALI DIGIT SIX */
+	{0x21, 0x09ED}, /* BENGALI DIGIT SEVEN */
+	{0x22, 0x09EE}, /* BENGALI DIGIT EIGHT */
+	{0x23, 0x09EF}, /* BENGALI DIGIT NINE */
+	{0x24, 0x09DF}, /* BENGALI LETTER YYA */
+	{0x25, 0x09E0}, /* BENGALI LETTER VOCALIC RR */
+	{0x26, 0x09E1}, /* BENGALI LETTER VOCALIC LL */
+	{0x27, 0x09E2}, /* BENGALI VOWEL SIGN VOCALIC L */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x09E3}, /* BENGALI VOWEL SIGN VOCALIC LL */
+	{0x2B, 0x09F2}, /* BENGALI RUPEE MARK */
+	{0x2C, 0x09F3}, /* BENGALI RUPEE SIGN */
+	{0x2D, 0x09F4}, /* BENGALI CURRENCY NUMERATOR ONE */
+	{0x2E, 0x09F5}, /* BENGALI CURRENCY NUMERATOR TWO */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x30, 0x09F6}, /* BENGALI CURRENCY NUMERATOR THREE */
+	{0x31, 0x09F7}, /* BENGALI CURRENCY NUMERATOR FOUR */
+	{0x32, 0x09F8}, /* BENGALI CURRENCY NUMERATOR ONE LESS THAN THE DENOMINATOR */
+	{0x33, 0x09F9}, /* BENGALI CURRENCY DENOMINATOR SIXTEEN */
+	{0x34, 0x09FA}, /* BENGALI ISSHAR */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var gujaratiEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0AE6}, /* GUJARATI DIGIT ZERO */
+	{0x1D, 0x0AE7}, /* GUJARATI DIGIT ONE */
+	{0x1E, 0x0AE8}, /* GUJARATI DIGIT TWO */
+	{0x1F, 0x0AE9}, /* GUJARATI DIGIT THREE */
+	{0x20, 0x0AEA}, /* GUJARATI DIGIT FOUR */
+	{0x21, 0x0AEB}, /* GUJARATI DIGIT FIVE */
+	{0x22, 0x0AEC}, /* GUJARATI DIGIT SIX */
+	{0x23, 0x0AED}, /* GUJARATI DIGIT SEVEN */
+	{0x24, 0x0AEE}, /* GUJARATI DIGIT EIGHT */
+	{0x25, 0x0AEF}, /* GUJARATI DIGIT NINE */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var hindiEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0966}, /* DEVANAGARI DIGIT ZERO */
+	{0x1D, 0x0967}, /* DEVANAGARI DIGIT ONE */
+	{0x1E, 0x0968}, /* DEVANAGARI DIGIT TWO */
+	{0x1F, 0x0969}, /* DEVANAGARI DIGIT THREE */
+	{0x20, 0x096A}, /* DEVANAGARI DIGIT FOUR */
+	{0x21, 0x096B}, /* DEVANAGARI DIGIT FIVE */
+	{0x22, 0x096C}, /* DEVANAGARI DIGIT SIX */
+	{0x23, 0x096D}, /* DEVANAGARI DIGIT SEVEN */
+	{0x24, 0x096E}, /* DEVANAGARI DIGIT EIGHT */
+	{0x25, 0x096F}, /* DEVANAGARI DIGIT NINE */
+	{0x26, 0x0951}, /* DEVANAGARI STRESS SIGN UDATTA */
+	{0x27, 0x0952}, /* DEVANAGARI STRESS SIGN ANUDATTA */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0953}, /* DEVANAGARI GRAVE ACCENT */
+	{0x2B, 0x0954}, /* DEVANAGARI ACUTE ACCENT */
+	{0x2C, 0x0958}, /* DEVANAGARI LETTER QA */
+	{0x2D, 0x0959}, /* DEVANAGARI LETTER KHHA */
+	{0x2E, 0x095A}, /* DEVANAGARI LETTER GHHA */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x30, 0x095B}, /* DEVANAGARI LETTER ZA */
+	{0x31, 0x095C}, /* DEVANAGARI LETTER DDDHA */
+	{0x32, 0x095D}, /* DEVANAGARI LETTER RHA */
+	{0x33, 0x095E}, /* DEVANAGARI LETTER FA */
+	{0x34, 0x095F}, /* DEVANAGARI LETTER YYA */
+	{0x35, 0x0960}, /* DEVANAGARI LETTER VOCALIC RR */
+	{0x36, 0x0961}, /* DEVANAGARI LETTER VOCALIC LL */
+	{0x37, 0x0962}, /* DEVANAGARI VOWEL SIGN VOCALIC L */
+	{0x38, 0x0963}, /* DEVANAGARI VOWEL SIGN VOCALIC LL */
+	{0x39, 0x0970}, /* DEVANAGARI ABBREVIATION SIGN */
+	{0x3A, 0x0971}, /* DEVANAGARI SIGN HIGH SPACING DOT */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var kannadaEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0CE6}, /* KANNADA DIGIT ZERO */
+	{0x1D, 0x0CE7}, /* KANNADA DIGIT ONE */
+	{0x1E, 0x0CE8}, /* KANNADA DIGIT TWO */
+	{0x1F, 0x0CE9}, /* KANNADA DIGIT THREE */
+	{0x20, 0x0CEA}, /* KANNADA DIGIT FOUR */
+	{0x21, 0x0CEB}, /* KANNADA DIGIT FIVE */
+	{0x22, 0x0CEC}, /* KANNADA DIGIT SIX */
+	{0x23, 0x0CED}, /* KANNADA DIGIT SEVEN */
+	{0x24, 0x0CEE}, /* KANNADA DIGIT EIGHT */
+	{0x25, 0x0CEF}, /* KANNADA DIGIT NINE */
+	{0x26, 0x0CDE}, /* KANNADA LETTER FA */
+	{0x27, 0x0CF1}, /* KANNADA SIGN JIHVAMULIYA */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0CF2}, /* KANNADA SIGN UPADHMANIYA */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var malayalamEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0D66}, /* MALAYALAM DIGIT ZERO */
+	{0x1D, 0x0D67}, /* MALAYALAM DIGIT ONE */
+	{0x1E, 0x0D68}, /* MALAYALAM DIGIT TWO */
+	{0x1F, 0x0D69}, /* MALAYALAM DIGIT THREE */
+	{0x20, 0x0D6A}, /* MALAYALAM DIGIT FOUR */
+	{0x21, 0x0D6B}, /* MALAYALAM DIGIT FIVE */
+	{0x22, 0x0D6C}, /* MALAYALAM DIGIT SIX */
+	{0x23, 0x0D6D}, /* MALAYALAM DIGIT SEVEN */
+	{0x24, 0x0D6E}, /* MALAYALAM DIGIT EIGHT */
+	{0x25, 0x0D6F}, /* MALAYALAM DIGIT NINE */
+	{0x26, 0x0D70}, /* MALAYALAM NUMBER TEN */
+	{0x27, 0x0D71}, /* MALAYALAM NUMBER ONE HUNDRED */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0D72}, /* MALAYALAM NUMBER ONE THOUSAND */
+	{0x2B, 0x0D73}, /* MALAYALAM FRACTION ONE QUARTER */
+	{0x2C, 0x0D74}, /* MALAYALAM FRACTION ONE HALF */
+	{0x2D, 0x0D75}, /* MALAYALAM FRACTION THREE QUARTERS */
+	{0x2E, 0x0D7A}, /* MALAYALAM LETTER CHILLU NN */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x30, 0x0D7B}, /* MALAYALAM LETTER CHILLU N */
+	{0x31, 0x0D7C}, /* MALAYALAM LETTER CHILLU RR */
+	{0x32, 0x0D7D}, /* MALAYALAM LETTER CHILLU L */
+	{0x33, 0x0D7E}, /* MALAYALAM LETTER CHILLU LL */
+	{0x34, 0x0D7F}, /* MALAYALAM LETTER CHILLU K */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var oriyaEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0B66}, /* ORIYA DIGIT ZERO */
+	{0x1D, 0x0B67}, /* ORIYA DIGIT ONE */
+	{0x1E, 0x0B68}, /* ORIYA DIGIT TWO */
+	{0x1F, 0x0B69}, /* ORIYA DIGIT THREE */
+	{0x20, 0x0B6A}, /* ORIYA DIGIT FOUR */
+	{0x21, 0x0B6B}, /* ORIYA DIGIT FIVE */
+	{0x22, 0x0B6C}, /* ORIYA DIGIT SIX */
+	{0x23, 0x0B6D}, /* ORIYA DIGIT SEVEN */
+	{0x24, 0x0B6E}, /* ORIYA DIGIT EIGHT */
+	{0x25, 0x0B6F}, /* ORIYA DIGIT NINE */
+	{0x26, 0x0B5C}, /* ORIYA LETTER RRA */
+	{0x27, 0x0B5D}, /* ORIYA LETTER RHA */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0B5F}, /* ORIYA LETTER YYA */
+	{0x2B, 0x0B70}, /* ORIYA ISSHAR */
+	{0x2C, 0x0B71}, /* ORIYA LETTER WA */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var punjabiEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0A66}, /* GURMUKHI DIGIT ZERO */
+	{0x1D, 0x0A67}, /* GURMUKHI DIGIT ONE */
+	{0x1E, 0x0A68}, /* GURMUKHI DIGIT TWO */
+	{0x1F, 0x0A69}, /* GURMUKHI DIGIT THREE */
+	{0x20, 0x0A6A}, /* GURMUKHI DIGIT FOUR */
+	{0x21, 0x0A6B}, /* GURMUKHI DIGIT FIVE */
+	{0x22, 0x0A6C}, /* GURMUKHI DIGIT SIX */
+	{0x23, 0x0A6D}, /* GURMUKHI DIGIT SEVEN */
+	{0x24, 0x0A6E}, /* GURMUKHI DIGIT EIGHT */
+	{0x25, 0x0A6F}, /* GURMUKHI DIGIT NINE */
+	{0x26, 0x0A59}, /* GURMUKHI LETTER KHHA */
+	{0x27, 0x0A5A}, /* GURMUKHI LETTER GHHA */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0A5B}, /* GURMUKHI LETTER ZA */
+	{0x2B, 0x0A5C}, /* GURMUKHI LETTER RRA */
+	{0x2C, 0x0A5E}, /* GURMUKHI LETTER FA */
+	{0x2D, 0x0A75}, /* GURMUKHI SIGN YAKASH */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var tamilEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0964}, /* DEVANAGARI DANDA */
+	{0x1A, 0x0965}, /* DEVANAGARI DOUBLE DANDA */
+	{0x1C, 0x0BE6}, /* TAMIL DIGIT ZERO */
+	{0x1D, 0x0BE7}, /* TAMIL DIGIT ONE */
+	{0x1E, 0x0BE8}, /* TAMIL DIGIT TWO */
+	{0x1F, 0x0BE9}, /* TAMIL DIGIT THREE */
+	{0x20, 0x0BEA}, /* TAMIL DIGIT FOUR */
+	{0x21, 0x0BEB}, /* TAMIL DIGIT FIVE */
+	{0x22, 0x0BEC}, /* TAMIL DIGIT SIX */
+	{0x23, 0x0BED}, /* TAMIL DIGIT SEVEN */
+	{0x24, 0x0BEE}, /* TAMIL DIGIT EIGHT */
+	{0x25, 0x0BEF}, /* TAMIL DIGIT NINE */
+	{0x26, 0x0BF3}, /* TAMIL DAY SIGN */
+	{0x27, 0x0BF4}, /* TAMIL MONTH SIGN */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0BF5}, /* TAMIL YEAR SIGN */
+	{0x2B, 0x0BF6}, /* TAMIL DEBIT SIGN */
+	{0x2C, 0x0BF7}, /* TAMIL CREDIT SIGN */
+	{0x2D, 0x0BF8}, /* TAMIL AS ABOVE SIGN */
+	{0x2E, 0x0BFA}, /* TAMIL NUMBER SIGN */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var teluguEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x1C, 0x0C66}, /* TELUGU DIGIT ZERO */
+	{0x1D, 0x0C67}, /* TELUGU DIGIT ONE */
+	{0x1E, 0x0C68}, /* TELUGU DIGIT TWO */
+	{0x1F, 0x0C69}, /* TELUGU DIGIT THREE */
+	{0x20, 0x0C6A}, /* TELUGU DIGIT FOUR */
+	{0x21, 0x0C6B}, /* TELUGU DIGIT FIVE */
+	{0x22, 0x0C6C}, /* TELUGU DIGIT SIX */
+	{0x23, 0x0C6D}, /* TELUGU DIGIT SEVEN */
+	{0x24, 0x0C6E}, /* TELUGU DIGIT EIGHT */
+	{0x25, 0x0C6F}, /* TELUGU DIGIT NINE */
+	{0x26, 0x0C58}, /* TELUGU LETTER TSA */
+	{0x27, 0x0C59}, /* TELUGU LETTER DZA */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x0C78}, /* TELUGU FRACTION DIGIT ZERO FOR ODD POWERS OF FOUR */
+	{0x2B, 0x0C79}, /* TELUGU FRACTION DIGIT ONE FOR ODD POWERS OF FOUR */
+	{0x2C, 0x0C7A}, /* TELUGU FRACTION DIGIT TWO FOR ODD POWERS OF FOUR */
+	{0x2D, 0x0C7B}, /* TELUGU FRACTION DIGIT THREE FOR ODD POWERS OF FOUR */
+	{0x2E, 0x0C7C}, /* TELUGU FRACTION DIGIT ONE FOR EVEN POWERS OF FOUR */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x30, 0x0C7D}, /* TELUGU FRACTION DIGIT TWO FOR EVEN POWERS OF FOUR */
+	{0x31, 0x0C7E}, /* TELUGU FRACTION DIGIT THREE FOR EVEN POWERS OF FOUR */
+	{0x32, 0x0C7F}, /* TELUGU SIGN TUUMU */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
+
+var urduEscapes = escapeTable{
+	{0x00, 0x0040}, /* COMMERCIAL AT */
+	{0x01, 0x00A3}, /* POUND SIGN */
+	{0x02, 0x0024}, /* DOLLAR SIGN */
+	{0x03, 0x00A5}, /* YEN SIGN */
+	{0x04, 0x00BF}, /* INVERTED QUESTION MARK */
+	{0x05, 0x0022}, /* QUOTATION MARK */
+	{0x06, 0x00A4}, /* CURRENCY SIGN */
+	{0x07, 0x0025}, /* PERCENT SIGN */
+	{0x08, 0x0026}, /* AMPERSAND */
+	{0x09, 0x0027}, /* APOSTROPHE */
+	{0x0A, 0x000C}, /* FORM FEED */
+	{0x0B, 0x002A}, /* ASTERISK */
+	{0x0C, 0x002B}, /* PLUS SIGN */
+	{0x0E, 0x002D}, /* HYPHEN-MINUS */
+	{0x0F, 0x002F}, /* SOLIDUS */
+	{0x10, 0x003C}, /* LESS-THAN SIGN */
+	{0x11, 0x003D}, /* EQUALS SIGN */
+	{0x12, 0x003E}, /* GREATER-THAN SIGN */
+	{0x13, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x14, 0x005E}, /* CIRCUMFLEX ACCENT */
+	{0x15, 0x00A1}, /* INVERTED EXCLAMATION MARK */
+	{0x16, 0x005F}, /* LOW LINE */
+	{0x17, 0x0023}, /* NUMBER SIGN */
+	{0x18, 0x002A}, /* ASTERISK */
+	{0x19, 0x0600}, /* ARABIC NUMBER SIGN */
+	{0x1A, 0x0601}, /* ARABIC SIGN SANAH */
+	{0x1C, 0x06F0}, /* EXTENDED ARABIC-INDIC DIGIT ZERO */
+	{0x1D, 0x06F1}, /* EXTENDED ARABIC-INDIC DIGIT ONE */
+	{0x1E, 0x06F2}, /* EXTENDED ARABIC-INDIC DIGIT TWO */
+	{0x1F, 0x06F3}, /* EXTENDED ARABIC-INDIC DIGIT THREE */
+	{0x20, 0x06F4}, /* EXTENDED ARABIC-INDIC DIGIT FOUR */
+	{0x21, 0x06F5}, /* EXTENDED ARABIC-INDIC DIGIT FIVE */
+	{0x22, 0x06F6}, /* EXTENDED ARABIC-INDIC DIGIT SIX */
+	{0x23, 0x06F7}, /* EXTENDED ARABIC-INDIC DIGIT SEVEN */
+	{0x24, 0x06F8}, /* EXTENDED ARABIC-INDIC DIGIT EIGHT */
+	{0x25, 0x06F9}, /* EXTENDED ARABIC-INDIC DIGIT NINE */
+	{0x26, 0x060C}, /* ARABIC COMMA */
+	{0x27, 0x060D}, /* ARABIC DATE SEPARATOR */
+	{0x28, 0x007B}, /* LEFT CURLY BRACKET */
+	{0x29, 0x007D}, /* RIGHT CURLY BRACKET */
+	{0x2A, 0x060E}, /* ARABIC POETIC VERSE SIGN */
+	{0x2B, 0x060F}, /* ARABIC SIGN MISRA */
+	{0x2C, 0x0610}, /* ARABIC SIGN SALLALLAHOU ALAYHE WASSALLAM */
+	{0x2D, 0x0611}, /* ARABIC SIGN ALAYHE ASSALLAM */
+	{0x2E, 0x0612}, /* ARABIC SIGN RAHMATULLAH ALAYHE */
+	{0x2F, 0x005C}, /* REVERSE SOLIDUS */
+	{0x30, 0x0613}, /* ARABIC SIGN RADI ALLAHOU ANHU */
+	{0x31, 0x0614}, /* ARABIC SIGN TAKHALLUS */
+	{0x32, 0x061B}, /* ARABIC SEMICOLON */
+	{0x33, 0x061F}, /* ARABIC QUESTION MARK */
+	{0x34, 0x0640}, /* ARABIC TATWEEL */
+	{0x35, 0x0652}, /* ARABIC SUKUN */
+	{0x36, 0x0658}, /* ARABIC MARK NOON GHUNNA */
+	{0x37, 0x066B}, /* ARABIC DECIMAL SEPARATOR */
+	{0x38, 0x066C}, /* ARABIC THOUSANDS SEPARATOR */
+	{0x39, 0x0672}, /* ARABIC LETTER ALEF WITH WAVY HAMZA ABOVE */
+	{0x3A, 0x0673}, /* ARABIC LETTER ALEF WITH WAVY HAMZA BELOW */
+	{0x3B, 0x06CD}, /* ARABIC LETTER YEH WITH TAIL */
+	{0x3C, 0x005B}, /* LEFT SQUARE BRACKET */
+	{0x3D, 0x007E}, /* TILDE */
+	{0x3E, 0x005D}, /* RIGHT SQUARE BRACKET */
+	{0x3F, 0x06D4}, /* ARABIC FULL STOP */
+	{0x40, 0x007C}, /* VERTICAL LINE */
+	{0x41, 0x0041}, /* LATIN CAPITAL LETTER A */
+	{0x42, 0x0042}, /* LATIN CAPITAL LETTER B */
+	{0x43, 0x0043}, /* LATIN CAPITAL LETTER C */
+	{0x44, 0x0044}, /* LATIN CAPITAL LETTER D */
+	{0x45, 0x0045}, /* LATIN CAPITAL LETTER E */
+	{0x46, 0x0046}, /* LATIN CAPITAL LETTER F */
+	{0x47, 0x0047}, /* LATIN CAPITAL LETTER G */
+	{0x48, 0x0048}, /* LATIN CAPITAL LETTER H */
+	{0x49, 0x0049}, /* LATIN CAPITAL LETTER I */
+	{0x4A, 0x004A}, /* LATIN CAPITAL LETTER J */
+	{0x4B, 0x004B}, /* LATIN CAPITAL LETTER K */
+	{0x4C, 0x004C}, /* LATIN CAPITAL LETTER L */
+	{0x4D, 0x004D}, /* LATIN CAPITAL LETTER M */
+	{0x4E, 0x004E}, /* LATIN CAPITAL LETTER N */
+	{0x4F, 0x004F}, /* LATIN CAPITAL LETTER O */
+	{0x50, 0x0050}, /* LATIN CAPITAL LETTER P */
+	{0x51, 0x0051}, /* LATIN CAPITAL LETTER Q */
+	{0x52, 0x0052}, /* LATIN CAPITAL LETTER R */
+	{0x53, 0x0053}, /* LATIN CAPITAL LETTER S */
+	{0x54, 0x0054}, /* LATIN CAPITAL LETTER T */
+	{0x55, 0x0055}, /* LATIN CAPITAL LETTER U */
+	{0x56, 0x0056}, /* LATIN CAPITAL LETTER V */
+	{0x57, 0x0057}, /* LATIN CAPITAL LETTER W */
+	{0x58, 0x0058}, /* LATIN CAPITAL LETTER X */
+	{0x59, 0x0059}, /* LATIN CAPITAL LETTER Y */
+	{0x5A, 0x005A}, /* LATIN CAPITAL LETTER Z */
+	{0x65, 0x20AC}, /* EURO SIGN */
+}
@@ -0,0 +1,47 @@
+package pdu
+
+import "unicode/utf8"
+
+// EncodeLatin1 encodes the given UTF-8 text into ISO-8859-1 (Latin-1), one octet per character,
+// e.g. for the 8-bit text of the legacy SMSCs. The characters above U+00FF are replaced with "?".
+func EncodeLatin1(str string) []byte {
+	return AppendLatin1(make([]byte, 0, len(str)), str)
+}
+
+// AppendLatin1 is like EncodeLatin1, but appends the octets to dst and returns the extended buffer.
+func AppendLatin1(dst []byte, str string) []byte {
+	for _, r := range str {
+		if r > 0xFF {
+			r = unknown
+		}
+		dst = append(dst, byte(r))
+	}
+	return dst
+}
+
+// DecodeLatin1 decodes the given ISO-8859-1 (Latin-1) octets into a UTF-8 encoded string,
+// every octet is a character.
+func DecodeLatin1(octets []byte) string {
+	buf := make([]byte, 0, len(octets)*2)
+	for _, oct := range octets {
+		if oct < utf8.RuneSelf {
+			buf = append(buf, oct)
+			continue
+		}
+		var enc [utf8.UTFMax]byte
+		n := utf8.EncodeRune(enc[:], rune(oct))
+		buf = append(buf, enc[:n]...)
+	}
+	return string(buf)
+}
+
+// IsLatin1Encodable reports whether the text can be encoded into ISO-8859-1 as is,
+// see EncodeLatin1. The invalid UTF-8 can't be encoded.
+func IsLatin1Encodable(str string) bool {
+	for _, r := range str {
+		if r > 0xFF || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
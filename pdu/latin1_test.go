@@ -0,0 +1,21 @@
+package pdu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatin1(t *testing.T) {
+	t.Parallel()
+
+	octets := EncodeLatin1("Café ñ ÿ ©")
+	assert.Equal(t, []byte{0x43, 0x61, 0x66, 0xE9, 0x20, 0xF1, 0x20, 0xFF, 0x20, 0xA9}, octets)
+	assert.Equal(t, "Café ñ ÿ ©", DecodeLatin1(octets))
+	assert.True(t, IsLatin1Encodable("Café ñ ÿ ©"))
+
+	assert.Equal(t, []byte("a?b?"), EncodeLatin1("a€b😀"))
+	assert.False(t, IsLatin1Encodable("a€b"))
+	assert.False(t, IsLatin1Encodable("a\xFFb"))
+	assert.Empty(t, DecodeLatin1(nil))
+}
@@ -0,0 +1,299 @@
+package pdu
+
+import "unicode/utf8"
+
+// reserved is the character of the septets the locking shift tables leave unassigned.
+const reserved = utf8.RuneError
+
+// Language identifies the national language shift tables of GSM 7-bit encoding,
+// as specified in 3GPP TS 23.038, section 6.2.1.2.4.
+type Language byte
+
+// Languages represent the national languages of 3GPP TS 23.038, annex A, Default selects
+// the default alphabet and its extension table. The Spanish language has the single shift
+// table only. The reserved identifiers select the default tables, as the receiving entity
+// ignores them (3GPP TS 23.040, section 9.2.3.24.15).
+var Languages = struct {
+	Default    Language
+	Turkish    Language
+	Spanish    Language
+	Portuguese Language
+	Bengali    Language
+	Gujarati   Language
+	Hindi      Language
+	Kannada    Language
+	Malayalam  Language
+	Oriya      Language
+	Punjabi    Language
+	Tamil      Language
+	Telugu     Language
+	Urdu       Language
+}{
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D,
+}
+
+// Encode7BitWithTables is like Encode7Bit, but uses the locking shift table instead of the default
+// alphabet and the single shift table instead of the extension table. The default tables are used
+// for the languages without a table of the kind and for the reserved identifiers, see Languages.
+func Encode7BitWithTables(str string, locking, single Language) []byte {
+	return encode7Bit(make([]byte, 0, len(str)+1), str, 0, lockingShiftTable(locking),
+		singleShiftTable(single))
+}
+
+// Encode7BitWithFill is like Encode7BitWithTables, but the packed septets are preceded by the given
+// number of fill bits, 0 to 6, e.g. to start the text that follows the user data header of a message
+// on the septet boundary (3GPP TS 23.040, section 9.2.3.24).
+func Encode7BitWithFill(str string, fill int, locking, single Language) []byte {
+	return AppendEncode7BitWithFill(make([]byte, 0, len(str)+1), str, fill, locking, single)
+}
+
+// AppendEncode7BitWithFill is like Encode7BitWithFill, but appends the octets to dst and returns
+// the extended buffer, e.g. to put the text right after the user data header.
+func AppendEncode7BitWithFill(dst []byte, str string, fill int, locking, single Language) []byte {
+	return encode7Bit(dst, str, fill, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// Decode7BitWithTables is like Decode7Bit, but uses the given locking and single shift tables,
+// see Encode7BitWithTables. The reserved septets of the locking shift tables are decoded as U+FFFD.
+func Decode7BitWithTables(octets []byte, locking, single Language) (string, error) {
+	return decode7Bit(octets, 0, -1, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// Decode7BitWithFill is like Decode7BitWithTables, but skips the given number of fill bits
+// that precede the packed septets, see Encode7BitWithFill.
+func Decode7BitWithFill(octets []byte, fill int, locking, single Language) (string, error) {
+	return Decode7BitWithFillLen(octets, fill, -1, locking, single)
+}
+
+// Decode7BitWithFillLen is like Decode7BitWithFill, but decodes the given number of septets
+// exactly, see Decode7BitLen. The negative number of septets means the number is unknown.
+func Decode7BitWithFillLen(octets []byte, fill, septets int, locking, single Language) (string, error) {
+	return decode7Bit(octets, fill, septets, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// Len7BitWithTables is like Len7Bit, but uses the given locking and single shift tables,
+// see Encode7BitWithTables.
+func Len7BitWithTables(str string, locking, single Language) int {
+	return len7Bit(str, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// Check7BitWithTables is like Check7Bit, but uses the given locking and single shift tables,
+// see Encode7BitWithTables.
+func Check7BitWithTables(str string, locking, single Language) (septets, invalid int) {
+	return check7Bit(str, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// Is7BitEncodableWithTables is like Is7BitEncodable, but uses the given locking and single shift tables,
+// see Encode7BitWithTables.
+func Is7BitEncodableWithTables(str string, locking, single Language) bool {
+	return is7BitEncodable(str, lockingShiftTable(locking), singleShiftTable(single))
+}
+
+// LockingShiftTable returns the characters of the septets 0x00 to 0x7F of the locking shift table
+// of the language, it's the default alphabet for Default, the languages without the table and the
+// reserved identifiers as the encoding uses, see Encode7BitWithTables. The entry of the escape 0x1B
+// is a placeholder, it doesn't stand for a character, neither do the reserved septets of U+FFFD.
+func (l Language) LockingShiftTable() [0x80]rune {
+	return *lockingShiftTable(l)
+}
+
+// SingleShiftTable returns the characters of the septets that follow the escape in the single shift
+// table of the language, it's the extension table of the default alphabet for Default, the languages
+// without the table and the reserved identifiers. The septets missing from the table stand for the
+// characters of the locking shift table.
+func (l Language) SingleShiftTable() map[byte]rune {
+	escapes := singleShiftTable(l)
+	table := make(map[byte]rune, len(escapes))
+	for _, esc := range escapes {
+		table[esc.from] = esc.to
+	}
+	return table
+}
+
+// ReverseTable returns the septets of the characters encodable with the given locking and single
+// shift tables, one septet for the character of the locking shift table or the escape and the septet
+// of the single shift table, e.g. to validate the text or transliterate it before the encoding.
+// The character of both tables is encoded with the locking shift table.
+func ReverseTable(locking, single Language) map[rune][]byte {
+	table, escapes := lockingShiftTable(locking), singleShiftTable(single)
+	septets := make(map[rune][]byte, len(table)+len(escapes))
+	// the first of the equal characters is encoded
+	for i := len(escapes) - 1; i >= 0; i-- {
+		septets[escapes[i].to] = []byte{Esc, escapes[i].from}
+	}
+	for i := len(table) - 1; i >= 0; i-- {
+		if byte(i) != Esc && table[i] != reserved {
+			septets[table[i]] = []byte{byte(i)}
+		}
+	}
+	return septets
+}
+
+func lockingShiftTable(lang Language) *runeTable {
+	switch lang {
+	case Languages.Turkish:
+		return &turkishTable
+	case Languages.Portuguese:
+		return &portugueseTable
+	case Languages.Bengali:
+		return &bengaliTable
+	case Languages.Gujarati:
+		return &gujaratiTable
+	case Languages.Hindi:
+		return &hindiTable
+	case Languages.Kannada:
+		return &kannadaTable
+	case Languages.Malayalam:
+		return &malayalamTable
+	case Languages.Oriya:
+		return &oriyaTable
+	case Languages.Punjabi:
+		return &punjabiTable
+	case Languages.Tamil:
+		return &tamilTable
+	case Languages.Telugu:
+		return &teluguTable
+	case Languages.Urdu:
+		return &urduTable
+	default:
+		return &gsmTable
+	}
+}
+
+func singleShiftTable(lang Language) escapeTable {
+	switch lang {
+	case Languages.Turkish:
+		return turkishEscapes
+	case Languages.Spanish:
+		return spanishEscapes
+	case Languages.Portuguese:
+		return portugueseEscapes
+	case Languages.Bengali:
+		return bengaliEscapes
+	case Languages.Gujarati:
+		return gujaratiEscapes
+	case Languages.Hindi:
+		return hindiEscapes
+	case Languages.Kannada:
+		return kannadaEscapes
+	case Languages.Malayalam:
+		return malayalamEscapes
+	case Languages.Oriya:
+		return oriyaEscapes
+	case Languages.Punjabi:
+		return punjabiEscapes
+	case Languages.Tamil:
+		return tamilEscapes
+	case Languages.Telugu:
+		return teluguEscapes
+	case Languages.Urdu:
+		return urduEscapes
+	default:
+		return gsmEscapes
+	}
+}
+
+// nationalTable returns the default alphabet with the given characters replaced.
+func nationalTable(runes map[byte]rune) runeTable {
+	table := gsmTable
+	for b, r := range runes {
+		table[b] = r
+	}
+	return table
+}
+
+var turkishTable = nationalTable(map[byte]rune{
+	0x04: 0x20AC, /* EURO SIGN */
+	0x07: 0x0131, /* LATIN SMALL LETTER DOTLESS I */
+	0x09: 0x00C7, /* LATIN CAPITAL LETTER C WITH CEDILLA */
+	0x0B: 0x011E, /* LATIN CAPITAL LETTER G WITH BREVE */
+	0x0C: 0x011F, /* LATIN SMALL LETTER G WITH BREVE */
+	0x1C: 0x015E, /* LATIN CAPITAL LETTER S WITH CEDILLA */
+	0x1D: 0x015F, /* LATIN SMALL LETTER S WITH CEDILLA */
+	0x40: 0x0130, /* LATIN CAPITAL LETTER I WITH DOT ABOVE */
+	0x60: 0x00E7, /* LATIN SMALL LETTER C WITH CEDILLA */
+})
+
+var portugueseTable = nationalTable(map[byte]rune{
+	0x04: 0x00EA, /* LATIN SMALL LETTER E WITH CIRCUMFLEX */
+	0x06: 0x00FA, /* LATIN SMALL LETTER U WITH ACUTE */
+	0x07: 0x00ED, /* LATIN SMALL LETTER I WITH ACUTE */
+	0x08: 0x00F3, /* LATIN SMALL LETTER O WITH ACUTE */
+	0x0B: 0x00D4, /* LATIN CAPITAL LETTER O WITH CIRCUMFLEX */
+	0x0C: 0x00F4, /* LATIN SMALL LETTER O WITH CIRCUMFLEX */
+	0x0E: 0x00C1, /* LATIN CAPITAL LETTER A WITH ACUTE */
+	0x0F: 0x00E1, /* LATIN SMALL LETTER A WITH ACUTE */
+	0x12: 0x00AA, /* FEMININE ORDINAL INDICATOR */
+	0x13: 0x00C7, /* LATIN CAPITAL LETTER C WITH CEDILLA */
+	0x14: 0x00C0, /* LATIN CAPITAL LETTER A WITH GRAVE */
+	0x15: 0x221E, /* INFINITY */
+	0x16: 0x005E, /* CIRCUMFLEX ACCENT */
+	0x17: 0x005C, /* REVERSE SOLIDUS */
+	0x18: 0x20AC, /* EURO SIGN */
+	0x19: 0x00D3, /* LATIN CAPITAL LETTER O WITH ACUTE */
+	0x1A: 0x007C, /* VERTICAL LINE */
+	0x1C: 0x00C2, /* LATIN CAPITAL LETTER A WITH CIRCUMFLEX */
+	0x1D: 0x00E2, /* LATIN SMALL LETTER A WITH CIRCUMFLEX */
+	0x1E: 0x00CA, /* LATIN CAPITAL LETTER E WITH CIRCUMFLEX */
+	0x24: 0x00BA, /* MASCULINE ORDINAL INDICATOR */
+	0x40: 0x00CD, /* LATIN CAPITAL LETTER I WITH ACUTE */
+	0x5B: 0x00C3, /* LATIN CAPITAL LETTER A WITH TILDE */
+	0x5C: 0x00D5, /* LATIN CAPITAL LETTER O WITH TILDE */
+	0x5D: 0x00DA, /* LATIN CAPITAL LETTER U WITH ACUTE */
+	0x60: 0x007E, /* TILDE */
+	0x7B: 0x00E3, /* LATIN SMALL LETTER A WITH TILDE */
+	0x7C: 0x00F5, /* LATIN SMALL LETTER O WITH TILDE */
+	0x7D: 0x0060, /* GRAVE ACCENT */
+})
+
+var turkishEscapes = append(escapeTable{
+	{0x47, 0x011E}, /* LATIN CAPITAL LETTER G WITH BREVE */
+	{0x49, 0x0130}, /* LATIN CAPITAL LETTER I WITH DOT ABOVE */
+	{0x53, 0x015E}, /* LATIN CAPITAL LETTER S WITH CEDILLA */
+	{0x63, 0x00E7}, /* LATIN SMALL LETTER C WITH CEDILLA */
+	{0x67, 0x011F}, /* LATIN SMALL LETTER G WITH BREVE */
+	{0x69, 0x0131}, /* LATIN SMALL LETTER DOTLESS I */
+	{0x73, 0x015F}, /* LATIN SMALL LETTER S WITH CEDILLA */
+}, gsmEscapes...)
+
+var spanishEscapes = append(escapeTable{
+	{0x09, 0x00E7}, /* LATIN SMALL LETTER C WITH CEDILLA */
+	{0x41, 0x00C1}, /* LATIN CAPITAL LETTER A WITH ACUTE */
+	{0x49, 0x00CD}, /* LATIN CAPITAL LETTER I WITH ACUTE */
+	{0x4F, 0x00D3}, /* LATIN CAPITAL LETTER O WITH ACUTE */
+	{0x55, 0x00DA}, /* LATIN CAPITAL LETTER U WITH ACUTE */
+	{0x61, 0x00E1}, /* LATIN SMALL LETTER A WITH ACUTE */
+	{0x69, 0x00ED}, /* LATIN SMALL LETTER I WITH ACUTE */
+	{0x6F, 0x00F3}, /* LATIN SMALL LETTER O WITH ACUTE */
+	{0x75, 0x00FA}, /* LATIN SMALL LETTER U WITH ACUTE */
+}, gsmEscapes...)
+
+var portugueseEscapes = append(escapeTable{
+	{0x05, 0x00EA}, /* LATIN SMALL LETTER E WITH CIRCUMFLEX */
+	{0x09, 0x00E7}, /* LATIN SMALL LETTER C WITH CEDILLA */
+	{0x0B, 0x00D4}, /* LATIN CAPITAL LETTER O WITH CIRCUMFLEX */
+	{0x0C, 0x00F4}, /* LATIN SMALL LETTER O WITH CIRCUMFLEX */
+	{0x0E, 0x00C1}, /* LATIN CAPITAL LETTER A WITH ACUTE */
+	{0x0F, 0x00E1}, /* LATIN SMALL LETTER A WITH ACUTE */
+	{0x12, 0x03A6}, /* GREEK CAPITAL LETTER PHI */
+	{0x13, 0x0393}, /* GREEK CAPITAL LETTER GAMMA */
+	{0x15, 0x03A9}, /* GREEK CAPITAL LETTER OMEGA */
+	{0x16, 0x03A0}, /* GREEK CAPITAL LETTER PI */
+	{0x17, 0x03A8}, /* GREEK CAPITAL LETTER PSI */
+	{0x18, 0x03A3}, /* GREEK CAPITAL LETTER SIGMA */
+	{0x19, 0x0398}, /* GREEK CAPITAL LETTER THETA */
+	{0x1F, 0x00CA}, /* LATIN CAPITAL LETTER E WITH CIRCUMFLEX */
+	{0x41, 0x00C0}, /* LATIN CAPITAL LETTER A WITH GRAVE */
+	{0x49, 0x00CD}, /* LATIN CAPITAL LETTER I WITH ACUTE */
+	{0x4F, 0x00D3}, /* LATIN CAPITAL LETTER O WITH ACUTE */
+	{0x55, 0x00DA}, /* LATIN CAPITAL LETTER U WITH ACUTE */
+	{0x5B, 0x00C3}, /* LATIN CAPITAL LETTER A WITH TILDE */
+	{0x5C, 0x00D5}, /* LATIN CAPITAL LETTER O WITH TILDE */
+	{0x61, 0x00C2}, /* LATIN CAPITAL LETTER A WITH CIRCUMFLEX */
+	{0x69, 0x00ED}, /* LATIN SMALL LETTER I WITH ACUTE */
+	{0x6F, 0x00F3}, /* LATIN SMALL LETTER O WITH ACUTE */
+	{0x75, 0x00FA}, /* LATIN SMALL LETTER U WITH ACUTE */
+	{0x7B, 0x00E3}, /* LATIN SMALL LETTER A WITH TILDE */
+	{0x7C, 0x00F5}, /* LATIN SMALL LETTER O WITH TILDE */
+	{0x7F, 0x00E2}, /* LATIN SMALL LETTER A WITH CIRCUMFLEX */
+}, gsmEscapes...)
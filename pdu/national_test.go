@@ -0,0 +1,145 @@
+package pdu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNationalTables(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		str             string
+		locking, single Language
+		septets         int
+	}{
+		{"Çığ düştü, İş güç", Languages.Turkish, Languages.Turkish, 17},
+		{"Ğ ı ş {}", Languages.Default, Languages.Turkish, 13},
+		{"Ñandú, ácido", Languages.Default, Languages.Spanish, 14},
+		{"Olá, não há pão", Languages.Portuguese, Languages.Portuguese, 15},
+		{"Ação: Ê€", Languages.Portuguese, Languages.Default, 8},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.septets, Len7BitWithTables(tc.str, tc.locking, tc.single), tc.str)
+		assert.True(t, Is7BitEncodableWithTables(tc.str, tc.locking, tc.single), tc.str)
+		octets := Encode7BitWithTables(tc.str, tc.locking, tc.single)
+		str, err := Decode7BitWithTables(octets, tc.locking, tc.single)
+		require.NoError(t, err)
+		assert.Equal(t, tc.str, str)
+	}
+
+	assert.False(t, Is7BitEncodable("Çığ"))
+	assert.False(t, Is7BitEncodableWithTables("Çığ á", Languages.Turkish, Languages.Turkish))
+
+	// the same septet means a different character in the default alphabet
+	octets := Encode7BitWithTables("ğ", Languages.Turkish, Languages.Default)
+	str, err := Decode7Bit(octets)
+	require.NoError(t, err)
+	assert.Equal(t, "ø", str)
+}
+
+func TestIndicTables(t *testing.T) {
+	t.Parallel()
+
+	langs := []Language{Languages.Bengali, Languages.Gujarati, Languages.Hindi, Languages.Kannada,
+		Languages.Malayalam, Languages.Oriya, Languages.Punjabi, Languages.Tamil, Languages.Telugu, Languages.Urdu}
+	for _, lang := range langs {
+		// all the characters of both tables
+		var b strings.Builder
+		var septets int
+		for i, r := range lang.LockingShiftTable() {
+			if byte(i) != Esc && r != reserved {
+				b.WriteRune(r)
+				septets++
+			}
+		}
+		for _, r := range lang.SingleShiftTable() {
+			b.WriteRune(r)
+			septets += 2
+		}
+		str := b.String()
+		assert.Equal(t, septets, Len7BitWithTables(str, lang, lang), lang)
+		assert.True(t, Is7BitEncodableWithTables(str, lang, lang), lang)
+		assert.False(t, Is7BitEncodableWithTables(str, Languages.Default, Languages.Default), lang)
+		out, err := Decode7BitWithTables(Encode7BitWithTables(str, lang, lang), lang, lang)
+		require.NoError(t, err)
+		assert.Equal(t, str, out, lang)
+	}
+
+	testcases := []struct {
+		str     string
+		lang    Language
+		septets []byte
+	}{
+		{"नमस्ते", Languages.Hindi, []byte{0x2F, 0x42, 0x4C, 0x5F, 0x27, 0x59}},
+		{"বাংলা", Languages.Bengali, []byte{0x40, 0x50, 0x01, 0x46, 0x50}},
+		{"தமிழ்", Languages.Tamil, []byte{0x27, 0x42, 0x51, 0x48, 0x5F}},
+		{"اردو", Languages.Urdu, []byte{0x00, 0x25, 0x1A, 0x53}},
+		{"१२३ है।", Languages.Hindi, []byte{Esc, 0x1D, Esc, 0x1E, Esc, 0x1F, 0x20, 0x4D, 0x5A, Esc, 0x19}},
+		{"hi {}", Languages.Telugu, []byte{0x68, 0x69, 0x20, Esc, 0x28, Esc, 0x29}},
+	}
+	for _, tc := range testcases {
+		octets := Encode7BitWithTables(tc.str, tc.lang, tc.lang)
+		assert.Equal(t, Pack7Bit(tc.septets, 0), octets, tc.str)
+		str, err := Decode7BitWithTables(octets, tc.lang, tc.lang)
+		require.NoError(t, err)
+		assert.Equal(t, tc.str, str)
+	}
+
+	// the reserved septets aren't decoded as a character
+	str, err := Decode7BitWithTables(Pack7Bit([]byte{0x00, 0x3C, 0x68}, 0), Languages.Tamil, Languages.Default)
+	require.NoError(t, err)
+	assert.Equal(t, "\uFFFD\u0BA9h", str)
+	assert.False(t, Is7BitEncodableWithTables("\uFFFD", Languages.Tamil, Languages.Tamil))
+	assert.NotContains(t, ReverseTable(Languages.Tamil, Languages.Tamil), reserved)
+
+	// the reserved identifiers select the default tables
+	reservedLang := Language(0x0E)
+	assert.Equal(t, Languages.Default.LockingShiftTable(), reservedLang.LockingShiftTable())
+	str, err = Decode7BitWithTables(Encode7Bit("hi {}"), reservedLang, reservedLang)
+	require.NoError(t, err)
+	assert.Equal(t, "hi {}", str)
+}
+
+func TestExportedTables(t *testing.T) {
+	t.Parallel()
+
+	langs := []Language{Languages.Default, Languages.Turkish, Languages.Spanish, Languages.Portuguese,
+		Languages.Hindi, Languages.Tamil, Languages.Urdu}
+	for _, locking := range langs {
+		table := locking.LockingShiftTable()
+		for i, r := range table {
+			if byte(i) == Esc {
+				continue
+			}
+			str, err := Decode7BitWithTables(Pack7Bit([]byte{byte(i)}, 0), locking, Languages.Default)
+			require.NoError(t, err)
+			assert.Equal(t, string(r), str, "%d: 0x%02X", locking, i)
+		}
+		for _, single := range langs {
+			for b, r := range single.SingleShiftTable() {
+				str, err := Decode7BitWithTables(Pack7Bit([]byte{Esc, b}, 0), locking, single)
+				require.NoError(t, err)
+				assert.Equal(t, string(r), str, "%d/%d: 0x%02X", locking, single, b)
+			}
+			reverse := ReverseTable(locking, single)
+			for r, septets := range reverse {
+				octets := Encode7BitWithTables(string(r), locking, single)
+				assert.Equal(t, Pack7Bit(septets, 0), octets, "%d/%d: %q", locking, single, r)
+			}
+			assert.NotContains(t, reverse, '→')
+		}
+	}
+
+	// the returned tables are copies
+	table := Languages.Default.LockingShiftTable()
+	table[0] = '?'
+	Languages.Default.SingleShiftTable()[0x65] = '?'
+	ReverseTable(Languages.Default, Languages.Default)['@'][0] = 0x3F
+	str, err := Decode7Bit(Encode7Bit("@€"))
+	require.NoError(t, err)
+	assert.Equal(t, "@€", str)
+}
@@ -1,6 +1,20 @@
 package pdu
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors of the semi-octet encoding of the addresses.
+var (
+	ErrUnexpectedDigit  = errors.New("semi-octet encode: met a non-digit character")
+	ErrUnexpectedFiller = errors.New("semi-octet decode: met the filler before the last semi-octet")
+)
+
+// semiDigits are the characters of the semi-octets 0x0 to 0xE of the addresses,
+// see 3GPP TS 23.040, section 9.1.2.3, 0xF is the filler.
+const semiDigits = "0123456789*#abc"
 
 // Swap semi-octets in octet.
 func Swap(octet byte) byte {
@@ -24,30 +38,36 @@ func Decode(octet byte) int {
 // EncodeSemi packs the given numerical chunks in a semi-octet
 // representation as described in 3GPP TS 23.040.
 func EncodeSemi(chunks ...uint64) []byte {
-	digits := make([]uint8, 0, len(chunks))
+	return AppendSemi(make([]byte, 0, len(chunks)+1), chunks...)
+}
+
+// AppendSemi is like EncodeSemi, but appends the octets to dst and returns the extended buffer.
+func AppendSemi(dst []byte, chunks ...uint64) []byte {
+	var buf [20]byte // the decimal digits of uint64
+	var low byte
+	pending := false // the low nibble is set
 	for _, c := range chunks {
-		var bucket []uint8
-		if c < 10 {
-			digits = append(digits, 0)
-		}
+		i := len(buf)
 		for c > 0 {
-			d := c % 10
-			bucket = append(bucket, uint8(d))
-			c = (c - d) / 10
+			i--
+			buf[i] = byte(c % 10)
+			c /= 10
 		}
-		for i := range bucket {
-			digits = append(digits, bucket[len(bucket)-1-i])
+		if len(buf)-i < 2 {
+			i--
+			buf[i] = 0
 		}
-	}
-	octets := make([]byte, 0, len(digits)/2+1)
-	for i := 0; i < len(digits); i += 2 {
-		if len(digits)-i < 2 {
-			octets = append(octets, 0xF0|digits[i])
-			return octets
+		for _, d := range buf[i:] {
+			if pending {
+				dst = append(dst, d<<4|low)
+			}
+			low, pending = d, !pending
 		}
-		octets = append(octets, digits[i+1]<<4|digits[i])
 	}
-	return octets
+	if pending {
+		dst = append(dst, 0xF0|low)
+	}
+	return dst
 }
 
 // DecodeSemi unpacks numerical chunks from the given semi-octet encoded data.
@@ -78,3 +98,50 @@ func DecodeSemiAddress(octets []byte) (str string) {
 	}
 	return
 }
+
+// EncodeBCD packs the digits of the address into semi-octets, the first digit goes to the low nibble
+// and the odd one is padded with the 0xF filler. Unlike EncodeSemi, the leading zeros are kept and
+// the number of digits isn't limited. The digits are 0-9, *, #, a, b and c, ErrUnexpectedDigit
+// is returned on the other characters.
+func EncodeBCD(digits string) ([]byte, error) {
+	octets, err := AppendBCD(make([]byte, 0, len(digits)/2+1), digits)
+	if err != nil {
+		return nil, err
+	}
+	return octets, nil
+}
+
+// AppendBCD is like EncodeBCD, but appends the octets to dst and returns the extended buffer.
+// The dst is returned as is on error.
+func AppendBCD(dst []byte, digits string) ([]byte, error) {
+	octets := dst
+	for i := 0; i < len(digits); i++ {
+		n := strings.IndexByte(semiDigits, digits[i])
+		if n < 0 {
+			return dst, fmt.Errorf("%w: %q at offset %d", ErrUnexpectedDigit, digits[i], i)
+		}
+		if i%2 == 0 {
+			octets = append(octets, 0xF0|byte(n))
+		} else {
+			octets[len(octets)-1] = byte(n)<<4 | octets[len(octets)-1]&0x0F
+		}
+	}
+	return octets, nil
+}
+
+// DecodeBCD unpacks the digits of the address from semi-octets, see EncodeBCD.
+// ErrUnexpectedFiller is returned if the 0xF filler isn't the high nibble of the last octet.
+func DecodeBCD(octets []byte) (string, error) {
+	digits := make([]byte, 0, len(octets)*2)
+	for i, oct := range octets {
+		lo, hi := oct&0x0F, oct>>4
+		if lo == 0x0F || hi == 0x0F && i < len(octets)-1 {
+			return string(digits), fmt.Errorf("%w: octet %d is 0x%02X", ErrUnexpectedFiller, i, oct)
+		}
+		digits = append(digits, semiDigits[lo])
+		if hi != 0x0F {
+			digits = append(digits, semiDigits[hi])
+		}
+	}
+	return string(digits), nil
+}
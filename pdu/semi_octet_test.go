@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncodeSemi(t *testing.T) {
@@ -22,3 +23,33 @@ func TestDecodeSemi(t *testing.T) {
 	exp := []int{14, 6, 26, 21, 36, 30, 16}
 	assert.Equal(t, exp, out)
 }
+
+func TestBCD(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		digits string
+		octets []byte
+	}{
+		{"", []byte{}},
+		{"0012", []byte{0x00, 0x21}},
+		{"123", []byte{0x21, 0xF3}},
+		{"12345678901234567890", []byte{0x21, 0x43, 0x65, 0x87, 0x09, 0x21, 0x43, 0x65, 0x87, 0x09}},
+		{"*100#", []byte{0x1A, 0x00, 0xFB}},
+		{"abc", []byte{0xDC, 0xFE}},
+	} {
+		octets, err := EncodeBCD(tc.digits)
+		require.NoError(t, err, tc.digits)
+		assert.Equal(t, tc.octets, octets, tc.digits)
+		digits, err := DecodeBCD(octets)
+		require.NoError(t, err, tc.digits)
+		assert.Equal(t, tc.digits, digits)
+	}
+
+	_, err := EncodeBCD("+7999")
+	assert.ErrorIs(t, err, ErrUnexpectedDigit)
+	for _, octets := range [][]byte{{0xF1, 0x21}, {0x1F}, {0x21, 0x0F}} {
+		_, err = DecodeBCD(octets)
+		assert.ErrorIs(t, err, ErrUnexpectedFiller, "% X", octets)
+	}
+}
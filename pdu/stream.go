@@ -0,0 +1,301 @@
+package pdu
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// chunkSize is the number of octets the decoders read at once.
+const chunkSize = 512
+
+// New7BitEncoder returns the writer that encodes the UTF-8 text written to it into GSM 7-bit
+// encoding with packing and writes the octets to w, the result is the same as of Encode7Bit.
+// The text may be written in chunks that split the characters, Close flushes the last octet
+// along with the padding, it doesn't close w.
+func New7BitEncoder(w io.Writer) io.WriteCloser {
+	return &encoder7Bit{w: w}
+}
+
+type encoder7Bit struct {
+	w       io.Writer
+	partial []byte // the incomplete character of the last write
+	p       packer
+}
+
+func (e *encoder7Bit) Write(p []byte) (int, error) {
+	text := append(e.partial, p...)
+	end := fullRunes(text)
+	out, _ := e.p.encode(make([]byte, 0, end), string(text[:end]), &gsmTable, gsmEscapes,
+		[]byte{byte(unknown)})
+	e.partial = append([]byte(nil), text[end:]...)
+	if err := e.write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes the incomplete character as "?" and adds the padding as Encode7Bit does.
+func (e *encoder7Bit) Close() error {
+	var out []byte
+	if len(e.partial) > 0 {
+		e.partial = nil
+		out = e.p.add(out, byte(unknown))
+	}
+	return e.write(e.p.flush(out))
+}
+
+func (e *encoder7Bit) write(out []byte) error {
+	if len(out) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(out)
+	return err
+}
+
+// New7BitDecoder returns the reader that decodes the GSM 7-bit packed octets read from r
+// into UTF-8 text, the result is the same as of Decode7Bit.
+func New7BitDecoder(r io.Reader) io.Reader {
+	return &decoder7Bit{r: r}
+}
+
+type decoder7Bit struct {
+	r    io.Reader
+	acc  uint   // the bits that don't fill a septet yet
+	bits uint   // the number of bits in acc
+	raw7 []byte // the septets not decoded yet
+	n    int    // the number of septets
+	out  []byte // the text not read yet
+	err  error
+}
+
+func (d *decoder7Bit) Read(p []byte) (int, error) {
+	for len(d.out) == 0 && d.err == nil {
+		d.fill()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	if len(d.out) > 0 {
+		return n, nil
+	}
+	return n, d.err
+}
+
+func (d *decoder7Bit) fill() {
+	buf := make([]byte, chunkSize)
+	n, err := d.r.Read(buf)
+	for _, oct := range buf[:n] {
+		d.acc |= uint(oct) << d.bits
+		for d.bits += 8; d.bits >= 7; d.bits -= 7 {
+			d.raw7 = append(d.raw7, byte(d.acc&0x7F))
+			d.acc >>= 7
+			d.n++
+		}
+	}
+
+	// the last two septets might be the padding and the escape needs the next septet
+	end := 0
+	if err == io.EOF {
+		d.raw7 = trimPadding(d.raw7, d.n, 0)
+		end = len(d.raw7)
+	} else {
+		for end < len(d.raw7)-2 && !(d.raw7[end] == Esc && end+1 >= len(d.raw7)-2) {
+			if d.raw7[end] == Esc {
+				end++
+			}
+			end++
+		}
+	}
+	var str strings.Builder
+	if decodeErr := decodeSeptets(&str, d.raw7[:end], &gsmTable, gsmEscapes); decodeErr != nil {
+		err = decodeErr
+	}
+	d.out = append(d.out, str.String()...)
+	d.raw7 = append(d.raw7[:0], d.raw7[end:]...)
+	d.err = err
+}
+
+// NewUcs2Encoder returns the writer that encodes the UTF-8 text written to it into UCS2 (UTF-16)
+// encoding and writes the octets to w, the result is the same as of EncodeUcs2. The text may be
+// written in chunks that split the characters, Close encodes the incomplete character as U+FFFD,
+// it doesn't close w.
+func NewUcs2Encoder(w io.Writer) io.WriteCloser {
+	return &encoderUcs2{w: w}
+}
+
+type encoderUcs2 struct {
+	w       io.Writer
+	partial []byte // the incomplete character of the last write
+}
+
+func (e *encoderUcs2) Write(p []byte) (int, error) {
+	text := append(e.partial, p...)
+	end := fullRunes(text)
+	e.partial = append([]byte(nil), text[end:]...)
+	if end == 0 {
+		return len(p), nil
+	}
+	if _, err := e.w.Write(EncodeUcs2(string(text[:end]))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *encoderUcs2) Close() error {
+	if len(e.partial) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(EncodeUcs2(string(utf8.RuneError)))
+	e.partial = nil
+	return err
+}
+
+// NewUcs2Decoder returns the reader that decodes the UCS2 (UTF-16) octets read from r
+// into UTF-8 text, the result is the same as of DecodeUcs2 without the user data header.
+// ErrUnevenNumber is returned if the octets end with a half of the code unit.
+func NewUcs2Decoder(r io.Reader) io.Reader {
+	return &decoderUcs2{r: r}
+}
+
+type decoderUcs2 struct {
+	r       io.Reader
+	partial []byte // the octets of the incomplete code unit or surrogate pair
+	out     []byte // the text not read yet
+	err     error
+}
+
+func (d *decoderUcs2) Read(p []byte) (int, error) {
+	for len(d.out) == 0 && d.err == nil {
+		d.fill()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	if len(d.out) > 0 {
+		return n, nil
+	}
+	return n, d.err
+}
+
+func (d *decoderUcs2) fill() {
+	buf := make([]byte, chunkSize)
+	n, err := d.r.Read(buf)
+	octets := append(d.partial, buf[:n]...)
+	end := len(octets) - len(octets)%2
+	if err == io.EOF && end < len(octets) {
+		err = ErrUnevenNumber
+	}
+	// the high surrogate is kept until the low one is read
+	if err == nil && end > 0 && octets[end-2] >= 0xD8 && octets[end-2] < 0xDC {
+		end -= 2
+	}
+	units := make([]uint16, 0, end/2)
+	for i := 0; i < end; i += 2 {
+		units = append(units, uint16(octets[i])<<8|uint16(octets[i+1]))
+	}
+	d.out = append(d.out, string(utf16.Decode(units))...)
+	d.partial = append([]byte(nil), octets[end:]...)
+	d.err = err
+}
+
+// NewSemiEncoder returns the writer that encodes the digits of the address written to it into
+// semi-octets and writes the octets to w, the result is the same as of EncodeBCD. Close pads
+// the odd digit with the 0xF filler, it doesn't close w.
+func NewSemiEncoder(w io.Writer) io.WriteCloser {
+	return &encoderSemi{w: w}
+}
+
+type encoderSemi struct {
+	w       io.Writer
+	pending bool // the low nibble is set
+	low     byte
+}
+
+func (e *encoderSemi) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p)/2+1)
+	n := len(p)
+	var digitErr error
+	for i, c := range p {
+		digit := strings.IndexByte(semiDigits, c)
+		if digit < 0 {
+			n, digitErr = i, fmt.Errorf("%w: %q at offset %d", ErrUnexpectedDigit, c, i)
+			break
+		}
+		if e.pending {
+			out = append(out, byte(digit)<<4|e.low)
+		}
+		e.low, e.pending = byte(digit), !e.pending
+	}
+	if len(out) > 0 {
+		if _, err := e.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return n, digitErr
+}
+
+func (e *encoderSemi) Close() error {
+	if !e.pending {
+		return nil
+	}
+	e.pending = false
+	_, err := e.w.Write([]byte{0xF0 | e.low})
+	return err
+}
+
+// NewSemiDecoder returns the reader that decodes the semi-octets read from r into the digits
+// of the address, the result is the same as of DecodeBCD. The octets that follow the 0xF filler
+// are not read.
+func NewSemiDecoder(r io.Reader) io.Reader {
+	return &decoderSemi{r: r}
+}
+
+type decoderSemi struct {
+	r   io.Reader
+	out []byte // the digits not read yet
+	err error
+}
+
+func (d *decoderSemi) Read(p []byte) (int, error) {
+	for len(d.out) == 0 && d.err == nil {
+		d.fill()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	if len(d.out) > 0 {
+		return n, nil
+	}
+	return n, d.err
+}
+
+func (d *decoderSemi) fill() {
+	buf := make([]byte, chunkSize)
+	n, err := d.r.Read(buf)
+	for _, oct := range buf[:n] {
+		if oct&0x0F == 0x0F {
+			d.err = fmt.Errorf("%w: 0x%02X", ErrUnexpectedFiller, oct)
+			return
+		}
+		d.out = append(d.out, semiDigits[oct&0x0F])
+		if oct>>4 == 0x0F {
+			d.err = io.EOF
+			return
+		}
+		d.out = append(d.out, semiDigits[oct>>4])
+	}
+	d.err = err
+}
+
+// fullRunes returns the length of the text without the incomplete character at the end.
+func fullRunes(text []byte) int {
+	for i := len(text) - 1; i >= 0 && i >= len(text)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(text[i]) {
+			if !utf8.FullRune(text[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(text)
+}
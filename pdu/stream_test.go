@@ -0,0 +1,88 @@
+package pdu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBytes writes the text to the writer byte by byte and closes it.
+func writeBytes(t *testing.T, w io.WriteCloser, text string) {
+	for i := 0; i < len(text); i++ {
+		_, err := w.Write([]byte{text[i]})
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestStream7Bit(t *testing.T) {
+	t.Parallel()
+
+	for _, str := range []string{
+		"", "hi", "1234567", "1234567\r", "123456\r", "{hello} €",
+		"hi ü→", "Этот", strings.Repeat("cell broadcast page ", 50),
+	} {
+		var buf bytes.Buffer
+		writeBytes(t, New7BitEncoder(&buf), str)
+		assert.Equal(t, fmt.Sprintf("%X", Encode7Bit(str)), fmt.Sprintf("%X", buf.Bytes()), str)
+
+		exp, err := Decode7Bit(buf.Bytes())
+		require.NoError(t, err)
+		out, err := ioutil.ReadAll(New7BitDecoder(iotest.OneByteReader(&buf)))
+		require.NoError(t, err)
+		assert.Equal(t, exp, string(out), str)
+	}
+}
+
+func TestStreamUcs2(t *testing.T) {
+	t.Parallel()
+
+	for _, str := range []string{"hi", "hi 😀", testStringUcs2, strings.Repeat("😀ж", 300)} {
+		var buf bytes.Buffer
+		writeBytes(t, NewUcs2Encoder(&buf), str)
+		assert.Equal(t, EncodeUcs2(str), buf.Bytes(), str)
+
+		out, err := ioutil.ReadAll(NewUcs2Decoder(iotest.OneByteReader(&buf)))
+		require.NoError(t, err)
+		assert.Equal(t, str, string(out))
+	}
+
+	var buf bytes.Buffer
+	writeBytes(t, NewUcs2Encoder(&buf), "hi\xF0\x9F")
+	assert.Equal(t, EncodeUcs2("hi�"), buf.Bytes())
+
+	_, err := ioutil.ReadAll(NewUcs2Decoder(bytes.NewReader([]byte{0x00, 0x68, 0x00})))
+	assert.ErrorIs(t, err, ErrUnevenNumber)
+}
+
+func TestStreamSemi(t *testing.T) {
+	t.Parallel()
+
+	for _, str := range []string{"79997654321", "7999765432", "1", "*100#"} {
+		var buf bytes.Buffer
+		writeBytes(t, NewSemiEncoder(&buf), str)
+		exp, err := EncodeBCD(str)
+		require.NoError(t, err)
+		assert.Equal(t, exp, buf.Bytes())
+
+		if len(str)%2 != 0 {
+			// the octets after the padding are not read
+			buf.WriteByte(0x21)
+		}
+		out, err := ioutil.ReadAll(NewSemiDecoder(iotest.OneByteReader(&buf)))
+		require.NoError(t, err)
+		assert.Equal(t, str, string(out))
+	}
+
+	var buf bytes.Buffer
+	n, err := NewSemiEncoder(&buf).Write([]byte("+7999"))
+	assert.ErrorIs(t, err, ErrUnexpectedDigit)
+	assert.Zero(t, n)
+}
@@ -1,7 +1,9 @@
 package pdu
 
 import (
+	"bytes"
 	"errors"
+	"unicode"
 	"unicode/utf16"
 )
 
@@ -12,17 +14,52 @@ var ErrUnevenNumber = errors.New("decode ucs2: uneven number of octets")
 var ErrIncorrectDataLength = errors.New("decode ucs2: incorrect data length in first entry of octets")
 
 // EncodeUcs2 encodes the given UTF-8 text into UCS2 (UTF-16) encoding and returns the produced octets.
+// The characters outside of the Basic Multilingual Plane, e.g. emoji, are encoded as surrogate pairs,
+// so they take 4 octets.
 func EncodeUcs2(str string) []byte {
-	buf := utf16.Encode([]rune(str))
-	octets := make([]byte, 0, len(buf)*2)
-	for _, n := range buf {
-		octets = append(octets, byte(n&0xFF00>>8), byte(n&0x00FF))
+	return AppendUcs2(make([]byte, 0, len(str)*2), str)
+}
+
+// AppendUcs2 is like EncodeUcs2, but appends the octets to dst and returns the extended buffer.
+func AppendUcs2(dst []byte, str string) []byte {
+	for _, r := range str {
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			dst = append(dst, byte(r1>>8), byte(r1), byte(r2>>8), byte(r2))
+		} else {
+			dst = append(dst, byte(r>>8), byte(r))
+		}
 	}
-	return octets
+	return dst
+}
+
+// ByteOrder represents the order of the octets of the UCS2 code units, see DecodeUcs2WithOrder.
+type ByteOrder byte
+
+// ByteOrders represent the possible byte orders.
+var ByteOrders = struct {
+	// BigEndian is the order of 3GPP TS 23.038, it's the one of DecodeUcs2.
+	BigEndian ByteOrder
+	// LittleEndian is the order of the buggy SMSCs that don't swap the octets.
+	LittleEndian ByteOrder
+	// Auto removes the byte order mark and decodes in its order, the text without it is decoded
+	// in big-endian unless that's clearly wrong, i.e. there are unpaired surrogates, noncharacters,
+	// unassigned characters or planes, while the little-endian text has none of them.
+	Auto ByteOrder
+}{
+	0x00, 0x01, 0x02,
 }
 
 // DecodeUcs2 decodes the given UCS2 (UTF-16) octet data into a UTF-8 encoded string.
+// The surrogate pairs are decoded into the single characters, the unpaired surrogates
+// are decoded as U+FFFD.
 func DecodeUcs2(octets []byte, startsWithHeader bool) (str string, err error) {
+	return DecodeUcs2WithOrder(octets, startsWithHeader, ByteOrders.BigEndian)
+}
+
+// DecodeUcs2WithOrder is like DecodeUcs2, but decodes the code units in the given byte order,
+// e.g. the text of the SMSCs that send it in little-endian or with the byte order mark.
+func DecodeUcs2WithOrder(octets []byte, startsWithHeader bool, order ByteOrder) (str string, err error) {
 	octetsLng := len(octets)
 	headerLng := 0
 
@@ -46,10 +83,63 @@ func DecodeUcs2(octets []byte, startsWithHeader bool) (str string, err error) {
 		err = ErrUnevenNumber
 		return
 	}
-	buf := make([]uint16, 0, octetsLng/2)
-	for i := 0; i < octetsLng; i += 2 {
-		buf = append(buf, uint16(octets[i+headerLng])<<8|uint16(octets[i+1+headerLng]))
+	octets = octets[headerLng:]
+	if order == ByteOrders.Auto {
+		order, octets = detectOrder(octets)
+	}
+	hi, lo := 0, 1
+	if order == ByteOrders.LittleEndian {
+		hi, lo = 1, 0
+	}
+	buf := make([]uint16, 0, len(octets)/2)
+	for i := 0; i < len(octets); i += 2 {
+		buf = append(buf, uint16(octets[i+hi])<<8|uint16(octets[i+lo]))
 	}
 	runes := utf16.Decode(buf)
 	return string(runes), nil
 }
+
+// detectOrder returns the byte order of the even number of octets and the octets
+// without the byte order mark, see ByteOrders.Auto.
+func detectOrder(octets []byte) (ByteOrder, []byte) {
+	switch {
+	case bytes.HasPrefix(octets, []byte{0xFE, 0xFF}):
+		return ByteOrders.BigEndian, octets[2:]
+	case bytes.HasPrefix(octets, []byte{0xFF, 0xFE}):
+		return ByteOrders.LittleEndian, octets[2:]
+	}
+	if !plausibleUcs2(octets, 0, 1) && plausibleUcs2(octets, 1, 0) {
+		return ByteOrders.LittleEndian, octets
+	}
+	return ByteOrders.BigEndian, octets
+}
+
+// plausibleUcs2 checks whether the code units, which high and low octets are at the given
+// positions, are valid UTF-16 of the assigned characters, see ByteOrders.Auto.
+func plausibleUcs2(octets []byte, hi, lo int) bool {
+	unit := func(i int) rune {
+		return rune(octets[i+hi])<<8 | rune(octets[i+lo])
+	}
+	for i := 0; i < len(octets); i += 2 {
+		r := unit(i)
+		if utf16.IsSurrogate(r) {
+			if i+2 >= len(octets) {
+				return false
+			}
+			if r = utf16.DecodeRune(r, unit(i+2)); r == unicode.ReplacementChar {
+				return false
+			}
+			i += 2
+			// the planes 4 to 13 are unassigned
+			if plane := r >> 16; plane >= 4 && plane <= 13 {
+				return false
+			}
+			continue
+		}
+		if r&0xFFFE == 0xFFFE || r >= 0xFDD0 && r <= 0xFDEF ||
+			!unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.C) {
+			return false
+		}
+	}
+	return true
+}
@@ -37,3 +37,70 @@ func TestDecodeUcs2(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, exp, out)
 }
+
+func TestUcs2SurrogatePairs(t *testing.T) {
+	t.Parallel()
+
+	octets := EncodeUcs2("hi 😀")
+	assert.Equal(t, []byte{0x00, 0x68, 0x00, 0x69, 0x00, 0x20, 0xD8, 0x3D, 0xDE, 0x00}, octets)
+	str, err := DecodeUcs2(octets, false)
+	require.NoError(t, err)
+	assert.Equal(t, "hi 😀", str)
+
+	// the pair split across the concatenated parts
+	str, err = DecodeUcs2(octets[:8], false)
+	require.NoError(t, err)
+	assert.Equal(t, "hi �", str)
+}
+
+func TestUcs2ByteOrder(t *testing.T) {
+	t.Parallel()
+
+	swap := func(octets []byte) []byte {
+		swapped := make([]byte, len(octets))
+		for i := 0; i < len(octets); i += 2 {
+			swapped[i], swapped[i+1] = octets[i+1], octets[i]
+		}
+		return swapped
+	}
+	for _, text := range []string{testStringUcs2, "Hello, world", "h", "hi 😀", "你好，世界", "Ж"} {
+		octets := EncodeUcs2(text)
+		for _, tc := range []struct {
+			order  ByteOrder
+			octets []byte
+		}{
+			{ByteOrders.BigEndian, octets},
+			{ByteOrders.LittleEndian, swap(octets)},
+			{ByteOrders.Auto, append([]byte{0xFE, 0xFF}, octets...)},
+			{ByteOrders.Auto, append([]byte{0xFF, 0xFE}, swap(octets)...)},
+		} {
+			str, err := DecodeUcs2WithOrder(tc.octets, false, tc.order)
+			require.NoError(t, err)
+			assert.Equal(t, text, str, "% X", tc.octets)
+		}
+	}
+
+	// the valid big-endian text is never swapped
+	for _, text := range []string{testStringUcs2, "Hello, world", "h", "Привет", "😀", "😀😀", "hi 😀", "栀椀"} {
+		str, err := DecodeUcs2WithOrder(EncodeUcs2(text), false, ByteOrders.Auto)
+		require.NoError(t, err)
+		assert.Equal(t, text, str)
+	}
+	// the little-endian text is detected if it's invalid in big-endian,
+	// e.g. 'ß' is the unpaired surrogate 0xDF00
+	for _, text := range []string{"Grüße", "Ø"} {
+		str, err := DecodeUcs2WithOrder(swap(EncodeUcs2(text)), false, ByteOrders.Auto)
+		require.NoError(t, err)
+		assert.Equal(t, text, str)
+	}
+	// otherwise it's left as is
+	str, err := DecodeUcs2WithOrder(swap(EncodeUcs2("hi")), false, ByteOrders.Auto)
+	require.NoError(t, err)
+	assert.Equal(t, "栀椀", str)
+
+	// the header isn't swapped
+	octets := append([]byte{0x05, 0x00, 0x03, 0x2A, 0x02, 0x01}, swap(EncodeUcs2("Hello"))...)
+	str, err = DecodeUcs2WithOrder(octets, true, ByteOrders.LittleEndian)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", str)
+}
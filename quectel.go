@@ -0,0 +1,174 @@
+package at
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceQuectel returns an instance of DeviceProfile implementation for Quectel
+// modules such as EC2x, EG2x and BG9x.
+func DeviceQuectel() DeviceProfile {
+	return &QuectelProfile{}
+}
+
+// QuectelProfile is a DeviceProfile implementation for Quectel modules. It routes
+// the unsolicited reports to the notification port, enables the +QIND indications
+// and keeps the signal strength and the access technology up to date.
+type QuectelProfile struct {
+	GenericProfile
+
+	// URCPort is the name of the port that the reports are routed to, as it is
+	// known to the module: "usbat", "usbmodem" or "uart1". Defaults to "usbat".
+	URCPort string
+}
+
+// Init makes the initial setup of the Quectel module.
+func (p *QuectelProfile) Init(d *Device) (err error) {
+	p.dev = d
+	p.dev.Send(NoopCmd) // kinda flush
+	urcPort := p.URCPort
+	if urcPort == "" {
+		urcPort = "usbat"
+	}
+	if err = p.QURCCFG(urcPort); err != nil {
+		return fmt.Errorf("at init: unable to route reports to %s: %w", urcPort, err)
+	}
+	if err = p.init(d, p); err != nil {
+		return err
+	}
+	p.QINDCFG("csq", true) // optional, the signal strength won't be updated otherwise
+	p.QINDCFG("act", true) // optional, the system mode won't be updated otherwise
+	return nil
+}
+
+// QURCCFG sends AT+QURCCFG to the device in order to select the port
+// that the unsolicited reports are routed to.
+func (p *QuectelProfile) QURCCFG(port string) (err error) {
+	req := fmt.Sprintf(`AT+QURCCFG="urcport","%s"`, port)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// QINDCFG sends AT+QINDCFG to the device in order to toggle the +QIND indications
+// of the given type, e.g. "csq", "act", "smsfull" or "all".
+func (p *QuectelProfile) QINDCFG(typ string, enable bool) (err error) {
+	var flag int
+	if enable {
+		flag = 1
+	}
+	req := fmt.Sprintf(`AT+QINDCFG="%s",%d`, typ, flag)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// QuectelSignalReport represents the report from the AT+QCSQ command.
+type QuectelSignalReport struct {
+	// SystemMode is the access technology in use, see SystemModes.
+	SystemMode Opt
+	// Values are the technology-specific signal quality values,
+	// e.g. RSSI, RSRP, SINR and RSRQ for LTE.
+	Values []int
+}
+
+// Parse scans the AT+QCSQ report into a non-nil QuectelSignalReport struct.
+func (r *QuectelSignalReport) Parse(str string) error {
+	fields := splitFields(str)
+	r.SystemMode = quectelSystemMode(fields[0])
+	r.Values = r.Values[:0]
+	for _, f := range fields[1:] {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return ErrParseReport
+		}
+		r.Values = append(r.Values, v)
+	}
+	return nil
+}
+
+// QCSQ sends AT+QCSQ to the device and returns the extended signal quality report.
+func (p *QuectelProfile) QCSQ() (report *QuectelSignalReport, err error) {
+	reply, err := p.dev.Send(`AT+QCSQ`)
+	if err != nil {
+		return nil, err
+	}
+	report = new(QuectelSignalReport)
+	err = report.Parse(strings.TrimPrefix(reply, `+QCSQ:`))
+	return
+}
+
+// SYSINFO emulates the Huawei's AT^SYSINFO report using the standard commands,
+// the system mode is reported by AT+QCSQ.
+func (p *QuectelProfile) SYSINFO() (info *SystemInfoReport, err error) {
+	if info, err = p.GenericProfile.SYSINFO(); err != nil {
+		return
+	}
+	if report, err := p.QCSQ(); err == nil {
+		info.SystemMode = report.SystemMode
+	}
+	return info, nil
+}
+
+// HandleReport handles the +QIND indications and the boot reports of Quectel modules.
+func (p *QuectelProfile) HandleReport(report string) (handled bool, err error) {
+	switch {
+	case report == "RDY":
+		return true, nil
+	case strings.HasPrefix(report, "+QIND:"):
+		fields := splitFields(strings.TrimPrefix(report, "+QIND:"))
+		switch fields[0] {
+		case "csq":
+			if len(fields) < 2 {
+				return true, ErrParseReport
+			}
+			var rssi signalStrengthReport
+			if err = rssi.Parse(fields[1]); err != nil {
+				return true, err
+			}
+			p.dev.UpdateState(func(s *DeviceState) bool {
+				if s.SignalStrength == int(rssi) {
+					return false
+				}
+				s.SignalStrength = int(rssi)
+				return true
+			})
+		case "act":
+			if len(fields) < 2 {
+				return true, ErrParseReport
+			}
+			mode := quectelSystemMode(fields[1])
+			p.dev.UpdateState(func(s *DeviceState) bool {
+				if s.SystemMode == mode {
+					return false
+				}
+				s.SystemMode = mode
+				return true
+			})
+		}
+		// the rest, e.g. SMS DONE or PB DONE, are informational
+		return true, nil
+	}
+	return false, nil
+}
+
+// quectelSystemMode resolves the access technology name used by Quectel modules.
+func quectelSystemMode(str string) Opt {
+	switch {
+	case str == "NOSERVICE":
+		return SystemModes.NoService
+	case strings.HasPrefix(str, "GSM"), str == "GPRS", str == "EDGE":
+		return SystemModes.GsmGprs
+	case str == "WCDMA", strings.HasPrefix(str, "HSDPA"), strings.HasPrefix(str, "HSUPA"),
+		strings.HasPrefix(str, "HSPA"), str == "UMTS":
+		return SystemModes.WCDMA
+	case str == "TDSCDMA":
+		return SystemModes.SCDMA
+	case str == "CDMA":
+		return SystemModes.CDMA
+	case str == "HDR", str == "EVDO":
+		return SystemModes.HDR
+	case strings.HasPrefix(str, "LTE"), str == "CAT-M", str == "eMTC", str == "NBIoT", str == "NB-IoT":
+		return SystemModes.LTE
+	}
+	return UnknownOpt
+}
@@ -0,0 +1,33 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuectelProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply(`AT+QURCCFG="urcport","usbat"`, "OK")
+	modem.Reply(`AT+QCSQ`, `+QCSQ: "LTE",-52,-81,195,-10`, "OK")
+	modem.Reply(`AT+QINDCFG="csq",1`, "OK")
+	modem.Reply(`AT+QINDCFG="act",1`, "OK")
+	require.NoError(t, dev.Init(DeviceQuectel()))
+	assert.Equal(t, SystemModes.LTE, dev.State().SystemMode)
+	assert.Contains(t, modem.Sent(), `AT+QINDCFG="act",1`)
+
+	require.NoError(t, dev.handleReport(`+QIND: "csq",12,99`))
+	require.NoError(t, dev.handleReport(`+QIND: "act","WCDMA"`))
+	require.NoError(t, dev.handleReport(`+QIND: SMS DONE`))
+	require.NoError(t, dev.handleReport(`RDY`))
+	state := dev.State()
+	assert.Equal(t, 12, state.SignalStrength)
+	assert.Equal(t, SystemModes.WCDMA, state.SystemMode)
+}
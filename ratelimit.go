@@ -0,0 +1,164 @@
+package at
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xlab/at/sms"
+)
+
+// RateLimit limits the rate of the outgoing messages, since the carriers tend to drop
+// the bursts. Each part of a concatenated message counts as a separate message.
+// The zero values mean no limit.
+type RateLimit struct {
+	// PerSecond is the maximum number of messages sent within a second.
+	PerSecond int
+	// PerMinute is the maximum number of messages sent within a minute.
+	PerMinute int
+	// PerDestination is the minimum interval between the messages sent to the same address.
+	PerDestination time.Duration
+}
+
+// DefaultSendRetryPolicy is used to retry the message submission if the device
+// has no SendRetry policy set.
+var DefaultSendRetryPolicy = &RetryPolicy{
+	Attempts:   3,
+	Backoff:    2 * time.Second,
+	MaxBackoff: 30 * time.Second,
+	Transient:  IsSendTransient,
+}
+
+// IsSendTransient reports whether the message submission failed for sure and may succeed
+// if retried later, e.g. the SIM was busy or there was no network service.
+// The errors after which the service centre might have accepted the message
+// aren't considered transient, see IsSendUnconfirmed.
+func IsSendTransient(err error) bool {
+	var cme *CmeError
+	if errors.As(err, &cme) {
+		return transientCme[cme.Code]
+	}
+	var cms *CmsError
+	if errors.As(err, &cms) {
+		return unsentCms[cms.Code]
+	}
+	return false
+}
+
+// IsSendUnconfirmed reports whether the outcome of the message submission is unknown,
+// i.e. the command timed out or failed with the network timeout or an unknown error,
+// so the service centre might have accepted the message anyway. Retrying such errors
+// may deliver the message twice, so it's opt-in:
+//
+//	dev.SendRetry = &at.RetryPolicy{
+//		Attempts: 3,
+//		Backoff:  2 * time.Second,
+//		Transient: func(err error) bool {
+//			return at.IsSendTransient(err) || at.IsSendUnconfirmed(err)
+//		},
+//	}
+func IsSendUnconfirmed(err error) bool {
+	var cms *CmsError
+	if errors.As(err, &cms) {
+		return unconfirmedCms[cms.Code]
+	}
+	return errors.Is(err, ErrTimeout)
+}
+
+// isSendCommand checks whether the command submits a message to the network.
+func isSendCommand(req string) bool {
+	return commandName(req) == "+CMGS" || commandName(req) == "+CMSS"
+}
+
+// rateLimiter keeps the times of the sent and scheduled messages.
+type rateLimiter struct {
+	mux  sync.Mutex
+	sent []time.Time // sorted
+	last map[sms.PhoneNumber]time.Time
+}
+
+// reserve returns the time when a message to the given address could be sent
+// according to the limit and records it as sent at that time.
+func (l *rateLimiter) reserve(limit *RateLimit, address sms.PhoneNumber, now time.Time) time.Time {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.last == nil {
+		l.last = make(map[sms.PhoneNumber]time.Time)
+	}
+	l.prune(limit, now)
+
+	t := now
+	for {
+		next := t
+		if last, ok := l.last[address]; ok && limit.PerDestination > 0 {
+			if last.Add(limit.PerDestination).After(next) {
+				next = last.Add(limit.PerDestination)
+			}
+		}
+		next = l.fit(next, time.Second, limit.PerSecond)
+		next = l.fit(next, time.Minute, limit.PerMinute)
+		if next.Equal(t) {
+			break
+		}
+		t = next
+	}
+
+	i := sort.Search(len(l.sent), func(i int) bool { return l.sent[i].After(t) })
+	l.sent = append(l.sent, time.Time{})
+	copy(l.sent[i+1:], l.sent[i:])
+	l.sent[i] = t
+	if last, ok := l.last[address]; !ok || t.After(last) {
+		l.last[address] = t
+	}
+	return t
+}
+
+// fit returns the earliest time not before t when there are less than n messages
+// within the window around it.
+func (l *rateLimiter) fit(t time.Time, window time.Duration, n int) time.Time {
+	if n <= 0 {
+		return t
+	}
+	for {
+		var within []time.Time
+		for _, s := range l.sent {
+			if s.After(t.Add(-window)) && s.Before(t.Add(window)) {
+				within = append(within, s)
+			}
+		}
+		if len(within) < n {
+			return t
+		}
+		t = within[len(within)-n].Add(window)
+	}
+}
+
+// prune forgets the messages that no longer affect the limits.
+func (l *rateLimiter) prune(limit *RateLimit, now time.Time) {
+	i := sort.Search(len(l.sent), func(i int) bool { return l.sent[i].After(now.Add(-time.Minute)) })
+	l.sent = l.sent[i:]
+	for address, last := range l.last {
+		if !last.Add(limit.PerDestination).After(now) {
+			delete(l.last, address)
+		}
+	}
+}
+
+// waitSendSlot blocks until a message to the given address could be sent
+// according to the device's rate limit.
+func (d *Device) waitSendSlot(address sms.PhoneNumber) error {
+	if d.SendLimit == nil {
+		return nil
+	}
+	delay := time.Until(d.limiter.reserve(d.SendLimit, address, time.Now()))
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-d.closed:
+		return ErrClosed
+	}
+}
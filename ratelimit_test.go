@@ -0,0 +1,54 @@
+package at
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	var l rateLimiter
+	limit := &RateLimit{PerSecond: 2, PerMinute: 5, PerDestination: 10 * time.Second}
+	now := time.Date(2020, 5, 18, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, now, l.reserve(limit, "1", now))
+	assert.Equal(t, now, l.reserve(limit, "2", now))
+	// two messages were sent within the second
+	assert.Equal(t, now.Add(time.Second), l.reserve(limit, "3", now))
+	// the same destination
+	assert.Equal(t, now.Add(10*time.Second), l.reserve(limit, "1", now))
+	assert.Equal(t, now.Add(time.Second), l.reserve(limit, "4", now))
+	// five messages were sent within the minute
+	assert.Equal(t, now.Add(time.Minute), l.reserve(limit, "5", now))
+
+	l = rateLimiter{}
+	assert.Equal(t, now, l.reserve(&RateLimit{}, "1", now))
+	assert.Equal(t, now, l.reserve(&RateLimit{}, "1", now))
+}
+
+func TestSendRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	dev := &Device{}
+	assert.Equal(t, DefaultSendRetryPolicy, dev.retryPolicy("AT+CMGS=24"))
+	assert.Equal(t, DefaultRetryPolicy, dev.retryPolicy("AT+CMGL=4"))
+	dev.SendRetry = NoRetry
+	assert.Equal(t, NoRetry, dev.retryPolicy("AT+CMSS=3"))
+	dev.CommandRetry = map[string]*RetryPolicy{"AT+CMGS": DefaultRetryPolicy}
+	assert.Equal(t, DefaultRetryPolicy, dev.retryPolicy("AT+CMGS=24"))
+
+	assert.True(t, IsSendTransient(newResultError("+CMS ERROR: 331", FinalResults.CmsError)))
+	assert.False(t, IsSendTransient(newResultError("+CMS ERROR: 304", FinalResults.CmsError)))
+	// the message might have been accepted by the service centre
+	for _, err := range []error{
+		ErrTimeout,
+		newResultError("+CMS ERROR: 332", FinalResults.CmsError),
+		newResultError("+CMS ERROR: 500", FinalResults.CmsError),
+	} {
+		assert.False(t, IsSendTransient(err))
+		assert.True(t, IsSendUnconfirmed(err))
+	}
+	assert.False(t, IsSendUnconfirmed(newResultError("+CMS ERROR: 331", FinalResults.CmsError)))
+}
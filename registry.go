@@ -0,0 +1,153 @@
+package at
+
+import (
+	"strings"
+	"sync"
+)
+
+// ProfileMatcher reports whether a profile is suitable for the device, the manufacturer
+// and the model are the ones reported by AT+CGMI and AT+CGMM.
+type ProfileMatcher func(manufacturer, model string) bool
+
+// ProfileFactory returns a new instance of a DeviceProfile implementation.
+type ProfileFactory func() DeviceProfile
+
+type registeredProfile struct {
+	name    string
+	match   ProfileMatcher
+	factory ProfileFactory
+}
+
+var (
+	profilesMux sync.RWMutex
+	profiles    []registeredProfile
+)
+
+func init() {
+	RegisterProfile("generic", nil, DeviceGeneric)
+	RegisterProfile("huawei", MatchManufacturer("huawei"), DeviceE173)
+	RegisterProfile("huawei-lte", matchHuaweiLTE, DeviceE3372)
+	RegisterProfile("quectel", MatchManufacturer("quectel"), DeviceQuectel)
+	RegisterProfile("simcom", MatchManufacturer("simcom"), DeviceSIMCom)
+	RegisterProfile("u-blox", MatchManufacturer("u-blox"), DeviceUblox)
+	RegisterProfile("telit", MatchManufacturer("telit"), DeviceTelit)
+	RegisterProfile("sierra", MatchManufacturer("sierra"), DeviceSierra)
+	RegisterProfile("zte", MatchManufacturer("zte"), DeviceZTE)
+	RegisterProfile("fibocom", MatchManufacturer("fibocom"), DeviceFibocom)
+}
+
+// RegisterProfile makes a DeviceProfile implementation available by the name and
+// lets it participate in the auto-detection, see Device.DetectProfile. The profiles
+// registered later take precedence, so a third-party module may supersede the built-in
+// profiles. Registering a profile with the same name replaces the previous one.
+// The profile with a nil matcher is available by the name only.
+func RegisterProfile(name string, match ProfileMatcher, factory ProfileFactory) {
+	if factory == nil {
+		panic("at: RegisterProfile factory is nil")
+	}
+	profilesMux.Lock()
+	defer profilesMux.Unlock()
+	for i := range profiles {
+		if profiles[i].name == name {
+			profiles = append(profiles[:i], profiles[i+1:]...)
+			break
+		}
+	}
+	profiles = append(profiles, registeredProfile{name, match, factory})
+}
+
+// LookupProfile returns a new instance of the profile registered with the given name.
+func LookupProfile(name string) (profile DeviceProfile, ok bool) {
+	profilesMux.RLock()
+	defer profilesMux.RUnlock()
+	for _, p := range profiles {
+		if p.name == name {
+			return p.factory(), true
+		}
+	}
+	return nil, false
+}
+
+// Profiles returns the names of the registered profiles.
+func Profiles() []string {
+	profilesMux.RLock()
+	defer profilesMux.RUnlock()
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+// MatchManufacturer returns a ProfileMatcher that matches the devices which manufacturer
+// contains one of the given names, the case is ignored.
+func MatchManufacturer(names ...string) ProfileMatcher {
+	return func(manufacturer, model string) bool {
+		manufacturer = strings.ToLower(manufacturer)
+		for _, name := range names {
+			if strings.Contains(manufacturer, strings.ToLower(name)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchHuaweiLTE matches the Huawei LTE sticks, e.g. E3372 and E8372.
+func matchHuaweiLTE(manufacturer, model string) bool {
+	model = strings.ToUpper(model)
+	return MatchManufacturer("huawei")(manufacturer, model) &&
+		(strings.HasPrefix(model, "E3372") || strings.HasPrefix(model, "E8372") ||
+			strings.HasPrefix(model, "E3276") || strings.HasPrefix(model, "MS2372"))
+}
+
+// matchProfile returns the latest registered profile that matches the device,
+// the generic profile is returned if none of them does.
+func matchProfile(manufacturer, model string) (name string, profile DeviceProfile) {
+	profilesMux.RLock()
+	defer profilesMux.RUnlock()
+	for i := len(profiles) - 1; i >= 0; i-- {
+		p := profiles[i]
+		if p.match != nil && p.match(manufacturer, model) {
+			return p.name, p.factory()
+		}
+	}
+	return "generic", DeviceGeneric()
+}
+
+// DetectProfile identifies the device using AT+CGMI and AT+CGMM and returns the name
+// and a new instance of the matching registered profile, see RegisterProfile.
+// The device must be opened, but it's not required to be initialized.
+func (d *Device) DetectProfile() (name string, profile DeviceProfile, err error) {
+	if err = d.sanityCheck(false); err != nil {
+		return
+	}
+	d.send(NoopCmd) // kinda flush
+	manufacturer, err := d.identify(`AT+CGMI`, `AT+GMI`)
+	if err != nil {
+		return
+	}
+	model, _ := d.identify(`AT+CGMM`, `AT+GMM`)
+	name, profile = matchProfile(manufacturer, model)
+	return
+}
+
+// identify sends the identification command, the alternative one is used if it fails.
+func (d *Device) identify(req, alt string) (str string, err error) {
+	if str, err = d.send(req); err != nil {
+		if str, err = d.send(alt); err != nil {
+			return
+		}
+	}
+	str = strings.TrimSpace(strings.TrimPrefix(str, strings.TrimPrefix(req, "AT")+":"))
+	return
+}
+
+// InitAuto detects the device profile using DetectProfile and initializes the device with it.
+func (d *Device) InitAuto() error {
+	_, profile, err := d.DetectProfile()
+	if err != nil {
+		return err
+	}
+	return d.Init(profile)
+}
@@ -0,0 +1,50 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type acmeProfile struct {
+	GenericProfile
+}
+
+func TestDetectProfile(t *testing.T) {
+	t.Parallel()
+
+	RegisterProfile("acme", MatchManufacturer("ACME"), func() DeviceProfile {
+		return &acmeProfile{}
+	})
+	assert.Contains(t, Profiles(), "acme")
+	profile, ok := LookupProfile("acme")
+	require.True(t, ok)
+	assert.IsType(t, &acmeProfile{}, profile)
+
+	dev, modem := openTestDevice(t)
+	modem.Reply("AT", "OK")
+	modem.ReplySequence("AT+CGMI",
+		[]string{"Quectel", "OK"},
+		[]string{"+CGMI: ACME Wireless", "OK"},
+		[]string{"Nobody", "OK"})
+	modem.Reply("AT+CGMM", "X1", "OK")
+
+	name, profile, err := dev.DetectProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "quectel", name)
+	assert.IsType(t, &QuectelProfile{}, profile)
+
+	name, profile, err = dev.DetectProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "acme", name)
+	assert.IsType(t, &acmeProfile{}, profile)
+
+	name, profile, err = dev.DetectProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "generic", name)
+	assert.IsType(t, &GenericProfile{}, profile)
+}
@@ -0,0 +1,182 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+const testDeliverPDU = "07919762020033F1040B919762995696F0000041606291401561066379180E8200"
+
+func TestRetentionDeleteAfterAck(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGL=4", "+CMGL: 3,1,,24", testDeliverPDU, "OK")
+	modem.Reply("AT+CMGD=3,0", "OK")
+	dev.Retention = RetentionPolicies.DeleteAfterAck
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.Len(t, dev.IncomingSms(), 1)
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=3,0")
+
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "crap Δ", msg.Text)
+	require.NoError(t, dev.Ack(msg))
+	assert.Contains(t, modem.Sent(), "AT+CMGD=3,0")
+	require.NoError(t, dev.Ack(msg))
+}
+
+func TestRetentionKeep(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=5", "+CMGR: 0,,24", testDeliverPDU, "OK")
+	dev.Retention = RetentionPolicies.Keep
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",5`))
+	require.Len(t, dev.IncomingSms(), 1)
+	require.NoError(t, dev.Ack(<-dev.IncomingSms()))
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=5,0")
+}
+
+func TestRetentionDeleteImmediately(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=5", "+CMGR: 0,,24", testDeliverPDU, "OK")
+	modem.Reply("AT+CMGD=5,0", "OK")
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",5`))
+	require.Len(t, dev.IncomingSms(), 1)
+	assert.Contains(t, modem.Sent(), "AT+CMGD=5,0")
+}
+
+func TestStoredStatusReport(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=2", "+CMGR: 0,,27", "079194710600400706360d91947106000000f122206151457440222061514584400000", "OK")
+	modem.Reply("AT+CMGD=2,0", "OK")
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CDSI: "SM",2`))
+	require.Len(t, dev.StatusReports(), 1)
+	assert.Empty(t, dev.IncomingSms())
+	assert.Contains(t, modem.Sent(), "AT+CMGD=2,0")
+}
+
+func TestRetentionDroppedMessage(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=5", "+CMGR: 0,,24", testDeliverPDU, "OK")
+	modem.Reply("AT+CMGD=5,0", "OK")
+	dev.Retention = RetentionPolicies.DeleteAfterAck
+	dev.AddFilter(func(*sms.Message) error { return ErrDropMessage })
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",5`))
+	assert.Empty(t, dev.IncomingSms())
+	// nobody is going to acknowledge the dropped message
+	assert.Contains(t, modem.Sent(), "AT+CMGD=5,0")
+}
+
+func TestStoredSIMMessage(t *testing.T) {
+	t.Parallel()
+
+	// the message of class 2
+	const pdu = "07919762020033F1040B919762995696F0001241606291401561066379180E8200"
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=4", "+CMGR: 0,,24", pdu, "OK")
+	modem.Reply("AT+CMGD=4,0", "OK")
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",4`))
+	assert.Empty(t, dev.IncomingSms())
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=4,0")
+	require.Len(t, dev.SIMMessages(), 1)
+	raw := <-dev.SIMMessages()
+	assert.Equal(t, uint16(4), raw.Index)
+	assert.True(t, raw.Stored)
+	assert.Equal(t, "crap Δ", raw.Message.Text)
+
+	dev.DeliverSIMMessages = true
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",4`))
+	require.Len(t, dev.IncomingSms(), 1)
+	assert.Empty(t, dev.SIMMessages())
+	assert.Contains(t, modem.Sent(), "AT+CMGD=4,0")
+}
+
+func TestReplaceMessages(t *testing.T) {
+	t.Parallel()
+
+	// the messages of replace type 1 from the same sender
+	const pdu = "07919762020033F1040B919762995696F0410041606291401561066379180E8200"
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGR=1", "+CMGR: 0,,24", pdu, "OK")
+	modem.Reply("AT+CMGR=2", "+CMGR: 0,,24", pdu, "OK")
+	modem.Reply("AT+CMGR=3", "+CMGR: 0,,24", testDeliverPDU, "OK")
+	modem.Reply("AT+CMGD=1,0", "OK")
+	dev.Retention = RetentionPolicies.Keep
+	dev.ReplaceMessages = true
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",1`))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",3`))
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=1,0")
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",2`))
+	assert.Contains(t, modem.Sent(), "AT+CMGD=1,0")
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=3,0")
+	assert.Len(t, dev.IncomingSms(), 3)
+}
+
+func TestReplaceConcatenatedMessages(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	// the two-part messages of replace type 1 from the same sender
+	for i, udh := range []sms.UserDataHeader{
+		{Tag: 7, TotalNumber: 2, Sequence: 1},
+		{Tag: 7, TotalNumber: 2, Sequence: 2},
+		{Tag: 8, TotalNumber: 2, Sequence: 1},
+		{Tag: 8, TotalNumber: 2, Sequence: 2},
+	} {
+		msg := sms.Message{
+			Text:                     "part",
+			Type:                     sms.MessageTypes.Deliver,
+			Encoding:                 sms.Encodings.Gsm7Bit,
+			Address:                  "+79269965690",
+			ServiceCenterAddress:     "+79262000331",
+			ProtocolIdentifier:       sms.ProtocolIdentifiers.ReplaceType1,
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           udh,
+		}
+		n, octets, err := msg.PDU()
+		require.NoError(t, err)
+		modem.Reply(fmt.Sprintf("AT+CMGR=%d", i+1), fmt.Sprintf("+CMGR: 0,,%d", n), fmt.Sprintf("%02X", octets), "OK")
+		modem.Reply(fmt.Sprintf("AT+CMGD=%d,0", i+1), "OK")
+	}
+	dev.Retention = RetentionPolicies.Keep
+	dev.ReplaceMessages = true
+	require.NoError(t, dev.Init(DeviceGeneric()))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",1`))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",2`))
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=1,0")
+	// the new message supersedes both parts of the previous one
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",3`))
+	require.NoError(t, dev.handleReport(`+CMTI: "SM",4`))
+	assert.Contains(t, modem.Sent(), "AT+CMGD=1,0")
+	assert.Contains(t, modem.Sent(), "AT+CMGD=2,0")
+	assert.NotContains(t, modem.Sent(), "AT+CMGD=3,0")
+	assert.Len(t, dev.IncomingSms(), 4)
+}
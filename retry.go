@@ -0,0 +1,121 @@
+package at
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RetryPolicy defines how commands are retried when the device replies
+// with a transient error, e.g. when the SIM is busy during the early boot.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts including the first one.
+	Attempts int
+	// Backoff is the delay before the first retry, it is doubled after each attempt.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between attempts, zero means no limit.
+	MaxBackoff time.Duration
+	// Transient reports whether a command failed with the given error should be retried.
+	// IsTransient is used if it's nil, IsSendTransient for the message submission.
+	Transient func(err error) bool
+}
+
+// DefaultRetryPolicy is used if the device has no retry policy set.
+var DefaultRetryPolicy = &RetryPolicy{
+	Attempts:   5,
+	Backoff:    500 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// NoRetry is a policy that disables retries.
+var NoRetry = &RetryPolicy{Attempts: 1}
+
+// Transient error codes, see 3GPP TS 27.007 and 27.005.
+var (
+	transientCme = map[int]bool{
+		14:  true, // SIM busy
+		515: true, // Please wait, init or command processing in progress
+	}
+	transientCms = map[int]bool{
+		314: true, // SIM busy
+		331: true, // No network service
+		332: true, // Network timeout
+		500: true, // Unknown error
+	}
+	// unsentCms are the transient errors that mean the message wasn't submitted.
+	unsentCms = map[int]bool{
+		314: true, // SIM busy
+		331: true, // No network service
+	}
+	// unconfirmedCms are the errors after which the message might have been submitted.
+	unconfirmedCms = map[int]bool{
+		332: true, // Network timeout
+		500: true, // Unknown error
+	}
+)
+
+// IsTransient reports whether the error is a temporary failure of the device
+// so the command may succeed if retried later.
+func IsTransient(err error) bool {
+	var cme *CmeError
+	if errors.As(err, &cme) {
+		return transientCme[cme.Code]
+	}
+	var cms *CmsError
+	if errors.As(err, &cms) {
+		return transientCms[cms.Code]
+	}
+	return false
+}
+
+// retryPolicy returns the policy that should be applied to the given command.
+// The policy for the longest matching prefix from CommandRetry takes precedence,
+// then the SendRetry policy is applied to the message submission.
+func (d *Device) retryPolicy(req string) *RetryPolicy {
+	policy := d.Retry
+	var matched int
+	for prefix, p := range d.CommandRetry {
+		if len(prefix) > matched && strings.HasPrefix(req, prefix) {
+			policy = p
+			matched = len(prefix)
+		}
+	}
+	if matched == 0 && isSendCommand(req) {
+		policy = d.SendRetry
+		if policy == nil {
+			return DefaultSendRetryPolicy
+		}
+	}
+	if policy == nil {
+		return DefaultRetryPolicy
+	}
+	return policy
+}
+
+// retry runs the given command function according to the retry policy of req.
+func (d *Device) retry(req string, f func() (string, error)) (reply string, err error) {
+	policy := d.retryPolicy(req)
+	transient := policy.Transient
+	if transient == nil {
+		transient = IsTransient
+		if isSendCommand(req) {
+			transient = IsSendTransient
+		}
+	}
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		reply, err = f()
+		if err == nil || attempt >= policy.Attempts || !transient(err) {
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-d.closed:
+			return
+		}
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
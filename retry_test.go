@@ -0,0 +1,55 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsTransient(newResultError("+CME ERROR: 14", FinalResults.CmeError)))
+	assert.True(t, IsTransient(newResultError("+CMS ERROR: 500", FinalResults.CmsError)))
+	assert.False(t, IsTransient(newResultError("+CME ERROR: 10", FinalResults.CmeError)))
+	// the verbose causes are mapped onto the codes
+	assert.True(t, IsTransient(newResultError("+CME ERROR: SIM busy", FinalResults.CmeError)))
+	assert.False(t, IsTransient(newResultError("+CME ERROR: SIM busy somehow", FinalResults.CmeError)))
+	assert.False(t, IsTransient(errors.New("Error")))
+}
+
+func TestSendRetry(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	dev.Retry = &RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+	modem.ReplySequence("AT+CPMS?",
+		[]string{"+CME ERROR: 14"},
+		[]string{"+CME ERROR: 14"},
+		[]string{`+CPMS: "ME",0,50`, "OK"},
+	)
+	reply, err := dev.Send("AT+CPMS?")
+	require.NoError(t, err)
+	assert.Equal(t, `+CPMS: "ME",0,50`, reply)
+	assert.Len(t, modem.Sent(), 3)
+}
+
+func TestSendRetryOverride(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := newTestDevice(t)
+	dev.Retry = &RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+	dev.CommandRetry = map[string]*RetryPolicy{"AT+CPMS": NoRetry}
+	modem.Reply("AT+CPMS?", "+CME ERROR: 14")
+	_, err := dev.Send("AT+CPMS?")
+	var cme *CmeError
+	require.ErrorAs(t, err, &cme)
+	assert.Equal(t, 14, cme.Code)
+	assert.Len(t, modem.Sent(), 1)
+}
@@ -0,0 +1,119 @@
+package at
+
+import (
+	"strings"
+)
+
+// DeviceSierra returns an instance of DeviceProfile implementation for Sierra Wireless
+// modules such as AirPrime, EM and MC series.
+func DeviceSierra() DeviceProfile {
+	return &SierraProfile{}
+}
+
+// SierraProfile is a DeviceProfile implementation for Sierra Wireless modules.
+// It reads the system info using the AT!GSTATUS command.
+type SierraProfile struct {
+	GenericProfile
+}
+
+// SierraStatusReport represents the report from the AT!GSTATUS? command.
+type SierraStatusReport struct {
+	// Fields contain the reported values by their names, e.g. "System mode" or "PS state".
+	Fields map[string]string
+}
+
+// Parse scans the multi-line AT!GSTATUS? report into a non-nil SierraStatusReport struct.
+// The report consists of tab-separated "name: value" pairs.
+func (r *SierraStatusReport) Parse(str string) error {
+	r.Fields = make(map[string]string)
+	str = strings.TrimPrefix(strings.TrimSpace(str), "!GSTATUS:")
+	for _, line := range strings.Split(str, "\n") {
+		var last string
+		for _, segment := range strings.Split(line, "\t") {
+			segment = strings.TrimSpace(segment)
+			if len(segment) == 0 {
+				continue
+			}
+			kv := strings.SplitN(segment, ":", 2)
+			if len(kv) < 2 {
+				// continuation of the previous value, e.g. "Registered   Normal Service"
+				if last != "" {
+					r.Fields[last] += " " + segment
+				}
+				continue
+			}
+			last = strings.TrimSpace(kv[0])
+			r.Fields[last] = strings.TrimSpace(kv[1])
+		}
+	}
+	if _, ok := r.Fields["System mode"]; !ok {
+		return ErrParseReport
+	}
+	return nil
+}
+
+// SystemMode returns the reported system mode, see SystemModes.
+func (r *SierraStatusReport) SystemMode() Opt {
+	mode := strings.ToUpper(r.Fields["System mode"])
+	switch {
+	case strings.HasPrefix(mode, "LTE"):
+		return SystemModes.LTE
+	case strings.HasPrefix(mode, "WCDMA"), strings.HasPrefix(mode, "HSPA"), strings.HasPrefix(mode, "UMTS"):
+		return SystemModes.WCDMA
+	case strings.HasPrefix(mode, "GSM"), strings.HasPrefix(mode, "EDGE"), strings.HasPrefix(mode, "GPRS"):
+		return SystemModes.GsmGprs
+	case strings.HasPrefix(mode, "CDMA"), strings.HasPrefix(mode, "1X"):
+		return SystemModes.CDMA
+	case strings.HasPrefix(mode, "HDR"), strings.HasPrefix(mode, "EVDO"):
+		return SystemModes.HDR
+	case mode == "NONE", strings.HasPrefix(mode, "NO SERVICE"):
+		return SystemModes.NoService
+	}
+	return UnknownOpt
+}
+
+// ServiceDomain returns the domain of the service, see ServiceDomains.
+func (r *SierraStatusReport) ServiceDomain() Opt {
+	ps := strings.EqualFold(r.Fields["PS state"], "Attached") ||
+		strings.Contains(strings.ToUpper(r.Fields["GMM (PS) state"]), "NORMAL SERVICE") ||
+		strings.Contains(strings.ToUpper(r.Fields["EMM state"]), "NORMAL SERVICE")
+	cs := strings.Contains(strings.ToUpper(r.Fields["MM (CS) state"]), "NORMAL SERVICE")
+	switch {
+	case ps && cs:
+		return ServiceDomains.Resolve(3)
+	case ps:
+		return ServiceDomains.Resolve(2)
+	case cs:
+		return ServiceDomains.Resolve(1)
+	}
+	return ServiceDomains.Resolve(0)
+}
+
+// GSTATUS sends AT!GSTATUS? to the device and parses the output.
+func (p *SierraProfile) GSTATUS() (report *SierraStatusReport, err error) {
+	reply, err := p.dev.Send(`AT!GSTATUS?`)
+	if err != nil {
+		return nil, err
+	}
+	report = new(SierraStatusReport)
+	err = report.Parse(reply)
+	return
+}
+
+// SYSINFO emulates the Huawei's AT^SYSINFO report using the standard commands,
+// the system mode and the service domain are reported by AT!GSTATUS?.
+func (p *SierraProfile) SYSINFO() (info *SystemInfoReport, err error) {
+	if info, err = p.GenericProfile.SYSINFO(); err != nil {
+		return
+	}
+	report, err := p.GSTATUS()
+	if err != nil {
+		return info, nil
+	}
+	info.SystemMode = report.SystemMode()
+	info.ServiceDomain = report.ServiceDomain()
+	if info.ServiceDomain.ID > 0 {
+		info.ServiceState = ServiceStates.Valid
+	}
+	return info, nil
+}
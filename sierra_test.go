@@ -0,0 +1,41 @@
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sierraStatusLTE = "!GSTATUS: \n" +
+	"Current Time:  1234\t\tTemperature: 38\n" +
+	"Reset Counter: 1\t\tMode:        ONLINE         \n" +
+	"System mode:   LTE        \tPS state:    Attached     \n" +
+	"LTE band:      B3     \t\tLTE bw:      20 MHz  \n" +
+	"EMM state:     Registered     \tNormal Service \n" +
+	"RRC state:     RRC Idle       \n" +
+	"PCC RxM RSSI:  -64\t\tRSRP (dBm):  -93\n"
+
+const sierraStatusWCDMA = "!GSTATUS: \n" +
+	"System mode:   WCDMA      \tPS state:    Not attached\n" +
+	"WCDMA band:    WCDMA 2100\n" +
+	"GMM (PS) state:DEREGISTERED  \tNO SERVICE\n" +
+	"MM (CS) state: IDLE        \tNORMAL SERVICE\n"
+
+func TestSierraStatusReport(t *testing.T) {
+	t.Parallel()
+
+	var report SierraStatusReport
+	require.NoError(t, report.Parse(sierraStatusLTE))
+	assert.Equal(t, "Registered Normal Service", report.Fields["EMM state"])
+	assert.Equal(t, "-93", report.Fields["RSRP (dBm)"])
+	assert.Equal(t, SystemModes.LTE, report.SystemMode())
+	assert.Equal(t, ServiceDomains.Resolve(2), report.ServiceDomain())
+
+	require.NoError(t, report.Parse(sierraStatusWCDMA))
+	assert.Equal(t, "WCDMA 2100", report.Fields["WCDMA band"])
+	assert.Equal(t, SystemModes.WCDMA, report.SystemMode())
+	assert.Equal(t, ServiceDomains.Resolve(1), report.ServiceDomain())
+
+	assert.Equal(t, ErrParseReport, report.Parse("OK"))
+}
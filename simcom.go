@@ -0,0 +1,121 @@
+package at
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeviceSIMCom returns an instance of DeviceProfile implementation for SIMCom
+// modules such as SIM800, SIM7000 and SIM7600.
+func DeviceSIMCom() DeviceProfile {
+	return &SIMComProfile{}
+}
+
+// SlowClockModes represent the sleep modes of SIMCom modules (AT+CSCLK).
+var SlowClockModes = struct {
+	Disabled Opt
+	DTR      Opt
+	Auto     Opt
+}{
+	Opt{0, "Slow clock is disabled"},
+	Opt{1, "Sleep is controlled by DTR"},
+	Opt{2, "Sleep when idle"},
+}
+
+// SIMComProfile is a DeviceProfile implementation for SIMCom modules. It handles
+// the boot reports, waits for the messaging to become ready and controls the sleep mode.
+// The modules reject AT+CNMI until the messaging is ready and reset it to the defaults
+// once they report it again after a restart, so the notifications are turned on again then.
+type SIMComProfile struct {
+	GenericProfile
+
+	// SlowClock is the sleep mode that is set at the end of Init, the sleep mode
+	// is disabled by default. Note that a sleeping module drops the first characters
+	// of the command, so Wake should be used before sending commands in the Auto mode.
+	SlowClock Opt
+}
+
+// Init makes the initial setup of the SIMCom module.
+func (p *SIMComProfile) Init(d *Device) (err error) {
+	p.dev = d
+	p.Wake()
+	p.CSCLK(SlowClockModes.Disabled) // optional, not all modules support sleep
+	if err = p.init(d, p); err != nil {
+		return err
+	}
+	if p.SlowClock.ID > 0 {
+		if err = p.CSCLK(p.SlowClock); err != nil {
+			return fmt.Errorf("at init: unable to set the sleep mode: %w", err)
+		}
+	}
+	return nil
+}
+
+// CSCLK sends AT+CSCLK to the device in order to set the sleep mode,
+// see SlowClockModes.
+func (p *SIMComProfile) CSCLK(mode Opt) (err error) {
+	req := fmt.Sprintf(`AT+CSCLK=%d`, mode.ID)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// Wake wakes up the module from the sleep mode, the module doesn't reply to the
+// first command after it was woken up.
+func (p *SIMComProfile) Wake() (err error) {
+	for i := 0; i < 3; i++ {
+		if _, err = p.dev.Send(NoopCmd); err == nil {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return
+}
+
+// HandleReport handles the boot reports of SIMCom modules.
+func (p *SIMComProfile) HandleReport(report string) (handled bool, err error) {
+	switch report {
+	case "RDY", "Call Ready", "PB DONE":
+		return true, nil
+	case "SMS Ready", "SMS DONE":
+		return true, p.restoreNotifications()
+	}
+	switch {
+	case strings.HasPrefix(report, "+CFUN:"):
+		return true, nil
+	case strings.HasPrefix(report, "+CPIN:"):
+		sim := SimStates.Invalid
+		switch SimPinStates.Resolve(strings.TrimSpace(strings.TrimPrefix(report, "+CPIN:"))) {
+		case SimPinStates.Ready:
+			sim = SimStates.Valid
+		case UnknownStringOpt:
+			// NOT INSERTED or NOT READY
+			sim = SimStates.NoCard
+		}
+		p.dev.UpdateState(func(s *DeviceState) bool {
+			if s.SimState == sim {
+				return false
+			}
+			s.SimState = sim
+			return true
+		})
+		return true, nil
+	}
+	return false, nil
+}
+
+// restoreNotifications turns on the new message notifications with the last applied config,
+// since the module resets AT+CNMI when the messaging becomes ready after a restart,
+// e.g. after AT+CFUN=1,1. Nothing is done if the notifications were not configured yet.
+func (p *SIMComProfile) restoreNotifications() error {
+	if p.dev == nil {
+		return nil
+	}
+	p.dev.notifyMux.Lock()
+	cfg := p.dev.notifyConfig
+	p.dev.notifyMux.Unlock()
+	if cfg == nil {
+		return nil
+	}
+	return p.SetNotifications(*cfg)
+}
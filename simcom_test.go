@@ -0,0 +1,41 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSIMComProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CSCLK=0", "OK")
+	modem.Reply("AT+CSCLK=2", "OK")
+	// the messaging is not ready right after the SIM
+	modem.ReplySequence(`AT+CPMS="SM","SM","SM"`, []string{"+CMS ERROR: 302"}, []string{"OK"})
+	require.NoError(t, dev.Init(&SIMComProfile{SlowClock: SlowClockModes.Auto}))
+	assert.Contains(t, modem.Sent(), "AT+CSCLK=2")
+
+	// the module resets the notifications once the messaging is ready after a restart
+	countCNMI := func() (n int) {
+		for _, cmd := range modem.Sent() {
+			if cmd == "AT+CNMI=2,1,0,0,0" {
+				n++
+			}
+		}
+		return
+	}
+	assert.Equal(t, 1, countCNMI())
+	require.NoError(t, dev.handleReport("SMS Ready"))
+	assert.Equal(t, 2, countCNMI())
+	require.NoError(t, dev.handleReport("+CPIN: NOT READY"))
+	assert.Equal(t, SimStates.NoCard, dev.State().SimState)
+	require.NoError(t, dev.handleReport("+CPIN: READY"))
+	assert.Equal(t, SimStates.Valid, dev.State().SimState)
+}
@@ -0,0 +1,231 @@
+package sms
+
+import (
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// concatKey identifies the concatenated message, i.e. its parts have the same
+// originating or destination address, reference number and number of parts.
+type concatKey struct {
+	typ     MessageType
+	address PhoneNumber
+	tag     int
+	total   int
+}
+
+// fragments are the received parts of a concatenated message.
+type fragments struct {
+	parts []Message
+	first time.Time
+}
+
+// Assembler reassembles the concatenated messages from their parts, see Add.
+// The parts of the messages that are never completed are dropped by Expire,
+// the memory is bounded by Timeout and MaxParts if Expire is never called.
+// It's safe for concurrent use.
+type Assembler struct {
+	// Timeout is the time the parts of an incomplete message are kept since
+	// the first one was added, zero means they're kept until Expire is called.
+	Timeout time.Duration
+	// Replace drops the parts of the incomplete message once a part of the message
+	// that supersedes it is added, see Message.Replaces.
+	Replace bool
+	// MaxParts is the limit of the parts kept, the oldest incomplete messages are dropped
+	// once it's exceeded, zero means no limit. The parts of the messages that consist
+	// of more parts are dropped right away since they can't be completed.
+	MaxParts int
+	// OnEvict is called with the parts of each incomplete message dropped by Add, i.e. expired,
+	// superseded or evicted due to MaxParts, in order of their sequence numbers. It's called
+	// without the lock held, so it may use the assembler.
+	OnEvict func(parts []Message)
+
+	now     func() time.Time
+	mux     sync.Mutex
+	pending map[concatKey]*fragments
+	parts   int
+}
+
+// NewAssembler returns an assembler that keeps the parts of an incomplete message for the given time.
+func NewAssembler(timeout time.Duration) *Assembler {
+	return &Assembler{Timeout: timeout}
+}
+
+// Add adds the decoded message to the assembler, the complete message is returned along with true
+// once all of its parts are added. The message that is not concatenated is returned as is right away.
+// The parts added twice are ignored.
+//
+// The incomplete messages kept longer than the timeout are dropped along the way, so are the ones
+// superseded by the added message if Replace is set and the oldest ones once MaxParts is exceeded.
+//
+// The complete message is the first part with the texts or the data of all parts joined, the user
+// data header keeps the elements other than the concatenation one and the positions of the EMS
+// objects are shifted to the joined text.
+func (a *Assembler) Add(msg *Message) (*Message, bool) {
+	var evicted [][]Message
+	defer func() {
+		if a.OnEvict != nil {
+			for _, parts := range evicted {
+				a.OnEvict(parts)
+			}
+		}
+	}()
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.pending == nil {
+		a.pending = make(map[concatKey]*fragments)
+	}
+	now := a.clock()
+	if a.Timeout > 0 {
+		evicted = a.expire(now)
+	}
+
+	udh := msg.UserDataHeader
+	if !msg.UserDataStartsWithHeader || udh.TotalNumber < 2 {
+		if a.Replace {
+			evicted = append(evicted, a.replace(concatKey{}, msg)...)
+		}
+		return msg, true
+	}
+	if udh.Sequence < 1 || udh.Sequence > udh.TotalNumber {
+		return nil, false
+	}
+	key := concatKey{
+		typ:     msg.Type,
+		address: msg.Address,
+		tag:     udh.Tag,
+		total:   udh.TotalNumber,
+	}
+	if a.Replace {
+		evicted = append(evicted, a.replace(key, msg)...)
+	}
+	if a.MaxParts > 0 && udh.TotalNumber > a.MaxParts {
+		evicted = append(evicted, []Message{*msg})
+		return nil, false
+	}
+
+	frags, ok := a.pending[key]
+	if !ok {
+		frags = &fragments{first: now}
+		a.pending[key] = frags
+	}
+	for i := range frags.parts {
+		if frags.parts[i].UserDataHeader.Sequence == udh.Sequence {
+			return nil, false
+		}
+	}
+	frags.parts = append(frags.parts, *msg)
+	a.parts++
+	if len(frags.parts) < udh.TotalNumber {
+		for a.MaxParts > 0 && a.parts > a.MaxParts && len(a.pending) > 1 {
+			evicted = append(evicted, a.drop(a.oldest(key)))
+		}
+		return nil, false
+	}
+	return joinParts(a.drop(key)), true
+}
+
+// Expire drops the parts of the incomplete messages that are kept longer than the timeout,
+// the dropped parts of each message are returned in order of their sequence numbers.
+// All of the parts are dropped if the timeout is zero.
+func (a *Assembler) Expire() [][]Message {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.Timeout > 0 {
+		return a.expire(a.clock())
+	}
+	var expired [][]Message
+	for key := range a.pending {
+		expired = append(expired, a.drop(key))
+	}
+	return expired
+}
+
+// Pending returns the number of the incomplete messages.
+func (a *Assembler) Pending() int {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return len(a.pending)
+}
+
+// expire drops the incomplete messages that are kept longer than the timeout.
+func (a *Assembler) expire(now time.Time) [][]Message {
+	var expired [][]Message
+	for key, frags := range a.pending {
+		if now.Sub(frags.first) > a.Timeout {
+			expired = append(expired, a.drop(key))
+		}
+	}
+	return expired
+}
+
+// replace drops the incomplete messages other than the given one superseded by the message.
+func (a *Assembler) replace(key concatKey, msg *Message) [][]Message {
+	var replaced [][]Message
+	for k, frags := range a.pending {
+		if k != key && msg.Replaces(&frags.parts[0]) {
+			replaced = append(replaced, a.drop(k))
+		}
+	}
+	return replaced
+}
+
+// oldest returns the key of the incomplete message other than the given one added first.
+func (a *Assembler) oldest(except concatKey) concatKey {
+	var key concatKey
+	var first time.Time
+	for k, frags := range a.pending {
+		if k != except && (first.IsZero() || frags.first.Before(first)) {
+			key, first = k, frags.first
+		}
+	}
+	return key
+}
+
+// drop drops the incomplete message and returns its parts in order of their sequence numbers.
+func (a *Assembler) drop(key concatKey) []Message {
+	frags := a.pending[key]
+	delete(a.pending, key)
+	a.parts -= len(frags.parts)
+	sortParts(frags.parts)
+	return frags.parts
+}
+
+func (a *Assembler) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+func sortParts(parts []Message) {
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].UserDataHeader.Sequence < parts[j].UserDataHeader.Sequence
+	})
+}
+
+// joinParts joins the complete set of the parts into a single message.
+func joinParts(parts []Message) *Message {
+	msg := parts[0]
+	msg.Text, msg.Data, msg.EMS, msg.MessageWaiting = "", nil, nil, nil
+	var offset int
+	for _, part := range parts {
+		msg.Text += part.Text
+		msg.Data = append(msg.Data, part.Data...)
+		for _, obj := range part.EMS {
+			obj.Position += offset
+			msg.EMS = append(msg.EMS, obj)
+		}
+		msg.MessageWaiting = append(msg.MessageWaiting, part.MessageWaiting...)
+		offset += utf8.RuneCountInString(part.Text)
+	}
+	msg.UserDataHeader.TotalNumber = 0
+	msg.UserDataHeader.Sequence = 0
+	msg.UserDataHeader.Tag = 0
+	msg.UserDataHeader.Tag16Bit = false
+	msg.UserDataStartsWithHeader = len(msg.UserDataHeader.InformationElements()) > 0
+	return &msg
+}
@@ -0,0 +1,158 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssembler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	asm := NewAssembler(time.Minute)
+	asm.now = func() time.Time { return now }
+
+	single := &Message{Type: MessageTypes.Deliver, Address: "+79991234567", Text: "hi"}
+	msg, ok := asm.Add(single)
+	assert.True(t, ok)
+	assert.Equal(t, single, msg)
+
+	long := Message{
+		Type:     MessageTypes.Deliver,
+		Encoding: Encodings.Gsm7Bit,
+		Address:  "+79991234567",
+		Text:     strings.Repeat("a", 200) + strings.Repeat("b", 200),
+	}
+	long.SetPorts(2948, 9200)
+	parts, err := Split(&long)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	parts[2].EMS = []EMSObject{{Type: IEIs.PredefinedSound, Position: 1, Sound: 2}}
+
+	_, ok = asm.Add(&parts[2])
+	assert.False(t, ok)
+	_, ok = asm.Add(&parts[0])
+	assert.False(t, ok)
+	_, ok = asm.Add(&parts[0])
+	assert.False(t, ok)
+	assert.Equal(t, 1, asm.Pending())
+	msg, ok = asm.Add(&parts[1])
+	require.True(t, ok)
+	assert.Equal(t, long.Text, msg.Text)
+	assert.True(t, msg.UserDataStartsWithHeader)
+	assert.Equal(t, UserDataHeader{DestinationPort: 2948, SourcePort: 9200}, msg.UserDataHeader)
+	assert.Equal(t, []EMSObject{{Type: IEIs.PredefinedSound, Position: len(parts[0].Text) + len(parts[1].Text) + 1,
+		Sound: 2}}, msg.EMS)
+	assert.Equal(t, 0, asm.Pending())
+
+	// the parts of another message with the same reference from another sender
+	other := parts[0]
+	other.Address = "+79997654321"
+	_, ok = asm.Add(&other)
+	assert.False(t, ok)
+	_, ok = asm.Add(&parts[1])
+	assert.False(t, ok)
+	assert.Equal(t, 2, asm.Pending())
+
+	assert.Empty(t, asm.Expire())
+	now = now.Add(2 * time.Minute)
+	expired := asm.Expire()
+	assert.Len(t, expired, 2)
+	assert.Equal(t, 0, asm.Pending())
+}
+
+func TestAssemblerReplace(t *testing.T) {
+	t.Parallel()
+
+	asm := NewAssembler(time.Minute)
+	asm.Replace = true
+	msg := Message{
+		Type:               MessageTypes.Deliver,
+		Encoding:           Encodings.Gsm7Bit,
+		Address:            "+79991234567",
+		ProtocolIdentifier: ProtocolIdentifiers.ReplaceType2,
+		Text:               strings.Repeat("a", 200),
+	}
+	parts, err := Split(&msg)
+	require.NoError(t, err)
+	_, ok := asm.Add(&parts[0])
+	assert.False(t, ok)
+
+	// the other sender doesn't replace the message
+	other := parts[0]
+	other.Address = "+79997654321"
+	other.UserDataHeader.Tag++
+	_, ok = asm.Add(&other)
+	assert.False(t, ok)
+	assert.Equal(t, 2, asm.Pending())
+
+	single := msg
+	single.Text = "b"
+	_, ok = asm.Add(&single)
+	assert.True(t, ok)
+	assert.Equal(t, 1, asm.Pending())
+	_, ok = asm.Add(&parts[1])
+	assert.False(t, ok)
+}
+
+func TestAssemblerLimits(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	asm := NewAssembler(time.Minute)
+	asm.now = func() time.Time { return now }
+	asm.MaxParts = 4
+	var evicted [][]Message
+	asm.OnEvict = func(parts []Message) {
+		// the lock is not held
+		asm.Pending()
+		evicted = append(evicted, parts)
+	}
+	part := func(address PhoneNumber, tag, total, seq int) *Message {
+		return &Message{
+			Type:                     MessageTypes.Deliver,
+			Address:                  address,
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           UserDataHeader{Tag: tag, TotalNumber: total, Sequence: seq},
+		}
+	}
+
+	// the message that can't fit is dropped right away
+	_, ok := asm.Add(part("+79991234567", 1, 5, 1))
+	assert.False(t, ok)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, 0, asm.Pending())
+
+	asm.Add(part("+79991234567", 2, 3, 1))
+	asm.Add(part("+79991234567", 2, 3, 3))
+	now = now.Add(time.Second)
+	asm.Add(part("+79997654321", 3, 3, 1))
+	asm.Add(part("+79997654321", 3, 3, 2))
+	assert.Len(t, evicted, 1)
+	assert.Equal(t, 2, asm.Pending())
+
+	// the oldest message is evicted once the limit is exceeded
+	asm.Add(part("+79990000000", 4, 2, 1))
+	require.Len(t, evicted, 2)
+	require.Len(t, evicted[1], 2)
+	assert.Equal(t, 1, evicted[1][0].UserDataHeader.Sequence)
+	assert.Equal(t, 3, evicted[1][1].UserDataHeader.Sequence)
+	assert.Equal(t, 2, asm.Pending())
+	msg, ok := asm.Add(part("+79997654321", 3, 3, 3))
+	assert.True(t, ok)
+	assert.NotNil(t, msg)
+	assert.Equal(t, 1, asm.Pending())
+
+	// the expired message is dropped by the next Add
+	now = now.Add(2 * time.Minute)
+	single := &Message{Type: MessageTypes.Deliver, Text: "hi"}
+	msg, ok = asm.Add(single)
+	assert.True(t, ok)
+	assert.Equal(t, single, msg)
+	assert.Len(t, evicted, 3)
+	assert.Equal(t, 0, asm.Pending())
+}
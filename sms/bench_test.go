@@ -0,0 +1,19 @@
+package sms
+
+import "testing"
+
+func BenchmarkMessagePDU(b *testing.B) {
+	msg := Message{
+		Type:     MessageTypes.Submit,
+		Encoding: Encodings.Gsm7Bit,
+		Address:  "+79997654321",
+		VPFormat: ValidityPeriodFormats.Relative,
+		Text:     "Your verification code is 123456, it expires in {5} minutes",
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := msg.PDU(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
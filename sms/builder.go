@@ -0,0 +1,169 @@
+package sms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xlab/at/pdu"
+)
+
+// SubmitBuilder builds an SMS-SUBMIT message step by step, e.g.
+//
+//	msg, err := sms.NewSubmit().To("+79991234567").Text("hello").WithStatusReport().Build()
+type SubmitBuilder struct {
+	msg         Message
+	encodingSet bool
+	flash       bool
+}
+
+// NewSubmit returns the builder of an SMS-SUBMIT message with the relative validity period of 4 days.
+// The encoding is selected by the content unless it's set explicitly: GSM 7-bit if the text could be
+// encoded with it, UCS2 otherwise, and 8-bit data if the data is set.
+func NewSubmit() *SubmitBuilder {
+	return &SubmitBuilder{msg: Message{
+		Type:     MessageTypes.Submit,
+		VPFormat: ValidityPeriodFormats.Relative,
+		VP:       ValidityPeriod(4 * 24 * time.Hour),
+	}}
+}
+
+// To sets the destination address.
+func (b *SubmitBuilder) To(address PhoneNumber) *SubmitBuilder {
+	b.msg.Address = address
+	return b
+}
+
+// Text sets the text of the message.
+func (b *SubmitBuilder) Text(text string) *SubmitBuilder {
+	b.msg.Text = text
+	return b
+}
+
+// Data sets the binary payload of the message.
+func (b *SubmitBuilder) Data(data []byte) *SubmitBuilder {
+	b.msg.Data = data
+	return b
+}
+
+// Encoding sets the encoding explicitly.
+func (b *SubmitBuilder) Encoding(enc Encoding) *SubmitBuilder {
+	b.msg.Encoding = enc
+	b.encodingSet = true
+	return b
+}
+
+// Flash makes the message a class 0 one, it's displayed immediately and not stored.
+// It's ignored if the encoding is set explicitly.
+func (b *SubmitBuilder) Flash() *SubmitBuilder {
+	b.flash = true
+	return b
+}
+
+// WithStatusReport requests the status report of the message.
+func (b *SubmitBuilder) WithStatusReport() *SubmitBuilder {
+	b.msg.StatusReportRequest = true
+	return b
+}
+
+// ValidFor sets the relative validity period.
+func (b *SubmitBuilder) ValidFor(d time.Duration) *SubmitBuilder {
+	b.msg.VPFormat = ValidityPeriodFormats.Relative
+	b.msg.VP = ValidityPeriod(d)
+	b.msg.VPTime = Timestamp{}
+	return b
+}
+
+// ValidUntil sets the absolute validity period.
+func (b *SubmitBuilder) ValidUntil(t time.Time) *SubmitBuilder {
+	b.msg.VPFormat = ValidityPeriodFormats.Absolute
+	b.msg.VP = 0
+	b.msg.VPTime = Timestamp(t)
+	return b
+}
+
+// ServiceCenter sets the service center address, the default one of the SIM card is used otherwise.
+func (b *SubmitBuilder) ServiceCenter(address PhoneNumber) *SubmitBuilder {
+	b.msg.ServiceCenterAddress = address
+	return b
+}
+
+// Reference sets the message reference, the modems usually assign their own one.
+func (b *SubmitBuilder) Reference(ref byte) *SubmitBuilder {
+	b.msg.MessageReference = ref
+	return b
+}
+
+// Email makes the message an email sent via the email gateway the message is addressed to,
+// see Message.SetEmail.
+func (b *SubmitBuilder) Email(address, subject string) *SubmitBuilder {
+	b.msg.SetEmail(address, subject)
+	return b
+}
+
+// ProtocolIdentifier sets the protocol identifier.
+func (b *SubmitBuilder) ProtocolIdentifier(pid ProtocolIdentifier) *SubmitBuilder {
+	b.msg.ProtocolIdentifier = pid
+	return b
+}
+
+// RejectDuplicates asks the service center to reject the message with the same
+// reference and destination address as a previously submitted one.
+func (b *SubmitBuilder) RejectDuplicates() *SubmitBuilder {
+	b.msg.RejectDuplicates = true
+	return b
+}
+
+// ReplyPath requests the reply path, i.e. the reply is sent via the same service center.
+func (b *SubmitBuilder) ReplyPath() *SubmitBuilder {
+	b.msg.ReplyPathExists = true
+	return b
+}
+
+// Ports sets the application ports the message is addressed to, see Message.SetPorts.
+func (b *SubmitBuilder) Ports(dst, src int) *SubmitBuilder {
+	b.msg.SetPorts(dst, src)
+	return b
+}
+
+// Build returns the validated message, see Message.Validate.
+func (b *SubmitBuilder) Build() (*Message, error) {
+	msg := b.msg
+	if !b.encodingSet {
+		dcs := DCS{Alphabet: Alphabets.Gsm7Bit}
+		locking, single := msg.languages()
+		switch {
+		case len(msg.Data) > 0:
+			dcs.Alphabet = Alphabets.Data8Bit
+		case !pdu.Is7BitEncodableWithTables(msg.text(), locking, single):
+			dcs.Alphabet = Alphabets.UCS2
+		}
+		if b.flash {
+			dcs.Class = MessageClasses.Class0
+		}
+		msg.Encoding = dcs.Encoding()
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// Reply returns the SMS-SUBMIT message replying to the SMS-DELIVER one with the given text,
+// it's addressed to the originating address and built as NewSubmit does. If the reply path is set,
+// the reply is sent via the service center of the message as required by 3GPP TS 23.040, annex D,
+// the default service center is used otherwise.
+func (s *Message) Reply(text string) (*Message, error) {
+	if s.Type != MessageTypes.Deliver {
+		return nil, fmt.Errorf("%w: only SMS-DELIVER could be replied to", ErrUnknownMessageType)
+	}
+	if s.Address.Alphanumeric() {
+		return nil, fmt.Errorf("%w: alphanumeric originating address %q can't be replied to",
+			ErrInvalidAddress, s.Address)
+	}
+	b := NewSubmit().To(s.Address).Text(text)
+	if s.ReplyPathExists {
+		b.ServiceCenter(s.ServiceCenterAddress)
+		b.msg.ServiceCenterType = s.ServiceCenterType
+	}
+	return b.Build()
+}
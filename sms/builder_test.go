@@ -0,0 +1,78 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitBuilder(t *testing.T) {
+	t.Parallel()
+
+	msg, err := NewSubmit().To("+79991234567").Text("hello").WithStatusReport().Build()
+	require.NoError(t, err)
+	assert.Equal(t, &Message{
+		Type:                MessageTypes.Submit,
+		Encoding:            Encodings.Gsm7Bit,
+		Address:             "+79991234567",
+		Text:                "hello",
+		VPFormat:            ValidityPeriodFormats.Relative,
+		VP:                  ValidityPeriod(4 * 24 * time.Hour),
+		StatusReportRequest: true,
+	}, msg)
+
+	msg, err = NewSubmit().To("+79991234567").Text("привет").Flash().ValidFor(time.Hour).Build()
+	require.NoError(t, err)
+	assert.Equal(t, Encodings.UCS2Flash, msg.Encoding)
+	assert.Equal(t, ValidityPeriod(time.Hour), msg.VP)
+
+	until := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg, err = NewSubmit().To("+79991234567").Data([]byte{0x01}).Ports(2948, 9200).ValidUntil(until).Build()
+	require.NoError(t, err)
+	assert.Equal(t, Encodings.Data8Bit, msg.Encoding)
+	assert.Equal(t, ValidityPeriodFormats.Absolute, msg.VPFormat)
+	assert.Equal(t, Timestamp(until), msg.VPTime)
+	dst, src, ok := msg.Ports()
+	assert.True(t, ok)
+	assert.Equal(t, [2]int{2948, 9200}, [2]int{dst, src})
+
+	_, err = NewSubmit().To("+79991234567").Text("привет").Encoding(Encodings.Gsm7Bit).Build()
+	assert.ErrorIs(t, err, ErrNotEncodable)
+	_, err = NewSubmit().Text("hello").Build()
+	assert.ErrorIs(t, err, ErrMissingAddress)
+	_, err = NewSubmit().To("+79991234567").Text(strings.Repeat("a", 161)).Build()
+	assert.ErrorIs(t, err, ErrUserDataTooLong)
+}
+
+func TestMessageReply(t *testing.T) {
+	t.Parallel()
+
+	msg := smsDeliverGsm7
+	reply, err := msg.Reply("ok")
+	require.NoError(t, err)
+	assert.Equal(t, &Message{
+		Type:     MessageTypes.Submit,
+		Encoding: Encodings.Gsm7Bit,
+		Address:  "+79269965690",
+		Text:     "ok",
+		VPFormat: ValidityPeriodFormats.Relative,
+		VP:       ValidityPeriod(4 * 24 * time.Hour),
+	}, reply)
+
+	msg.ReplyPathExists = true
+	reply, err = msg.Reply("ок")
+	require.NoError(t, err)
+	assert.Equal(t, PhoneNumber("+79262000331"), reply.ServiceCenterAddress)
+	assert.Equal(t, byte(0x91), reply.ServiceCenterType)
+	assert.Equal(t, Encodings.UCS2, reply.Encoding)
+	assert.False(t, reply.ReplyPathExists)
+
+	msg.Address = "Beeline"
+	_, err = msg.Reply("ok")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+	_, err = smsSubmitGsm7.Reply("ok")
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+}
@@ -0,0 +1,122 @@
+package sms
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrIncorrectCommand is returned when the SMS-COMMAND TPDU is malformed.
+var ErrIncorrectCommand = errors.New("sms: incorrect command")
+
+// maxCommandDataLen is the maximum length of TP-Command-Data.
+const maxCommandDataLen = 157
+
+// CommandType represents the TP-Command-Type, as specified in 3GPP TS 23.040, section 9.2.3.19.
+type CommandType byte
+
+// CommandTypes represent the command types applied to a previously submitted message.
+var CommandTypes = struct {
+	Enquiry            CommandType // enquiry relating to the message, a status report is requested
+	CancelStatusReport CommandType // cancel the status report request
+	Delete             CommandType // delete the message
+	EnableStatusReport CommandType // enable the status report request
+}{
+	0x00, 0x01, 0x02, 0x03,
+}
+
+// Command represents an SMS-COMMAND TPDU (3GPP TS 23.040, section 9.2.2.4) the mobile station
+// sends to the service center in order to operate on a previously submitted message.
+type Command struct {
+	Type CommandType
+	// MessageReference is the TP-MR of the command itself.
+	MessageReference byte
+	// MessageNumber is the TP-MR of the message the command is applied to.
+	MessageNumber byte
+	// Address is the destination address of the message the command is applied to.
+	Address                  PhoneNumber
+	ServiceCenterAddress     PhoneNumber
+	ProtocolIdentifier       ProtocolIdentifier
+	StatusReportRequest      bool
+	UserDataStartsWithHeader bool
+	// Data is the TP-Command-Data, its interpretation depends on the command type.
+	Data []byte
+}
+
+// PDU returns the number of TPDU bytes in the generated PDU and the PDU itself,
+// the same way Message.PDU does.
+func (c *Command) PDU() (int, []byte, error) {
+	if len(c.Data) > maxCommandDataLen {
+		return 0, nil, ErrIncorrectCommand
+	}
+	var buf bytes.Buffer
+	if err := writeServiceCenter(&buf, c.ServiceCenterAddress, 0); err != nil {
+		return 0, nil, err
+	}
+	scLen := buf.Len()
+
+	header := byte(MessageTypes.Command)
+	if c.StatusReportRequest {
+		header |= 0x01 << 5
+	}
+	if c.UserDataStartsWithHeader {
+		header |= 0x01 << 6
+	}
+	buf.WriteByte(header)
+	buf.WriteByte(c.MessageReference)
+	buf.WriteByte(byte(c.ProtocolIdentifier))
+	buf.WriteByte(byte(c.Type))
+	buf.WriteByte(c.MessageNumber)
+	addrLen, addr, err := c.Address.PDU()
+	if err != nil {
+		return 0, nil, err
+	}
+	buf.WriteByte(byte(addrLen))
+	buf.Write(addr)
+	buf.WriteByte(byte(len(c.Data)))
+	buf.Write(c.Data)
+	return buf.Len() - scLen, buf.Bytes(), nil
+}
+
+// ReadFrom constructs the command from the PDU octets, the SMSC information
+// precedes the TPDU the same way as in Message.ReadFrom.
+// Returns the number of read octets.
+func (c *Command) ReadFrom(octets []byte) (n int, err error) {
+	*c = Command{}
+	if c.ServiceCenterAddress, _, n, err = readServiceCenter(octets); err != nil {
+		return
+	}
+	data := octets[n:]
+	if len(data) < 6 || MessageType(data[0]&0x03) != MessageTypes.Command {
+		return n, ErrIncorrectCommand
+	}
+	c.StatusReportRequest = data[0]&(0x01<<5) != 0
+	c.UserDataStartsWithHeader = data[0]&(0x01<<6) != 0
+	c.MessageReference = data[1]
+	c.ProtocolIdentifier = ProtocolIdentifier(data[2])
+	c.Type = CommandType(data[3])
+	c.MessageNumber = data[4]
+	data = data[5:]
+	n += 5
+
+	addrLen := 2 + blocks(int(data[0]), 2)
+	if len(data) < addrLen+1 {
+		return n, ErrIncorrectCommand
+	}
+	if err = c.Address.readAddress(data[:addrLen]); err != nil {
+		return
+	}
+	data = data[addrLen:]
+	n += addrLen
+
+	dataLen := int(data[0])
+	data = data[1:]
+	n++
+	if dataLen > maxCommandDataLen || len(data) < dataLen {
+		return n, ErrIncorrectCommand
+	}
+	if dataLen > 0 {
+		c.Data = append([]byte(nil), data[:dataLen]...)
+	}
+	n += dataLen
+	return n, nil
+}
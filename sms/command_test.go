@@ -0,0 +1,48 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := Command{
+		Type:                CommandTypes.Delete,
+		MessageReference:    0x05,
+		MessageNumber:       0x2A,
+		Address:             "+79991234567",
+		StatusReportRequest: true,
+	}
+	n, octets, err := cmd.PDU()
+	require.NoError(t, err)
+	assert.Equal(t, 14, n)
+	assert.Equal(t, util.MustBytes("00220500022A0B919799214365F700"), octets)
+
+	var parsed Command
+	read, err := parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, len(octets), read)
+	assert.Equal(t, cmd, parsed)
+
+	cmd = Command{
+		Type:                 CommandTypes.Enquiry,
+		Address:              "+79991234567",
+		ServiceCenterAddress: "+79010000000",
+		Data:                 []byte{0x01, 0x02},
+	}
+	_, octets, err = cmd.PDU()
+	require.NoError(t, err)
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, cmd, parsed)
+
+	_, err = parsed.ReadFrom(util.MustBytes("00220500022A0B919799214365"))
+	assert.Equal(t, ErrIncorrectCommand, err)
+	_, err = parsed.ReadFrom(util.MustBytes("00010500022A0B919799214365F700"))
+	assert.Equal(t, ErrIncorrectCommand, err)
+}
@@ -0,0 +1,163 @@
+package sms
+
+// CodingGroup represents the coding group of the data coding scheme.
+type CodingGroup byte
+
+// CodingGroups represent the possible coding groups of the data coding scheme (3GPP TS 23.038, section 4).
+var CodingGroups = struct {
+	General      CodingGroup // 00xx
+	AutoDeletion CodingGroup // 01xx, the message is marked for automatic deletion
+	Reserved     CodingGroup // 1000..1011
+	MWIDiscard   CodingGroup // 1100, message waiting indication, the message may be discarded
+	MWIStore     CodingGroup // 1101 and 1110, message waiting indication, the message is stored
+	DataClass    CodingGroup // 1111, data coding and message class
+}{
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05,
+}
+
+// Alphabet represents the character set of the user data.
+type Alphabet byte
+
+// Alphabets represent the possible character sets of the user data, the values
+// match the alphabet bits of the general data coding group.
+var Alphabets = struct {
+	Gsm7Bit  Alphabet
+	Data8Bit Alphabet
+	UCS2     Alphabet
+	Reserved Alphabet
+	// Latin1 is the ISO-8859-1 text of the legacy SMSCs, e.g. the ANSI-136 ones, in place of
+	// the 8-bit data. It's encoded as Data8Bit and never parsed, see Message.SetDCS.
+	Latin1 Alphabet
+}{
+	0x00, 0x01, 0x02, 0x03, 0x04,
+}
+
+// MessageClass represents the class of the message.
+type MessageClass byte
+
+// MessageClasses represent the possible classes of the message, the values
+// match the class bits of the general data coding group.
+var MessageClasses = struct {
+	None   MessageClass
+	Class0 MessageClass // flash, displayed immediately
+	Class1 MessageClass // ME-specific
+	Class2 MessageClass // (U)SIM-specific
+	Class3 MessageClass // TE-specific
+}{
+	0x00, 0x10, 0x11, 0x12, 0x13,
+}
+
+// IndicationType represents the type of the message waiting indication.
+type IndicationType byte
+
+// IndicationTypes represent the possible types of the message waiting indication.
+var IndicationTypes = struct {
+	Voicemail IndicationType
+	Fax       IndicationType
+	Email     IndicationType
+	Other     IndicationType
+}{
+	0x00, 0x01, 0x02, 0x03,
+}
+
+// DCS represents the decoded TP-Data-Coding-Scheme, as specified in 3GPP TS 23.038, section 4.
+type DCS struct {
+	Group    CodingGroup
+	Alphabet Alphabet
+	Class    MessageClass
+	// Compressed is set for the user data compressed as specified in 3GPP TS 23.042,
+	// such messages can't be decoded or encoded, see ErrCompressed.
+	Compressed bool
+	// IndicationActive and IndicationType are set in the message waiting indication groups.
+	IndicationActive bool
+	IndicationType   IndicationType
+
+	// the octet of a reserved coding group
+	reserved byte
+}
+
+// ParseDCS decodes the data coding scheme from the given octet.
+// The reserved codings are assumed to use the GSM 7-bit default alphabet.
+func ParseDCS(octet byte) DCS {
+	var dcs DCS
+	switch group := octet >> 4; {
+	case group <= 0x07:
+		dcs.Group = CodingGroups.General
+		if group >= 0x04 {
+			dcs.Group = CodingGroups.AutoDeletion
+		}
+		dcs.Compressed = octet&0x20 != 0
+		dcs.Alphabet = Alphabet(octet >> 2 & 0x03)
+		if octet&0x10 != 0 {
+			dcs.Class = MessageClass(0x10 | octet&0x03)
+		}
+	case group <= 0x0B:
+		dcs.Group = CodingGroups.Reserved
+		dcs.reserved = octet
+	case group <= 0x0E:
+		dcs.Group = CodingGroups.MWIStore
+		if group == 0x0C {
+			dcs.Group = CodingGroups.MWIDiscard
+		}
+		if group == 0x0E {
+			dcs.Alphabet = Alphabets.UCS2
+		}
+		dcs.IndicationActive = octet&0x08 != 0
+		dcs.IndicationType = IndicationType(octet & 0x03)
+	default:
+		dcs.Group = CodingGroups.DataClass
+		if octet&0x04 != 0 {
+			dcs.Alphabet = Alphabets.Data8Bit
+		}
+		dcs.Class = MessageClass(0x10 | octet&0x03)
+	}
+	return dcs
+}
+
+// Byte encodes the data coding scheme into the octet. The data coding and message
+// class group has no class-less coding, so class 0 is used if the class is not set.
+func (d DCS) Byte() byte {
+	switch d.Group {
+	case CodingGroups.Reserved:
+		return d.reserved
+	case CodingGroups.MWIDiscard, CodingGroups.MWIStore:
+		octet := 0xC0 | byte(d.IndicationType)&0x03
+		switch {
+		case d.Alphabet == Alphabets.UCS2:
+			octet |= 0x20
+		case d.Group == CodingGroups.MWIStore:
+			octet |= 0x10
+		}
+		if d.IndicationActive {
+			octet |= 0x08
+		}
+		return octet
+	case CodingGroups.DataClass:
+		octet := 0xF0 | byte(d.Class)&0x03
+		if d.Alphabet == Alphabets.Data8Bit || d.Alphabet == Alphabets.Latin1 {
+			octet |= 0x04
+		}
+		return octet
+	default:
+		alphabet := d.Alphabet
+		if alphabet == Alphabets.Latin1 {
+			alphabet = Alphabets.Data8Bit
+		}
+		octet := byte(alphabet&0x03) << 2
+		if d.Group == CodingGroups.AutoDeletion {
+			octet |= 0x40
+		}
+		if d.Compressed {
+			octet |= 0x20
+		}
+		if d.Class != MessageClasses.None {
+			octet |= 0x10 | byte(d.Class)&0x03
+		}
+		return octet
+	}
+}
+
+// Encoding returns the data coding scheme as Encoding.
+func (d DCS) Encoding() Encoding {
+	return Encoding(d.Byte())
+}
@@ -0,0 +1,142 @@
+package sms
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestParseDCS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		octet byte
+		dcs   DCS
+	}{
+		{0x00, DCS{}},
+		{0x08, DCS{Alphabet: Alphabets.UCS2}},
+		{0x04, DCS{Alphabet: Alphabets.Data8Bit}},
+		{0x10, DCS{Class: MessageClasses.Class0}},
+		{0x18, DCS{Alphabet: Alphabets.UCS2, Class: MessageClasses.Class0}},
+		{0x32, DCS{Compressed: true, Class: MessageClasses.Class2}},
+		{0x48, DCS{Group: CodingGroups.AutoDeletion, Alphabet: Alphabets.UCS2}},
+		{0x80, DCS{Group: CodingGroups.Reserved, reserved: 0x80}},
+		{0xC8, DCS{Group: CodingGroups.MWIDiscard, IndicationActive: true}},
+		{0xD2, DCS{Group: CodingGroups.MWIStore, IndicationType: IndicationTypes.Email}},
+		{0xE9, DCS{Group: CodingGroups.MWIStore, Alphabet: Alphabets.UCS2,
+			IndicationActive: true, IndicationType: IndicationTypes.Fax}},
+		{0xF5, DCS{Group: CodingGroups.DataClass, Alphabet: Alphabets.Data8Bit, Class: MessageClasses.Class1}},
+	}
+	for _, test := range tests {
+		dcs := ParseDCS(test.octet)
+		assert.Equal(t, test.dcs, dcs, "%02X", test.octet)
+		assert.Equal(t, test.octet, dcs.Byte(), "%02X", test.octet)
+	}
+	assert.Equal(t, Alphabets.Gsm7Bit, Encodings.Gsm7Bit_2.DCS().Alphabet)
+	assert.Equal(t, MessageClasses.Class0, Encodings.UCS2Flash.DCS().Class)
+}
+
+func TestSmsDeliverClassData(t *testing.T) {
+	t.Parallel()
+
+	// the 8-bit data of class 1 in the data coding and message class group
+	msg := Message{
+		Type:     MessageTypes.Deliver,
+		Encoding: 0xF5,
+		Address:  "+79997654321",
+		Data:     []byte{0x01, 0x02},
+	}
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Data, parsed.Data)
+}
+
+func TestSIMSpecific(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&Message{Encoding: Encodings.Gsm7Bit}).SIMSpecific())
+	assert.True(t, (&Message{Encoding: 0x12}).SIMSpecific())
+	assert.True(t, (&Message{Encoding: 0xF6}).SIMSpecific())
+	assert.True(t, (&Message{ProtocolIdentifier: ProtocolIdentifiers.SimDataDownload}).SIMSpecific())
+	assert.False(t, (&Message{Encoding: 0xF5}).SIMSpecific())
+}
+
+func TestReplaces(t *testing.T) {
+	t.Parallel()
+
+	msg := &Message{
+		Type:               MessageTypes.Deliver,
+		Address:            "+79991234567",
+		ProtocolIdentifier: ProtocolIdentifiers.ReplaceType1,
+	}
+	assert.Equal(t, 1, msg.ReplaceType())
+	other := *msg
+	assert.True(t, msg.Replaces(&other))
+	other.ServiceCenterAddress = "+79990000000"
+	assert.False(t, msg.Replaces(&other))
+	other = *msg
+	other.ProtocolIdentifier = ProtocolIdentifiers.ReplaceType2
+	assert.False(t, msg.Replaces(&other))
+	other.ProtocolIdentifier = ProtocolIdentifiers.Default
+	assert.False(t, other.Replaces(&other))
+	report := Message{Type: MessageTypes.StatusReport, ProtocolIdentifier: ProtocolIdentifiers.ReplaceType1}
+	assert.Zero(t, report.ReplaceType())
+}
+
+func TestLatin1Text(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		Type:    MessageTypes.Submit,
+		Address: "+79997654321",
+		Text:    "Café à 5€",
+	}
+	msg.SetDCS(DCS{Alphabet: Alphabets.Latin1})
+	assert.Equal(t, Encodings.Data8Bit, msg.Encoding)
+	assert.True(t, msg.Latin1)
+	assert.ErrorIs(t, msg.Validate(), ErrNotEncodable)
+
+	msg.Text = "Café à 5$"
+	require.NoError(t, msg.Validate())
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasSuffix(octets, []byte{0x09, 'C', 'a', 'f', 0xE9, ' ', 0xE0, ' ', '5', '$'}), "% X", octets)
+
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Empty(t, parsed.Text)
+	require.NoError(t, parsed.DecodeLatin1())
+	assert.Equal(t, "Café à 5$", parsed.Text)
+	assert.Nil(t, parsed.Data)
+	assert.Equal(t, Alphabets.Latin1, parsed.DCS().Alphabet)
+	assert.Equal(t, byte(0xF4), DCS{Group: CodingGroups.DataClass, Alphabet: Alphabets.Latin1}.Byte())
+
+	parsed = Message{Encoding: Encodings.UCS2, Text: "hi"}
+	assert.ErrorIs(t, parsed.DecodeLatin1(), ErrEncodingMismatch)
+}
+
+func TestSmsDeliverCompressed(t *testing.T) {
+	t.Parallel()
+
+	// the concatenated message of the compressed GSM 7-bit text
+	octets := util.MustBytes("00440B919799674523F1" + "0020" + "22206151457440" + "08" + "0500032A0201" + "E834")
+	var msg Message
+	_, err := msg.ReadFrom(octets)
+	assert.ErrorIs(t, err, ErrCompressed)
+	_, warnings, err := msg.ReadFromMode(octets, DecodeModes.Lenient)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.ErrorIs(t, warnings[0], ErrCompressed)
+	assert.Equal(t, UserDataHeader{Tag: 0x2A, TotalNumber: 2, Sequence: 1}, msg.UserDataHeader)
+	assert.Equal(t, []byte{0xE8, 0x34}, msg.Data)
+	assert.Empty(t, msg.Text)
+	_, _, err = msg.PDU()
+	assert.ErrorIs(t, err, ErrCompressed)
+}
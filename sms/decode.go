@@ -0,0 +1,178 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xlab/at/sms/tpdu"
+)
+
+// Decoding violations, they're reported as warnings in lenient mode, see DecodeModes.
+var (
+	ErrUserDataLength          = errors.New("sms: user data length doesn't match the user data")
+	ErrIncorrectAddressLength  = errors.New("sms: address is longer than 20 digits")
+	ErrUnsupportedAddress      = errors.New("sms: address can't be decoded")
+	ErrReservedDataCodingGroup = errors.New("sms: reserved data coding group")
+	ErrUcs2ByteOrder           = errors.New("sms: UCS2 text isn't big-endian")
+)
+
+// DecodeMode represents the way the violations of the specification are handled when a message is decoded.
+type DecodeMode byte
+
+// DecodeModes represent the possible decoding modes.
+var DecodeModes = struct {
+	// Default ignores the violations that don't prevent the message from being decoded
+	// and fails on the other ones, it's the mode of ReadFrom.
+	Default DecodeMode
+	// Strict fails on any violation, e.g. to validate the PDUs.
+	Strict DecodeMode
+	// Lenient tolerates the real-world quirks and reports them as warnings: the user data length
+	// that doesn't match the user data, the overlong or undecodable addresses, the malformed user
+	// data header, which is decoded as a part of the user data then, and the reserved or unsupported
+	// data coding schemes, the user data is decoded as 8-bit data then. The byte order of UCS2 text
+	// is detected, see pdu.ByteOrders.Auto.
+	Lenient DecodeMode
+}{
+	0x00, 0x01, 0x02,
+}
+
+// decoder tracks the violations of the specification while a message is decoded.
+type decoder struct {
+	mode     DecodeMode
+	warnings []error
+}
+
+// violation handles the violation the default mode ignores,
+// the error is returned in strict mode only.
+func (d *decoder) violation(err error) error {
+	switch d.mode {
+	case DecodeModes.Strict:
+		return err
+	case DecodeModes.Lenient:
+		d.warnings = append(d.warnings, err)
+	}
+	return nil
+}
+
+// tolerate handles the violation the default mode fails on,
+// returns true if it's tolerated in lenient mode.
+func (d *decoder) tolerate(err error) bool {
+	if d.mode != DecodeModes.Lenient {
+		return false
+	}
+	d.warnings = append(d.warnings, err)
+	return true
+}
+
+// readAddress reads the address field of the message.
+func (d *decoder) readAddress(addr *PhoneNumber, field []byte) error {
+	if len(field) > 0 && field[0] > maxAddressDigits {
+		err := fmt.Errorf("%w: %d semi-octets", ErrIncorrectAddressLength, field[0])
+		if err = d.violation(err); err != nil {
+			return err
+		}
+	}
+	if err := addr.readAddress(field); err != nil {
+		return d.violation(fmt.Errorf("%w: %v", ErrUnsupportedAddress, err))
+	}
+	return nil
+}
+
+// readUserDataHeader reads the user data header of the message if it's indicated.
+func (d *decoder) readUserDataHeader(s *Message, userData []byte) error {
+	if !s.UserDataStartsWithHeader {
+		return nil
+	}
+	err := s.UserDataHeader.ReadFrom(userData)
+	if err != nil && d.tolerate(err) {
+		s.UserDataStartsWithHeader = false
+		s.UserDataHeader = UserDataHeader{}
+		return nil
+	}
+	return err
+}
+
+// alphabet returns the alphabet the user data is decoded with.
+func (d *decoder) alphabet(enc Encoding) (Alphabet, error) {
+	dcs := enc.DCS()
+	switch {
+	case dcs.Compressed:
+		// the compressed user data is kept as is in lenient mode
+		err := fmt.Errorf("%w: DCS 0x%02X", ErrCompressed, byte(enc))
+		if d.tolerate(err) {
+			return Alphabets.Data8Bit, nil
+		}
+		return 0, err
+	case dcs.Alphabet == Alphabets.Reserved:
+		err := fmt.Errorf("%w: DCS 0x%02X", ErrUnknownEncoding, byte(enc))
+		if d.tolerate(err) {
+			return Alphabets.Data8Bit, nil
+		}
+		return 0, err
+	case dcs.Group == CodingGroups.Reserved:
+		err := fmt.Errorf("%w: DCS 0x%02X", ErrReservedDataCodingGroup, byte(enc))
+		if d.tolerate(err) {
+			return Alphabets.Data8Bit, nil
+		}
+		// the reserved groups are assumed to use the default alphabet
+		return dcs.Alphabet, d.violation(err)
+	}
+	return dcs.Alphabet, nil
+}
+
+// checkUserDataLength checks the user data length matches the user data.
+func (d *decoder) checkUserDataLength(alphabet Alphabet, data []byte, dataLen byte) error {
+	expected := int(dataLen)
+	if alphabet == Alphabets.Gsm7Bit {
+		expected = blocks(int(dataLen)*7, 8)
+	}
+	if len(data) == expected {
+		return nil
+	}
+	return d.violation(fmt.Errorf("%w: %d octets, UDL is %d", ErrUserDataLength, len(data), dataLen))
+}
+
+// FieldError is returned when a field of the PDU can't be decoded. The offset is the one of the octet
+// the field starts at, counted from the start of the PDU, i.e. the SMSC information included.
+type FieldError struct {
+	// Kind is the kind of the TPDU, e.g. "deliver", it's empty for the SMSC information.
+	Kind   string
+	Field  string
+	Offset int
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	reason := strings.TrimPrefix(strings.TrimPrefix(e.Err.Error(), "sms: "), "tpdu: ")
+	if errors.Is(e.Err, io.ErrUnexpectedEOF) {
+		reason = "truncated"
+	}
+	if e.Kind == "" {
+		return fmt.Sprintf("sms: %s at offset %d: %s", e.Field, e.Offset, reason)
+	}
+	return fmt.Sprintf("sms: %s: %s at offset %d: %s", e.Kind, e.Field, e.Offset, reason)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// tpduError converts the error of a TPDU field into FieldError.
+func tpduError(err error) error {
+	var fieldErr *tpdu.FieldError
+	if errors.As(err, &fieldErr) {
+		return &FieldError{Field: fieldErr.Field, Offset: fieldErr.Offset, Err: fieldErr.Err}
+	}
+	return err
+}
+
+// fieldError wraps the error of the field that starts at the given offset of the TPDU,
+// the PDU that ends before the field is truncated.
+func fieldError(field string, offset int, err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return &FieldError{Field: field, Offset: offset, Err: err}
+}
@@ -0,0 +1,231 @@
+package sms
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/pdu"
+	"github.com/xlab/at/util"
+)
+
+func TestReadFromMode(t *testing.T) {
+	t.Parallel()
+
+	const header = "00040B919799674523F1"
+	const scts = "22206151457440"
+	testcases := []struct {
+		name    string
+		pdu     string
+		def     error // the error in default mode
+		strict  error
+		lenient func(t *testing.T, msg *Message)
+	}{
+		{
+			name: "valid",
+			pdu:  header + "0000" + scts + "02E834",
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, "hi", msg.Text)
+			},
+		},
+		{
+			name:   "short user data",
+			pdu:    header + "0000" + scts + "05E834",
+			strict: ErrUserDataLength,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, "hi", msg.Text)
+			},
+		},
+		{
+			name:   "trailing octets",
+			pdu:    header + "0008" + scts + "0400680069FFFF",
+			strict: ErrUserDataLength,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, "hi", msg.Text)
+			},
+		},
+		{
+			name:   "reserved coding group",
+			pdu:    header + "0080" + scts + "02E834",
+			strict: ErrReservedDataCodingGroup,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Empty(t, msg.Text)
+				assert.Equal(t, []byte{0xE8, 0x34}, msg.Data)
+			},
+		},
+		{
+			name:   "reserved alphabet",
+			pdu:    header + "000C" + scts + "02E834",
+			def:    ErrUnknownEncoding,
+			strict: ErrUnknownEncoding,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, []byte{0xE8, 0x34}, msg.Data)
+			},
+		},
+		{
+			name:   "compressed",
+			pdu:    header + "0020" + scts + "02E834",
+			def:    ErrCompressed,
+			strict: ErrCompressed,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.True(t, msg.Encoding.DCS().Compressed)
+				assert.Equal(t, []byte{0xE8, 0x34}, msg.Data)
+			},
+		},
+		{
+			name:   "overlong address",
+			pdu:    "0004159197996745231111111111F1" + "0000" + scts + "02E834",
+			strict: ErrIncorrectAddressLength,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, PhoneNumber("+799976543211111111111"), msg.Address)
+			},
+		},
+		{
+			name:   "unsupported address",
+			pdu:    "00040B819799674523F1" + "0000" + scts + "02E834",
+			strict: ErrUnsupportedAddress,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Empty(t, msg.Address)
+				assert.Equal(t, "hi", msg.Text)
+			},
+		},
+		{
+			name:   "malformed header",
+			pdu:    "00440B919799674523F1" + "0004" + scts + "03050003",
+			def:    ErrIncorrectUserDataHeaderLength,
+			strict: ErrIncorrectUserDataHeaderLength,
+			lenient: func(t *testing.T, msg *Message) {
+				assert.False(t, msg.UserDataStartsWithHeader)
+				assert.Equal(t, []byte{0x05, 0x00, 0x03}, msg.Data)
+			},
+		},
+		{
+			name: "reserved language",
+			pdu:  "00440B919799674523F1" + "0000" + scts + "070325017040A701",
+			lenient: func(t *testing.T, msg *Message) {
+				assert.Equal(t, pdu.Language(0x70), msg.UserDataHeader.LockingShift)
+				assert.Equal(t, "hi", msg.Text)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		octets := util.MustBytes(tc.pdu)
+		var msg Message
+		_, err := msg.ReadFrom(octets)
+		if tc.def != nil {
+			assert.ErrorIs(t, err, tc.def, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+
+		_, warnings, err := msg.ReadFromMode(octets, DecodeModes.Strict)
+		if tc.strict != nil {
+			assert.ErrorIs(t, err, tc.strict, tc.name)
+		} else {
+			assert.NoError(t, err, tc.name)
+		}
+		assert.Empty(t, warnings, tc.name)
+
+		_, warnings, err = msg.ReadFromMode(octets, DecodeModes.Lenient)
+		require.NoError(t, err, tc.name)
+		if tc.strict != nil {
+			require.Len(t, warnings, 1, tc.name)
+			assert.ErrorIs(t, warnings[0], tc.strict, tc.name)
+		} else {
+			assert.Empty(t, warnings, tc.name)
+		}
+		tc.lenient(t, &msg)
+	}
+}
+
+func TestReadFromModeByteOrder(t *testing.T) {
+	t.Parallel()
+
+	// the UCS2 text is little-endian, 0xDF00 of 'ß' is an unpaired surrogate
+	octets := util.MustBytes("00040B919799674523F1" + "0008" + "22206151457440" + "04DF00FC00")
+	var msg Message
+	_, err := msg.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, "\uFFFD\uFC00", msg.Text)
+
+	_, warnings, err := msg.ReadFromMode(octets, DecodeModes.Lenient)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.ErrorIs(t, warnings[0], ErrUcs2ByteOrder)
+	assert.Equal(t, "ßü", msg.Text)
+
+	// the valid big-endian text is kept and its byte order mark is removed
+	for _, ud := range []string{"04D83DDE00", "08D83DDE00D83DDE00", "06FEFFD83DDE00"} {
+		octets = util.MustBytes("00040B919799674523F1" + "0008" + "22206151457440" + ud)
+		_, warnings, err = msg.ReadFromMode(octets, DecodeModes.Lenient)
+		require.NoError(t, err, ud)
+		assert.Empty(t, warnings, ud)
+		assert.Contains(t, []string{"😀", "😀😀"}, msg.Text, ud)
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pdu    string
+		err    error
+		field  string
+		offset int
+		text   string
+	}{
+		{
+			pdu:    "07919762020033F1040B91976299",
+			err:    io.ErrUnexpectedEOF,
+			field:  "originating address",
+			offset: 9,
+			text:   "sms: deliver: originating address at offset 9: truncated",
+		},
+		{
+			pdu:    "07919762020033F1040B919762995696F00000416062914015",
+			err:    io.ErrUnexpectedEOF,
+			field:  "service center time stamp",
+			offset: 19,
+			text:   "sms: deliver: service center time stamp at offset 19: truncated",
+		},
+		{
+			pdu:    "0011001191",
+			err:    ErrIncorrectSize,
+			field:  "destination address",
+			offset: 3,
+			text:   "sms: submit: destination address at offset 3: decoded incorrect size of field: 17 digits",
+		},
+		{
+			pdu:    "000600",
+			err:    io.ErrUnexpectedEOF,
+			field:  "recipient address",
+			offset: 3,
+			text:   "sms: status report: recipient address at offset 3: truncated",
+		},
+		{
+			pdu:    "00040B919799674523F1000C22206151457440" + "02E834",
+			err:    ErrUnknownEncoding,
+			field:  "user data",
+			offset: 20,
+			text:   "sms: deliver: user data at offset 20: unsupported encoding: DCS 0x0C",
+		},
+		{
+			pdu:    "0791976202",
+			err:    io.ErrUnexpectedEOF,
+			field:  "service center address",
+			offset: 0,
+			text:   "sms: service center address at offset 0: truncated",
+		},
+	} {
+		var msg Message
+		_, err := msg.ReadFrom(util.MustBytes(tc.pdu))
+		assert.ErrorIs(t, err, tc.err, tc.pdu)
+		var fieldErr *FieldError
+		if assert.ErrorAs(t, err, &fieldErr, tc.pdu) {
+			assert.Equal(t, tc.field, fieldErr.Field, tc.pdu)
+			assert.Equal(t, tc.offset, fieldErr.Offset, tc.pdu)
+		}
+		assert.EqualError(t, err, tc.text, tc.pdu)
+	}
+}
@@ -0,0 +1,132 @@
+package sms
+
+import "errors"
+
+// ErrIncorrectDeliverReport is returned when the SMS-DELIVER-REPORT TPDU is malformed.
+var ErrIncorrectDeliverReport = errors.New("sms: incorrect deliver report")
+
+// FailureCause represents the reason the short message was not accepted by the receiving
+// entity, as specified in 3GPP TS 23.040, section 9.2.3.22.
+type FailureCause byte
+
+// FailureCauses represent the failure causes the mobile station reports to the service center.
+var FailureCauses = struct {
+	// TP-PID errors
+	TelematicInterworkingNotSupported FailureCause
+	ShortMessageType0NotSupported     FailureCause
+	CannotReplaceShortMessage         FailureCause
+	UnspecifiedPIDError               FailureCause
+
+	// TP-DCS errors
+	AlphabetNotSupported     FailureCause
+	MessageClassNotSupported FailureCause
+	UnspecifiedDCSError      FailureCause
+
+	// MS errors
+	SimStorageFull         FailureCause
+	NoSimStorage           FailureCause
+	ErrorInMS              FailureCause
+	MemoryCapacityExceeded FailureCause
+	SimToolkitBusy         FailureCause
+	SimDataDownloadError   FailureCause
+	UnspecifiedErrorCause  FailureCause
+}{
+	0x80, 0x81, 0x82, 0x8F,
+	0x90, 0x91, 0x9F,
+	0xD0, 0xD1, 0xD2, 0xD3, 0xD4, 0xD5, 0xFF,
+}
+
+// DeliverReport represents an SMS-DELIVER-REPORT TPDU (3GPP TS 23.040, section 9.2.2.1a)
+// the mobile station sends in acknowledgement of a received SMS-DELIVER. The report is
+// sent with RP-ACK if there is no failure cause and with RP-ERROR otherwise.
+type DeliverReport struct {
+	// FailureCause is zero for the positive acknowledgement.
+	FailureCause FailureCause
+	// ProtocolIdentifier is included if it's not nil.
+	ProtocolIdentifier *ProtocolIdentifier
+	// Encoding and Text are included if the text is not empty, the text must
+	// fit into the remaining user data without concatenation.
+	Encoding Encoding
+	Text     string
+}
+
+// Ack returns true if the report is the positive acknowledgement.
+func (r *DeliverReport) Ack() bool {
+	return r.FailureCause == 0
+}
+
+// Bytes returns the TPDU of the report, the TP-Parameter-Indicator
+// marks which of the optional fields follow.
+func (r *DeliverReport) Bytes() ([]byte, error) {
+	buf := []byte{byte(MessageTypes.DeliverReport)}
+	if !r.Ack() {
+		buf = append(buf, byte(r.FailureCause))
+	}
+	var pi byte
+	if r.ProtocolIdentifier != nil {
+		pi |= 0x01
+	}
+	if r.Text != "" {
+		pi |= 0x02 | 0x04
+	}
+	buf = append(buf, pi)
+	if r.ProtocolIdentifier != nil {
+		buf = append(buf, byte(*r.ProtocolIdentifier))
+	}
+	if r.Text != "" {
+		msg := Message{Encoding: r.Encoding, Text: r.Text}
+		userData, length, err := msg.encodedUserData()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, byte(r.Encoding), length)
+		buf = append(buf, userData...)
+	}
+	return buf, nil
+}
+
+// ReadFrom parses the report from the TPDU, the SMS-DELIVER-REPORT for RP-ACK and the one for
+// RP-ERROR are told apart by the fact the failure cause is always greater than 0x7F and the
+// parameter indicator never has the 8th bit set since there is no extension octet.
+func (r *DeliverReport) ReadFrom(octets []byte) error {
+	*r = DeliverReport{}
+	if len(octets) < 2 || MessageType(octets[0]&0x03) != MessageTypes.DeliverReport {
+		return ErrIncorrectDeliverReport
+	}
+	octets = octets[1:]
+	if octets[0]&0x80 != 0 {
+		r.FailureCause = FailureCause(octets[0])
+		octets = octets[1:]
+		if len(octets) == 0 {
+			return ErrIncorrectDeliverReport
+		}
+	}
+	pi := octets[0]
+	octets = octets[1:]
+	if pi&0x01 != 0 {
+		if len(octets) == 0 {
+			return ErrIncorrectDeliverReport
+		}
+		pid := ProtocolIdentifier(octets[0])
+		r.ProtocolIdentifier = &pid
+		octets = octets[1:]
+	}
+	if pi&0x02 != 0 {
+		if len(octets) == 0 {
+			return ErrIncorrectDeliverReport
+		}
+		r.Encoding = Encoding(octets[0])
+		octets = octets[1:]
+	}
+	if pi&0x04 != 0 {
+		if len(octets) == 0 {
+			return ErrIncorrectDeliverReport
+		}
+		msg := Message{Encoding: r.Encoding}
+		if err := msg.decodeUserData(octets[1:], octets[0], &decoder{}); err != nil {
+			return err
+		}
+		r.Text = msg.Text
+	}
+	return nil
+}
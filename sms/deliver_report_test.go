@@ -0,0 +1,36 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestDeliverReport(t *testing.T) {
+	t.Parallel()
+
+	ack := DeliverReport{}
+	octets, err := ack.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x00}, octets)
+
+	pid := ProtocolIdentifiers.SimDataDownload
+	report := DeliverReport{
+		FailureCause:       FailureCauses.SimDataDownloadError,
+		ProtocolIdentifier: &pid,
+		Encoding:           Encodings.Gsm7Bit,
+		Text:               "hi",
+	}
+	octets, err = report.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, util.MustBytes("00D5077F0002E834"), octets)
+
+	var parsed DeliverReport
+	require.NoError(t, parsed.ReadFrom(octets))
+	assert.Equal(t, report, parsed)
+	require.NoError(t, parsed.ReadFrom([]byte{0x00, 0x00}))
+	assert.True(t, parsed.Ack())
+	assert.Error(t, parsed.ReadFrom([]byte{0x00, 0xD5}))
+}
@@ -0,0 +1,258 @@
+package sms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var codingGroupNames = map[CodingGroup]string{
+	CodingGroups.General:      "general",
+	CodingGroups.AutoDeletion: "automatic deletion",
+	CodingGroups.Reserved:     "reserved",
+	CodingGroups.MWIDiscard:   "message waiting, discard",
+	CodingGroups.MWIStore:     "message waiting, store",
+	CodingGroups.DataClass:    "data coding and message class",
+}
+
+var alphabetNames = map[Alphabet]string{
+	Alphabets.Gsm7Bit:  "GSM 7-bit",
+	Alphabets.Data8Bit: "8-bit data",
+	Alphabets.UCS2:     "UCS2",
+	Alphabets.Reserved: "reserved alphabet",
+	Alphabets.Latin1:   "ISO-8859-1 text",
+}
+
+var messageClassNames = map[MessageClass]string{
+	MessageClasses.None:   "no class",
+	MessageClasses.Class0: "class 0 (flash)",
+	MessageClasses.Class1: "class 1 (ME-specific)",
+	MessageClasses.Class2: "class 2 (SIM-specific)",
+	MessageClasses.Class3: "class 3 (TE-specific)",
+}
+
+var indicationTypeNames = map[IndicationType]string{
+	IndicationTypes.Voicemail: "voicemail",
+	IndicationTypes.Fax:       "fax",
+	IndicationTypes.Email:     "email",
+	IndicationTypes.Other:     "other",
+}
+
+var ieiNames = map[IEI]string{
+	IEIs.Concatenated8Bit:     "concatenated short messages, 8-bit reference",
+	IEIs.SpecialMessage:       "special SMS message indication",
+	IEIs.ApplicationPort8Bit:  "application port addressing, 8-bit",
+	IEIs.ApplicationPort16Bit: "application port addressing, 16-bit",
+	IEIs.SMSCControl:          "SMSC control parameters",
+	IEIs.SourceIndicator:      "UDH source indicator",
+	IEIs.Concatenated16Bit:    "concatenated short messages, 16-bit reference",
+	IEIs.WirelessControl:      "wireless control message protocol",
+	IEIs.TextFormatting:       "text formatting",
+	IEIs.PredefinedSound:      "predefined sound",
+	IEIs.UserDefinedSound:     "user defined sound",
+	IEIs.LargePicture:         "large picture",
+	IEIs.SmallPicture:         "small picture",
+	IEIs.VariablePicture:      "variable picture",
+	IEIs.NationalSingleShift:  "national language single shift",
+	IEIs.NationalLockingShift: "national language locking shift",
+}
+
+// typeName returns the name of the message type as in 3GPP TS 23.040.
+func (s *Message) typeName() string {
+	switch s.Type {
+	case MessageTypes.Deliver:
+		return "SMS-DELIVER"
+	case MessageTypes.Submit:
+		return "SMS-SUBMIT"
+	case MessageTypes.StatusReport:
+		return "SMS-STATUS-REPORT"
+	}
+	return fmt.Sprintf("unknown type 0x%02X", byte(s.Type))
+}
+
+// String returns the short description of the message, e.g.
+//
+//	SMS-DELIVER from +79269965690: "crap Δ"
+func (s Message) String() string {
+	var b strings.Builder
+	b.WriteString(s.typeName())
+	switch s.Type {
+	case MessageTypes.Deliver:
+		fmt.Fprintf(&b, " from %s", s.Address)
+	case MessageTypes.Submit:
+		fmt.Fprintf(&b, " to %s", s.Address)
+	case MessageTypes.StatusReport:
+		fmt.Fprintf(&b, " for %s, reference %d, %s", s.Address, s.MessageReference, s.Status)
+		return b.String()
+	}
+	if s.UserDataHeader.TotalNumber > 0 {
+		fmt.Fprintf(&b, " (part %d of %d)", s.UserDataHeader.Sequence, s.UserDataHeader.TotalNumber)
+	}
+	if s.DCS().Alphabet == Alphabets.Data8Bit {
+		fmt.Fprintf(&b, ": %d octets of data", len(s.Data))
+	} else {
+		fmt.Fprintf(&b, ": %q", s.Text)
+	}
+	return b.String()
+}
+
+// Dump returns the annotated breakdown of the message, a field per line, similar to the online
+// PDU decoders. It's meant to debug the quirks of the carriers, the format may change.
+// See ExplainPDU for the offsets and the raw octets of the fields.
+func (s *Message) Dump() string {
+	var b strings.Builder
+	line := func(name, format string, args ...interface{}) {
+		fmt.Fprintf(&b, "%-22s "+format+"\n", append([]interface{}{name + ":"}, args...)...)
+	}
+
+	line("Type", "%s", s.typeName())
+	if s.ServiceCenterAddress != "" {
+		if s.ServiceCenterType != 0 {
+			line("Service center", "%s (type-of-address 0x%02X)", s.ServiceCenterAddress, s.ServiceCenterType)
+		} else {
+			line("Service center", "%s", s.ServiceCenterAddress)
+		}
+	} else {
+		line("Service center", "default")
+	}
+	switch s.Type {
+	case MessageTypes.Deliver:
+		line("Originating address", "%s", s.Address)
+	case MessageTypes.Submit:
+		line("Destination address", "%s", s.Address)
+	case MessageTypes.StatusReport:
+		line("Recipient address", "%s", s.Address)
+	}
+	line("Flags", "%s", strings.Join(s.flags(), ", "))
+	if s.Type != MessageTypes.Deliver {
+		line("Message reference", "%d", s.MessageReference)
+	}
+
+	if s.Type == MessageTypes.StatusReport {
+		line("Service center time", "%s", formatTimestamp(s.ServiceCenterTime))
+		line("Discharge time", "%s", formatTimestamp(s.DischargeTime))
+		line("Status", "%s", s.Status)
+		return b.String()
+	}
+
+	line("Protocol identifier", "0x%02X", byte(s.ProtocolIdentifier))
+	line("Data coding scheme", "0x%02X (%s)", byte(s.Encoding), describeDCS(s.Encoding.DCS()))
+	switch s.Type {
+	case MessageTypes.Deliver:
+		line("Service center time", "%s", formatTimestamp(s.ServiceCenterTime))
+	case MessageTypes.Submit:
+		switch s.VPFormat {
+		case ValidityPeriodFormats.FieldNotPresent:
+			line("Validity period", "not present")
+		case ValidityPeriodFormats.Relative:
+			line("Validity period", "relative, %v", time.Duration(s.VP))
+		case ValidityPeriodFormats.Absolute:
+			line("Validity period", "absolute, %s", formatTimestamp(s.VPTime))
+		case ValidityPeriodFormats.Enhanced:
+			line("Validity period", "enhanced")
+		}
+	}
+
+	if s.UserDataStartsWithHeader {
+		s.dumpUserDataHeader(line)
+	}
+	for _, mwi := range s.MessageWaiting {
+		state := "inactive"
+		if mwi.Active {
+			state = "active"
+		}
+		if mwi.Count > 0 {
+			state += fmt.Sprintf(", %d waiting", mwi.Count)
+		}
+		line("Message waiting", "%s, %s", indicationTypeNames[mwi.Type], state)
+	}
+	for _, obj := range s.EMS {
+		line("EMS object", "%s at %d", ieiNames[obj.Type], obj.Position)
+	}
+	if s.Email != nil {
+		line("Email address", "%s", s.Email.Address)
+		if s.Email.Subject != "" {
+			line("Email subject", "%q", s.Email.Subject)
+		}
+	}
+
+	if s.DCS().Alphabet == Alphabets.Data8Bit {
+		line("Data", "% X (%d octets)", s.Data, len(s.Data))
+	} else {
+		line("Text", "%q (%d characters)", s.Text, utf8.RuneCountInString(s.Text))
+	}
+	return b.String()
+}
+
+// flags returns the names of the set flags of the first octet.
+func (s *Message) flags() []string {
+	var flags []string
+	add := func(set bool, name string) {
+		if set {
+			flags = append(flags, name)
+		}
+	}
+	switch s.Type {
+	case MessageTypes.Deliver:
+		add(s.MoreMessagesToSend, "TP-MMS (more messages to send)")
+		add(s.LoopPrevention, "TP-LP (loop prevention)")
+		add(s.StatusReportIndication, "TP-SRI (status report indication)")
+	case MessageTypes.Submit:
+		add(s.RejectDuplicates, "TP-RD (reject duplicates)")
+		add(s.StatusReportRequest, "TP-SRR (status report request)")
+	case MessageTypes.StatusReport:
+		add(s.MoreMessagesToSend, "TP-MMS (more messages to send)")
+		add(s.LoopPrevention, "TP-LP (loop prevention)")
+		add(s.StatusReportQualificator, "TP-SRQ (status report qualifier)")
+	}
+	add(s.UserDataStartsWithHeader, "TP-UDHI (user data header)")
+	add(s.ReplyPathExists, "TP-RP (reply path)")
+	if len(flags) == 0 {
+		return []string{"none"}
+	}
+	return flags
+}
+
+func (s *Message) dumpUserDataHeader(line func(name, format string, args ...interface{})) {
+	udh := &s.UserDataHeader
+	if udh.TotalNumber > 0 {
+		line("Concatenation", "part %d of %d, reference %d", udh.Sequence, udh.TotalNumber, udh.Tag)
+	}
+	if udh.DestinationPort != 0 || udh.SourcePort != 0 {
+		line("Application ports", "destination %d, source %d", udh.DestinationPort, udh.SourcePort)
+	}
+	for _, ie := range udh.InformationElements() {
+		name, ok := ieiNames[ie.ID]
+		if !ok {
+			name = "unknown"
+		}
+		line("User data header", "IE 0x%02X %s: % X", byte(ie.ID), name, ie.Data)
+	}
+}
+
+// describeDCS returns the description of the data coding scheme, e.g. "general, GSM 7-bit, no class".
+func describeDCS(dcs DCS) string {
+	parts := []string{codingGroupNames[dcs.Group], alphabetNames[dcs.Alphabet]}
+	if dcs.Compressed {
+		parts = append(parts, "compressed")
+	}
+	switch dcs.Group {
+	case CodingGroups.MWIDiscard, CodingGroups.MWIStore:
+		state := "inactive"
+		if dcs.IndicationActive {
+			state = "active"
+		}
+		parts = append(parts, indicationTypeNames[dcs.IndicationType]+" "+state)
+	default:
+		parts = append(parts, messageClassNames[dcs.Class])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatTimestamp(t Timestamp) string {
+	if time.Time(t).IsZero() {
+		return "not set"
+	}
+	return time.Time(t).Format(time.RFC3339)
+}
@@ -0,0 +1,34 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestMessageDump(t *testing.T) {
+	t.Parallel()
+
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes("00440B919799674523F1000422206151457440" + "0A0605040B8423F0010203"))
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"Type:                  SMS-DELIVER\n"+
+		"Service center:        default\n"+
+		"Originating address:   +79997654321\n"+
+		"Flags:                 TP-UDHI (user data header)\n"+
+		"Protocol identifier:   0x00\n"+
+		"Data coding scheme:    0x04 (general, 8-bit data, no class)\n"+
+		"Service center time:   2022-02-16T15:54:47+01:00\n"+
+		"Application ports:     destination 2948, source 9200\n"+
+		"User data header:      IE 0x05 application port addressing, 16-bit: 0B 84 23 F0\n"+
+		"Data:                  01 02 03 (3 octets)\n", msg.Dump())
+	assert.Equal(t, "SMS-DELIVER from +79997654321: 3 octets of data", msg.String())
+
+	assert.Contains(t, smsSubmitGsm7.Dump(), "Validity period:       relative, 96h0m0s\n")
+	assert.Equal(t, `SMS-SUBMIT to +79269965690: "crap Δ"`, smsSubmitGsm7.String())
+	assert.Contains(t, smsReport.Dump(), "Status:                completed: Short message received by the SME (0x00)\n")
+	assert.Equal(t, "SMS-STATUS-REPORT for +4917600000001, reference 54, completed: Short message received by the SME (0x00)", smsReport.String())
+}
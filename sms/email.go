@@ -0,0 +1,81 @@
+package sms
+
+import "strings"
+
+// Email represents the email header of the message interworking with Internet electronic mail,
+// i.e. the one with the Email protocol identifier. The header precedes the text of the message
+// as specified in 3GPP TS 23.040, section 3.8, e.g.
+//
+//	user@example.com (Subject)Text
+type Email struct {
+	// Address is the originating address of SMS-DELIVER or the destination address of SMS-SUBMIT.
+	Address string `json:"address"`
+	// Subject is the subject of the email, it's empty if there is none.
+	Subject string `json:"subject,omitempty"`
+
+	// hashes is true if the subject is delimited with # rather than with parentheses.
+	hashes bool
+}
+
+// header returns the header as it precedes the text, the subject is delimited
+// with # if it contains a closing parenthesis.
+func (e *Email) header() string {
+	header := e.Address + " "
+	switch {
+	case e.Subject == "":
+	case e.hashes || strings.Contains(e.Subject, ")"):
+		header += "#" + e.Subject + "#"
+	default:
+		header += "(" + e.Subject + ")"
+	}
+	return header
+}
+
+// text returns the text of the message preceded by the email header if there is one.
+func (s *Message) text() string {
+	switch {
+	case s.Email == nil:
+		return s.Text
+	case s.Email.Subject == "" && s.Text == "":
+		return s.Email.Address
+	}
+	return s.Email.header() + s.Text
+}
+
+// SetEmail sets the email header of the message and the Email protocol identifier.
+func (s *Message) SetEmail(address, subject string) {
+	s.ProtocolIdentifier = ProtocolIdentifiers.Email
+	s.Email = &Email{Address: address, Subject: subject}
+}
+
+// decodeEmail takes the email header from the text of the message with the Email protocol identifier.
+// The header is recognized by the address that contains @, since it's optional in SMS-DELIVER,
+// it's expected in the first part of the concatenated message only.
+func (s *Message) decodeEmail() {
+	s.Email = nil
+	if s.ProtocolIdentifier != ProtocolIdentifiers.Email || s.DCS().Alphabet == Alphabets.Data8Bit ||
+		s.Type != MessageTypes.Deliver && s.Type != MessageTypes.Submit ||
+		s.UserDataStartsWithHeader && s.UserDataHeader.Sequence > 1 {
+		return
+	}
+	address, text := s.Text, ""
+	if i := strings.IndexByte(s.Text, ' '); i >= 0 {
+		address, text = s.Text[:i], s.Text[i+1:]
+	}
+	if !strings.Contains(address, "@") {
+		return
+	}
+	email := &Email{Address: address}
+	switch {
+	case strings.HasPrefix(text, "("):
+		if i := strings.IndexByte(text, ')'); i > 0 {
+			email.Subject, text = text[1:i], text[i+1:]
+		}
+	case strings.HasPrefix(text, "#"):
+		if i := strings.IndexByte(text[1:], '#'); i >= 0 {
+			email.Subject, text = text[1:i+1], text[i+2:]
+			email.hashes = true
+		}
+	}
+	s.Email, s.Text = email, text
+}
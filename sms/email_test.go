@@ -0,0 +1,84 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		text  string
+		email *Email
+		body  string
+	}{
+		{"user@example.com (Hello)How are you?", &Email{Address: "user@example.com", Subject: "Hello"}, "How are you?"},
+		{"user@example.com #Hi (again)#text", &Email{Address: "user@example.com", Subject: "Hi (again)", hashes: true}, "text"},
+		{"user@example.com plain text", &Email{Address: "user@example.com"}, "plain text"},
+		{"user@example.com", &Email{Address: "user@example.com"}, ""},
+		{"no address here", nil, "no address here"},
+	} {
+		msg := Message{
+			Type:               MessageTypes.Deliver,
+			Encoding:           Encodings.Gsm7Bit,
+			Address:            "1234",
+			ProtocolIdentifier: ProtocolIdentifiers.Email,
+			Text:               tc.text,
+		}
+		_, octets, err := msg.PDU()
+		require.NoError(t, err)
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err, tc.text)
+		assert.Equal(t, tc.email, parsed.Email, tc.text)
+		assert.Equal(t, tc.body, parsed.Text, tc.text)
+
+		// the header is put back when the message is encoded
+		_, reencoded, err := parsed.PDU()
+		require.NoError(t, err)
+		assert.Equal(t, octets, reencoded, tc.text)
+	}
+
+	// the email header is left as is without the Email protocol identifier
+	msg := Message{Type: MessageTypes.Deliver, Encoding: Encodings.Gsm7Bit, Address: "1234", Text: "user@example.com hi"}
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Email)
+	assert.Equal(t, msg.Text, parsed.Text)
+}
+
+func TestEmailSubmit(t *testing.T) {
+	t.Parallel()
+
+	msg, err := NewSubmit().To("1234").Email("user@example.com", "Hi").Text("hello").Build()
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolIdentifiers.Email, msg.ProtocolIdentifier)
+	msg.Text = strings.Repeat("a", 200)
+	parts, err := Split(msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Nil(t, parts[0].Email)
+	assert.True(t, strings.HasPrefix(parts[0].Text, "user@example.com (Hi)aaa"))
+
+	asm := NewAssembler(0)
+	for i := range parts {
+		_, octets, err := parts[i].PDU()
+		require.NoError(t, err)
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err)
+		joined, ok := asm.Add(&parsed)
+		if i == len(parts)-1 {
+			require.True(t, ok)
+			assert.Equal(t, &Email{Address: "user@example.com", Subject: "Hi"}, joined.Email)
+			assert.Equal(t, msg.Text, joined.Text)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package sms
+
+// TextAlignment represents the alignment of the formatted text.
+type TextAlignment byte
+
+// TextAlignments represent the possible alignments of the formatted text.
+var TextAlignments = struct {
+	Left     TextAlignment
+	Center   TextAlignment
+	Right    TextAlignment
+	Language TextAlignment // language dependent, the default
+}{
+	0x00, 0x01, 0x02, 0x03,
+}
+
+// FontSize represents the font size of the formatted text.
+type FontSize byte
+
+// FontSizes represent the possible font sizes of the formatted text.
+var FontSizes = struct {
+	Normal FontSize
+	Large  FontSize
+	Small  FontSize
+}{
+	0x00, 0x01, 0x02,
+}
+
+// TextFormat represents the formatting mode octet of the text formatting element.
+type TextFormat byte
+
+// Alignment returns the alignment of the text.
+func (f TextFormat) Alignment() TextAlignment {
+	return TextAlignment(f & 0x03)
+}
+
+// FontSize returns the font size of the text.
+func (f TextFormat) FontSize() FontSize {
+	return FontSize(f >> 2 & 0x03)
+}
+
+// Bold returns true if the text is bold.
+func (f TextFormat) Bold() bool {
+	return f&0x10 != 0
+}
+
+// Italic returns true if the text is italic.
+func (f TextFormat) Italic() bool {
+	return f&0x20 != 0
+}
+
+// Underlined returns true if the text is underlined.
+func (f TextFormat) Underlined() bool {
+	return f&0x40 != 0
+}
+
+// Strikethrough returns true if the text is struck through.
+func (f TextFormat) Strikethrough() bool {
+	return f&0x80 != 0
+}
+
+// EMSObject represents an object of the Enhanced Messaging Service conveyed by
+// an information element of the user data header (3GPP TS 23.040, section 9.2.3.24.10).
+// The fields that are set depend on the type of the object.
+type EMSObject struct {
+	// Type is one of IEIs.TextFormatting, IEIs.PredefinedSound, IEIs.UserDefinedSound,
+	// IEIs.LargePicture, IEIs.SmallPicture and IEIs.VariablePicture.
+	Type IEI `json:"type"`
+	// Position is the character position in the text the object is placed at
+	// or the formatting starts at.
+	Position int `json:"position"`
+
+	// Length is the number of the formatted characters.
+	Length int        `json:"length,omitempty"`
+	Format TextFormat `json:"format,omitempty"`
+	// Color is the optional text colour, the foreground colour is in the low nibble
+	// and the background colour is in the high one.
+	Color *byte `json:"color,omitempty"`
+
+	// Sound is the number of the predefined sound.
+	Sound int `json:"sound,omitempty"`
+	// Melody is the user defined sound in iMelody format.
+	Melody []byte `json:"melody,omitempty"`
+
+	// Width and Height are the dimensions of the picture in pixels.
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+	// Bitmap is the black and white picture, a bit per pixel row by row
+	// with the most significant bit first, the set bits are black.
+	Bitmap []byte `json:"bitmap,omitempty"`
+}
+
+// decodeEMS collects the EMS objects of the user data header in order of appearance,
+// the malformed elements are skipped.
+func (s *Message) decodeEMS() {
+	s.EMS = nil
+	for _, ie := range s.UserDataHeader.Elements {
+		if obj, ok := parseEMSObject(ie); ok {
+			s.EMS = append(s.EMS, obj)
+		}
+	}
+}
+
+func parseEMSObject(ie InformationElement) (obj EMSObject, ok bool) {
+	if len(ie.Data) < 2 {
+		return
+	}
+	obj = EMSObject{Type: ie.ID, Position: int(ie.Data[0])}
+	data := ie.Data[1:]
+	switch ie.ID {
+	case IEIs.TextFormatting:
+		if len(data) < 2 || len(data) > 3 {
+			return
+		}
+		obj.Length = int(data[0])
+		obj.Format = TextFormat(data[1])
+		if len(data) == 3 {
+			color := data[2]
+			obj.Color = &color
+		}
+	case IEIs.PredefinedSound:
+		if len(data) != 1 {
+			return
+		}
+		obj.Sound = int(data[0])
+	case IEIs.UserDefinedSound:
+		obj.Melody = append([]byte(nil), data...)
+	case IEIs.LargePicture:
+		if len(data) != 32*32/8 {
+			return
+		}
+		obj.Width, obj.Height = 32, 32
+		obj.Bitmap = append([]byte(nil), data...)
+	case IEIs.SmallPicture:
+		if len(data) != 16*16/8 {
+			return
+		}
+		obj.Width, obj.Height = 16, 16
+		obj.Bitmap = append([]byte(nil), data...)
+	case IEIs.VariablePicture:
+		// the width is given in octets and the height in pixels
+		if len(data) < 2 || len(data[2:]) != int(data[0])*int(data[1]) {
+			return
+		}
+		obj.Width, obj.Height = int(data[0])*8, int(data[1])
+		obj.Bitmap = append([]byte(nil), data[2:]...)
+	default:
+		return
+	}
+	return obj, true
+}
@@ -0,0 +1,55 @@
+package sms
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageEMS(t *testing.T) {
+	t.Parallel()
+
+	picture := bytes.Repeat([]byte{0xAA}, 32)
+	msg := Message{
+		Type:                     MessageTypes.Submit,
+		Encoding:                 Encodings.Gsm7Bit,
+		Address:                  "+79997654321",
+		VPFormat:                 ValidityPeriodFormats.Relative,
+		Text:                     "Hello world",
+		UserDataStartsWithHeader: true,
+		UserDataHeader: UserDataHeader{Elements: []InformationElement{
+			{ID: IEIs.TextFormatting, Data: []byte{0x00, 0x05, 0x10}},
+			{ID: IEIs.TextFormatting, Data: []byte{0x06, 0x05, 0x21, 0x0F}},
+			{ID: IEIs.PredefinedSound, Data: []byte{0x05, 0x03}},
+			{ID: IEIs.PredefinedSound, Data: []byte{0x05}},
+			{ID: IEIs.UserDefinedSound, Data: []byte{0x0B, 'B', 'E', 'G'}},
+			{ID: IEIs.SmallPicture, Data: append([]byte{0x00}, picture...)},
+			{ID: IEIs.VariablePicture, Data: []byte{0x0B, 0x01, 0x02, 0x18, 0x3C}},
+		}},
+	}
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world", parsed.Text)
+
+	color := byte(0x0F)
+	assert.Equal(t, []EMSObject{
+		{Type: IEIs.TextFormatting, Position: 0, Length: 5, Format: 0x10},
+		{Type: IEIs.TextFormatting, Position: 6, Length: 5, Format: 0x21, Color: &color},
+		{Type: IEIs.PredefinedSound, Position: 5, Sound: 3},
+		{Type: IEIs.UserDefinedSound, Position: 11, Melody: []byte("BEG")},
+		{Type: IEIs.SmallPicture, Width: 16, Height: 16, Bitmap: picture},
+		{Type: IEIs.VariablePicture, Position: 11, Width: 8, Height: 2, Bitmap: []byte{0x18, 0x3C}},
+	}, parsed.EMS)
+
+	format := parsed.EMS[1].Format
+	assert.Equal(t, TextAlignments.Center, format.Alignment())
+	assert.Equal(t, FontSizes.Normal, format.FontSize())
+	assert.True(t, format.Italic())
+	assert.False(t, format.Bold())
+	assert.True(t, parsed.EMS[0].Format.Bold())
+}
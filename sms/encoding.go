@@ -9,6 +9,20 @@ var Encodings = struct {
 	UCS2      Encoding
 	Gsm7Bit_2 Encoding
 	Gsm7Bit_3 Encoding
+
+	// Flash messages (class 0) are displayed immediately and not stored.
+	Gsm7BitFlash Encoding
+	UCS2Flash    Encoding
+
+	// Data8Bit carries the binary payload, see Message.Data.
+	Data8Bit Encoding
 }{
 	0x00, 0x08, 0x11, 0x01,
+	0x10, 0x18,
+	0x04,
+}
+
+// DCS decodes the encoding as the data coding scheme.
+func (e Encoding) DCS() DCS {
+	return ParseDCS(byte(e))
 }
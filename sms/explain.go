@@ -0,0 +1,298 @@
+package sms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xlab/at/util"
+)
+
+// PDUField is a field of the PDU annotated by ExplainPDU.
+type PDUField struct {
+	// Offset is the offset of the first octet of the field, counted from the start of the PDU,
+	// i.e. the SMSC information included.
+	Offset int
+	Raw    []byte
+	Name   string
+	Value  string
+}
+
+// Explanation is the breakdown of the PDU into the fields in the order of the octets.
+type Explanation []PDUField
+
+// maxExplainedRaw is the number of the octets of the field String prints.
+const maxExplainedRaw = 12
+
+// String returns the breakdown a field per line, the offset, the raw octets, the name and the value.
+func (e Explanation) String() string {
+	var b strings.Builder
+	for _, f := range e {
+		raw := util.HexString(f.Raw)
+		if len(f.Raw) > maxExplainedRaw {
+			raw = util.HexString(f.Raw[:maxExplainedRaw]) + "…"
+		}
+		fmt.Fprintf(&b, "%3d  %-25s %-36s %s\n", f.Offset, raw, f.Name+":", f.Value)
+	}
+	return b.String()
+}
+
+// ExplainPDU returns the annotated breakdown of the PDU, every field with its offset and raw octets,
+// similar to the online PDU decoders. It's meant to find out why a PDU is decoded the way it is,
+// so the message is decoded in lenient mode and the error of ReadFromMode is returned along with
+// the fields up to the truncated one, if any. The values are meant for humans, the format may change.
+func ExplainPDU(octets []byte) (Explanation, error) {
+	var msg Message
+	_, _, err := msg.ReadFromMode(octets, DecodeModes.Lenient)
+	e := explainer{octets: octets, msg: &msg}
+	e.explain()
+	return e.fields, err
+}
+
+// explainer walks the fields of the PDU, the values are taken from the decoded message,
+// while the lengths are taken from the octets, since the message might be decoded partially.
+type explainer struct {
+	octets []byte
+	off    int
+	msg    *Message
+	fields Explanation
+
+	first Message // the flags of the first octet
+	dcs   DCS
+}
+
+// field adds the field of the next n octets, false is returned if there are fewer of them.
+func (e *explainer) field(name string, n int, value string) bool {
+	raw := e.octets[e.off:]
+	ok := n <= len(raw)
+	if ok {
+		raw = raw[:n]
+	} else {
+		value = fmt.Sprintf("truncated, %d of %d octets", len(raw), n)
+	}
+	if n > 0 {
+		e.fields = append(e.fields, PDUField{Offset: e.off, Raw: raw, Name: name, Value: value})
+	}
+	e.off += len(raw)
+	return ok
+}
+
+// next returns the next octet, ok is false if there are no octets left.
+func (e *explainer) next() (b byte, ok bool) {
+	if e.off >= len(e.octets) {
+		return 0, false
+	}
+	return e.octets[e.off], true
+}
+
+func (e *explainer) explain() {
+	s := e.msg
+	scLen, ok := e.next()
+	if !ok {
+		return
+	}
+	if scLen == 0 {
+		e.field("SMSC information length", 1, "0 (the default service center)")
+	} else if !e.field("SMSC information length", 1, fmt.Sprintf("%d octets", scLen)) ||
+		!e.field("SMSC type-of-address", 1, fmt.Sprintf("0x%02X", s.ServiceCenterType)) ||
+		!e.field("SMSC address", int(scLen)-1, string(s.ServiceCenterAddress)) {
+		return
+	}
+
+	first, ok := e.next()
+	if !ok {
+		return
+	}
+	e.first = firstOctet(first)
+	parts := []string{e.first.typeName()}
+	if flags := e.first.flags(); flags[0] != "none" {
+		parts = append(parts, flags...)
+	}
+	if e.first.Type == MessageTypes.Submit {
+		vpf := vpFormatNames[e.first.VPFormat]
+		if vpf == "" {
+			vpf = "not present"
+		}
+		parts = append(parts, "TP-VPF "+vpf)
+	}
+	e.field("First octet", 1, strings.Join(parts, ", "))
+
+	switch e.first.Type {
+	case MessageTypes.Deliver:
+		_ = e.address("TP-OA", "originating address", s.Address) &&
+			e.header() &&
+			e.timestamp("TP-SCTS (service centre time stamp)", s.ServiceCenterTime) &&
+			e.userData()
+	case MessageTypes.Submit:
+		_ = e.field("TP-MR (message reference)", 1, fmt.Sprint(s.MessageReference)) &&
+			e.address("TP-DA", "destination address", s.Address) &&
+			e.header() &&
+			e.validityPeriod() &&
+			e.userData()
+	case MessageTypes.StatusReport:
+		_ = e.field("TP-MR (message reference)", 1, fmt.Sprint(s.MessageReference)) &&
+			e.address("TP-RA", "recipient address", s.Address) &&
+			e.timestamp("TP-SCTS (service centre time stamp)", s.ServiceCenterTime) &&
+			e.timestamp("TP-DT (discharge time)", s.DischargeTime) &&
+			e.field("TP-ST (status)", 1, s.Status.String()) &&
+			e.parameters()
+	default:
+		return
+	}
+	if e.off < len(e.octets) {
+		e.field("Trailing octets", len(e.octets)-e.off, "not a part of the TPDU")
+	}
+}
+
+// firstOctet returns the message with the type and the flags of the first octet of the TPDU.
+func firstOctet(octet byte) Message {
+	s := Message{
+		Type:                     MessageType(octet & 0x03),
+		UserDataStartsWithHeader: octet&0x40 != 0,
+	}
+	switch s.Type {
+	case MessageTypes.Submit:
+		s.RejectDuplicates = octet&0x04 != 0
+		s.VPFormat = ValidityPeriodFormat(octet >> 3 & 0x03)
+		s.StatusReportRequest = octet&0x20 != 0
+		s.ReplyPathExists = octet&0x80 != 0
+	case MessageTypes.Deliver:
+		s.MoreMessagesToSend = octet&0x04 == 0
+		s.LoopPrevention = octet&0x08 != 0
+		s.StatusReportIndication = octet&0x20 != 0
+		s.ReplyPathExists = octet&0x80 != 0
+	case MessageTypes.StatusReport:
+		s.MoreMessagesToSend = octet&0x04 == 0
+		s.LoopPrevention = octet&0x08 != 0
+		s.StatusReportQualificator = octet&0x20 != 0
+	}
+	return s
+}
+
+// address adds the length, the type-of-address and the digits of the address field.
+func (e *explainer) address(abbr, name string, addr PhoneNumber) bool {
+	semiOctets, ok := e.next()
+	if !ok || !e.field(abbr+" length", 1, fmt.Sprintf("%d semi-octets", semiOctets)) {
+		return false
+	}
+	typ, ok := e.next()
+	return ok && e.field(abbr+" type-of-address", 1, fmt.Sprintf("0x%02X", typ)) &&
+		e.field(abbr+" ("+name+")", blocks(int(semiOctets), 2), string(addr))
+}
+
+// header adds the protocol identifier and the data coding scheme.
+func (e *explainer) header() bool {
+	if !e.field("TP-PID (protocol identifier)", 1, fmt.Sprintf("0x%02X", byte(e.msg.ProtocolIdentifier))) {
+		return false
+	}
+	return e.dataCodingScheme()
+}
+
+func (e *explainer) dataCodingScheme() bool {
+	dcs, ok := e.next()
+	e.dcs = ParseDCS(dcs)
+	return ok && e.field("TP-DCS (data coding scheme)", 1, describeDCS(e.dcs))
+}
+
+func (e *explainer) timestamp(name string, t Timestamp) bool {
+	return e.field(name, 7, formatTimestamp(t))
+}
+
+func (e *explainer) validityPeriod() bool {
+	s := e.msg
+	switch e.first.VPFormat {
+	case ValidityPeriodFormats.Relative:
+		return e.field("TP-VP (validity period)", 1, fmt.Sprintf("relative, %v", time.Duration(s.VP)))
+	case ValidityPeriodFormats.Absolute:
+		return e.field("TP-VP (validity period)", 7, "absolute, "+formatTimestamp(s.VPTime))
+	case ValidityPeriodFormats.Enhanced:
+		return e.field("TP-VP (validity period)", 7, "enhanced")
+	}
+	return true
+}
+
+// parameters adds the parameter indicator of the status report and the parameters it selects.
+func (e *explainer) parameters() bool {
+	pi, ok := e.next()
+	if !ok {
+		// the parameter indicator is optional
+		return true
+	}
+	if !e.field("TP-PI (parameter indicator)", 1, fmt.Sprintf("0x%02X", pi)) {
+		return false
+	}
+	for ext := pi; ext&0x80 != 0; {
+		if ext, ok = e.next(); !ok {
+			return false
+		}
+		e.field("TP-PI extension", 1, fmt.Sprintf("0x%02X", ext))
+	}
+	if pi&0x01 != 0 &&
+		!e.field("TP-PID (protocol identifier)", 1, fmt.Sprintf("0x%02X", byte(e.msg.ProtocolIdentifier))) {
+		return false
+	}
+	if pi&0x02 != 0 && !e.dataCodingScheme() {
+		return false
+	}
+	return pi&0x04 == 0 || e.userData()
+}
+
+// userData adds the user data length, the user data header and the text or the data.
+func (e *explainer) userData() bool {
+	udl, ok := e.next()
+	if !ok {
+		return false
+	}
+	n, unit := int(udl), "octets"
+	if e.dcs.Alphabet == Alphabets.Gsm7Bit && !e.dcs.Compressed {
+		n, unit = blocks(int(udl)*7, 8), "septets"
+	}
+	if !e.field("TP-UDL (user data length)", 1, fmt.Sprintf("%d %s", udl, unit)) {
+		return false
+	}
+	if e.first.UserDataStartsWithHeader {
+		udhl, ok := e.next()
+		if !ok {
+			return false
+		}
+		if !e.field("TP-UDH (user data header)", int(udhl)+1, e.describeHeader()) {
+			return false
+		}
+		n -= int(udhl) + 1
+	}
+	if n < 0 {
+		n = 0
+	}
+	// the user data shorter than UDL is decoded anyway
+	if rest := len(e.octets) - e.off; n > rest && rest > 0 {
+		return e.field("TP-UD (user data)", rest, e.describeUserData()+fmt.Sprintf(", %d of %d octets", rest, n))
+	}
+	return e.field("TP-UD (user data)", n, e.describeUserData())
+}
+
+func (e *explainer) describeHeader() string {
+	if !e.msg.UserDataStartsWithHeader {
+		return "malformed, decoded as a part of the user data"
+	}
+	ies := e.msg.UserDataHeader.InformationElements()
+	names := make([]string, 0, len(ies))
+	for _, ie := range ies {
+		name, ok := ieiNames[ie.ID]
+		if !ok {
+			name = "unknown"
+		}
+		names = append(names, fmt.Sprintf("IE 0x%02X %s", byte(ie.ID), name))
+	}
+	if len(names) == 0 {
+		return "no information elements"
+	}
+	return strings.Join(names, "; ")
+}
+
+func (e *explainer) describeUserData() string {
+	s := e.msg
+	if s.DCS().Alphabet == Alphabets.Data8Bit || s.Text == "" && len(s.Data) > 0 {
+		return fmt.Sprintf("%d octets of data", len(s.Data))
+	}
+	return fmt.Sprintf("%q", s.Text)
+}
@@ -0,0 +1,49 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestExplainPDU(t *testing.T) {
+	t.Parallel()
+
+	explanation, err := ExplainPDU(util.MustBytes("00440B919799674523F1000422206151457440" + "0A0605040B8423F0010203"))
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"  0  00                        SMSC information length:             0 (the default service center)\n"+
+		"  1  44                        First octet:                         SMS-DELIVER, TP-UDHI (user data header)\n"+
+		"  2  0B                        TP-OA length:                        11 semi-octets\n"+
+		"  3  91                        TP-OA type-of-address:               0x91\n"+
+		"  4  9799674523F1              TP-OA (originating address):         +79997654321\n"+
+		" 10  00                        TP-PID (protocol identifier):        0x00\n"+
+		" 11  04                        TP-DCS (data coding scheme):         general, 8-bit data, no class\n"+
+		" 12  22206151457440            TP-SCTS (service centre time stamp): 2022-02-16T15:54:47+01:00\n"+
+		" 19  0A                        TP-UDL (user data length):           10 octets\n"+
+		" 20  0605040B8423F0            TP-UDH (user data header):           IE 0x05 application port addressing, 16-bit\n"+
+		" 27  010203                    TP-UD (user data):                   3 octets of data\n", explanation.String())
+
+	_, octets, err := smsSubmitGsm7.PDU()
+	require.NoError(t, err)
+	explanation, err = ExplainPDU(append(octets, 0xFF))
+	require.NoError(t, err)
+	require.Len(t, explanation, 14)
+	assert.Equal(t, PDUField{Offset: 1, Raw: []byte{0x91}, Name: "SMSC type-of-address", Value: "0x91"}, explanation[1])
+	assert.Equal(t, "SMS-SUBMIT, TP-VPF relative", explanation[3].Value)
+	assert.Equal(t, PDUField{Offset: 20, Raw: []byte{0xAA}, Name: "TP-VP (validity period)", Value: "relative, 96h0m0s"},
+		explanation[10])
+	assert.Equal(t, `"crap Δ"`, explanation[12].Value)
+	assert.Equal(t, PDUField{Offset: 28, Raw: []byte{0xFF}, Name: "Trailing octets", Value: "not a part of the TPDU"},
+		explanation[13])
+
+	// the fields are explained up to the truncated one
+	explanation, err = ExplainPDU(util.MustBytes("00440B919799674523F100042220"))
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.Len(t, explanation, 8)
+	assert.Equal(t, PDUField{Offset: 12, Raw: []byte{0x22, 0x20}, Name: "TP-SCTS (service centre time stamp)",
+		Value: "truncated, 2 of 7 octets"}, explanation[7])
+}
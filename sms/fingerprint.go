@@ -0,0 +1,27 @@
+package sms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Fingerprint returns the stable hash of the message that identifies it among the received ones,
+// e.g. to drop the duplicates or to store the messages idempotently. It's the hex-encoded hash of
+// the type, the address, the service center time stamp and the concatenation reference, number
+// of parts and sequence number. The message reference is taken into account for the messages
+// other than SMS-DELIVER. The contents of the user data don't affect the fingerprint, so it's
+// the same for the message decoded with different modes or received in text mode.
+func (s *Message) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d|%s|%d", byte(s.Type), s.Address, time.Time(s.ServiceCenterTime).Unix())
+	if s.Type != MessageTypes.Deliver {
+		fmt.Fprintf(h, "|%d", s.MessageReference)
+	}
+	if s.UserDataStartsWithHeader && s.UserDataHeader.TotalNumber > 0 {
+		udh := s.UserDataHeader
+		fmt.Fprintf(h, "|%d|%d|%d", udh.Tag, udh.TotalNumber, udh.Sequence)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
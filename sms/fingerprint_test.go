@@ -0,0 +1,52 @@
+package sms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	scts := time.Date(2020, 5, 18, 12, 0, 0, 0, time.FixedZone("", 3*60*60))
+	msg := Message{
+		Type:              MessageTypes.Deliver,
+		Address:           "+79997654321",
+		ServiceCenterTime: Timestamp(scts),
+		Text:              "hi",
+	}
+	fp := msg.Fingerprint()
+	assert.Len(t, fp, 32)
+	assert.Equal(t, fp, msg.Fingerprint())
+
+	// the same instant in another zone and other contents
+	same := msg
+	same.ServiceCenterTime = Timestamp(scts.UTC())
+	same.Text = "hello"
+	assert.Equal(t, fp, same.Fingerprint())
+
+	other := msg
+	other.Address = "+79991234567"
+	assert.NotEqual(t, fp, other.Fingerprint())
+	other = msg
+	other.ServiceCenterTime = Timestamp(scts.Add(time.Second))
+	assert.NotEqual(t, fp, other.Fingerprint())
+
+	part := msg
+	part.UserDataStartsWithHeader = true
+	part.UserDataHeader = UserDataHeader{TotalNumber: 2, Sequence: 1, Tag: 7}
+	assert.NotEqual(t, fp, part.Fingerprint())
+	next := part
+	next.UserDataHeader.Sequence = 2
+	assert.NotEqual(t, part.Fingerprint(), next.Fingerprint())
+	next = part
+	next.UserDataHeader.Tag = 8
+	assert.NotEqual(t, part.Fingerprint(), next.Fingerprint())
+
+	report := Message{Type: MessageTypes.StatusReport, Address: msg.Address, MessageReference: 1}
+	another := report
+	another.MessageReference = 2
+	assert.NotEqual(t, report.Fingerprint(), another.Fingerprint())
+}
@@ -0,0 +1,332 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownJSONValue is returned when a JSON value can't be mapped onto the field.
+var ErrUnknownJSONValue = errors.New("sms: unknown JSON value")
+
+var jsonNull = []byte("null")
+
+// jsonMessage is the JSON representation of Message. The fields that don't apply to the
+// message are omitted, e.g. the timestamps aren't set in SMS-SUBMIT, and the enumerations
+// are represented by their names.
+type jsonMessage struct {
+	Type                     string             `json:"type"`
+	Encoding                 Encoding           `json:"encoding"`
+	ProtocolIdentifier       ProtocolIdentifier `json:"protocol_identifier,omitempty"`
+	VPFormat                 string             `json:"vp_format,omitempty"`
+	VP                       ValidityPeriod     `json:"vp,omitempty"`
+	VPTime                   *Timestamp         `json:"vp_time,omitempty"`
+	ServiceCenterTime        *Timestamp         `json:"service_center_time,omitempty"`
+	DischargeTime            *Timestamp         `json:"discharge_time,omitempty"`
+	ServiceCenterAddress     PhoneNumber        `json:"service_center_address,omitempty"`
+	ServiceCenterType        byte               `json:"service_center_type,omitempty"`
+	Address                  PhoneNumber        `json:"address"`
+	Text                     string             `json:"text,omitempty"`
+	Data                     []byte             `json:"data,omitempty"`
+	Latin1                   bool               `json:"latin1,omitempty"`
+	UserDataHeader           *UserDataHeader    `json:"user_data_header,omitempty"`
+	MessageWaiting           []MessageWaiting   `json:"message_waiting,omitempty"`
+	EMS                      []EMSObject        `json:"ems,omitempty"`
+	Email                    *Email             `json:"email,omitempty"`
+	MessageReference         byte               `json:"message_reference"`
+	Status                   *Status            `json:"status,omitempty"`
+	ReplyPathExists          bool               `json:"reply_path,omitempty"`
+	StatusReportIndication   bool               `json:"status_report_indication,omitempty"`
+	StatusReportRequest      bool               `json:"status_report_request,omitempty"`
+	StatusReportQualificator bool               `json:"status_report_qualificator,omitempty"`
+	MoreMessagesToSend       bool               `json:"more_messages_to_send,omitempty"`
+	LoopPrevention           bool               `json:"loop_prevention,omitempty"`
+	RejectDuplicates         bool               `json:"reject_duplicates,omitempty"`
+}
+
+var messageTypeNames = map[MessageType]string{
+	MessageTypes.Deliver:      "deliver",
+	MessageTypes.Submit:       "submit",
+	MessageTypes.StatusReport: "status_report",
+}
+
+var vpFormatNames = map[ValidityPeriodFormat]string{
+	ValidityPeriodFormats.FieldNotPresent: "",
+	ValidityPeriodFormats.Relative:        "relative",
+	ValidityPeriodFormats.Enhanced:        "enhanced",
+	ValidityPeriodFormats.Absolute:        "absolute",
+}
+
+// timestampOrNil returns nil for the zero timestamp so it's omitted.
+func timestampOrNil(t Timestamp) *Timestamp {
+	if time.Time(t).IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Message) MarshalJSON() ([]byte, error) {
+	typ, ok := messageTypeNames[s.Type]
+	if !ok {
+		return nil, ErrUnknownMessageType
+	}
+	m := jsonMessage{
+		Type:                     typ,
+		Encoding:                 s.Encoding,
+		ProtocolIdentifier:       s.ProtocolIdentifier,
+		VPFormat:                 vpFormatNames[s.VPFormat],
+		VPTime:                   timestampOrNil(s.VPTime),
+		ServiceCenterTime:        timestampOrNil(s.ServiceCenterTime),
+		DischargeTime:            timestampOrNil(s.DischargeTime),
+		ServiceCenterAddress:     s.ServiceCenterAddress,
+		ServiceCenterType:        s.ServiceCenterType,
+		Address:                  s.Address,
+		Text:                     s.Text,
+		Data:                     s.Data,
+		Latin1:                   s.Latin1,
+		MessageWaiting:           s.MessageWaiting,
+		EMS:                      s.EMS,
+		Email:                    s.Email,
+		MessageReference:         s.MessageReference,
+		ReplyPathExists:          s.ReplyPathExists,
+		StatusReportIndication:   s.StatusReportIndication,
+		StatusReportRequest:      s.StatusReportRequest,
+		StatusReportQualificator: s.StatusReportQualificator,
+		MoreMessagesToSend:       s.MoreMessagesToSend,
+		LoopPrevention:           s.LoopPrevention,
+		RejectDuplicates:         s.RejectDuplicates,
+	}
+	if s.VPFormat == ValidityPeriodFormats.Relative {
+		m.VP = s.VP
+	}
+	if s.UserDataStartsWithHeader {
+		udh := s.UserDataHeader
+		m.UserDataHeader = &udh
+	}
+	if s.Type == MessageTypes.StatusReport {
+		status := s.Status
+		m.Status = &status
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Message) UnmarshalJSON(data []byte) error {
+	var m jsonMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	var msgType MessageType
+	ok := false
+	for t, name := range messageTypeNames {
+		if name == m.Type {
+			msgType, ok = t, true
+		}
+	}
+	if !ok {
+		return ErrUnknownMessageType
+	}
+	var vpFormat ValidityPeriodFormat
+	ok = false
+	for f, name := range vpFormatNames {
+		if name == m.VPFormat {
+			vpFormat, ok = f, true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%w: vp_format %q", ErrUnknownJSONValue, m.VPFormat)
+	}
+	*s = Message{
+		Type:                     msgType,
+		Encoding:                 m.Encoding,
+		ProtocolIdentifier:       m.ProtocolIdentifier,
+		VPFormat:                 vpFormat,
+		VP:                       m.VP,
+		ServiceCenterAddress:     m.ServiceCenterAddress,
+		ServiceCenterType:        m.ServiceCenterType,
+		Address:                  m.Address,
+		Text:                     m.Text,
+		Data:                     m.Data,
+		Latin1:                   m.Latin1,
+		MessageWaiting:           m.MessageWaiting,
+		EMS:                      m.EMS,
+		Email:                    m.Email,
+		MessageReference:         m.MessageReference,
+		ReplyPathExists:          m.ReplyPathExists,
+		StatusReportIndication:   m.StatusReportIndication,
+		StatusReportRequest:      m.StatusReportRequest,
+		StatusReportQualificator: m.StatusReportQualificator,
+		MoreMessagesToSend:       m.MoreMessagesToSend,
+		LoopPrevention:           m.LoopPrevention,
+		RejectDuplicates:         m.RejectDuplicates,
+	}
+	if m.VPTime != nil {
+		s.VPTime = *m.VPTime
+	}
+	if m.ServiceCenterTime != nil {
+		s.ServiceCenterTime = *m.ServiceCenterTime
+	}
+	if m.DischargeTime != nil {
+		s.DischargeTime = *m.DischargeTime
+	}
+	if m.UserDataHeader != nil {
+		s.UserDataStartsWithHeader = true
+		s.UserDataHeader = *m.UserDataHeader
+	}
+	if m.Status != nil {
+		s.Status = *m.Status
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the timestamp is represented in RFC 3339 format
+// with its time zone and the zero timestamp is null.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if time.Time(t).IsZero() {
+		return jsonNull, nil
+	}
+	return json.Marshal(time.Time(t).Format(time.RFC3339))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, jsonNull) {
+		*t = Timestamp{}
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	date, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return err
+	}
+	*t = Timestamp(date)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PhoneNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the numbers without
+// the leading plus sign are accepted as JSON numbers as well.
+func (p *PhoneNumber) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+		*p = PhoneNumber(number)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	*p = PhoneNumber(str)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the validity period
+// is represented as a duration string, e.g. "24h0m0s".
+func (v ValidityPeriod) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(v).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the number of seconds is accepted as well.
+func (v *ValidityPeriod) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		var seconds int64
+		if err := json.Unmarshal(data, &seconds); err != nil {
+			return err
+		}
+		*v = ValidityPeriod(time.Duration(seconds) * time.Second)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*v = ValidityPeriod(d)
+	return nil
+}
+
+var statusNames = map[Status]string{
+	StatusCodes.CompletedReceived: "completed_received",
+	StatusCodes.CompletedForwared: "completed_forwarded",
+	StatusCodes.CompletedReplaced: "completed_replaced",
+
+	StatusCodes.TemporaryCongestion:                   "temporary_congestion",
+	StatusCodes.TemporaryBusy:                         "temporary_busy",
+	StatusCodes.TemporaryNoResponseFromRecipient:      "temporary_no_response_from_recipient",
+	StatusCodes.TemporaryServiceRejected:              "temporary_service_rejected",
+	StatusCodes.TemporaryQualityOfServiceNotAvailable: "temporary_quality_of_service_not_available",
+	StatusCodes.TemporaryErrorInRecipient:             "temporary_error_in_recipient",
+
+	StatusCodes.PermanentRemoteProcedureError:         "permanent_remote_procedure_error",
+	StatusCodes.PermanentIncompatibleDestination:      "permanent_incompatible_destination",
+	StatusCodes.PermanentConnectionRejected:           "permanent_connection_rejected",
+	StatusCodes.PermanentNotObtainable:                "permanent_not_obtainable",
+	StatusCodes.PermanentQualityOfServiceNotAvailable: "permanent_quality_of_service_not_available",
+	StatusCodes.PermanentNoInterworkingAvailable:      "permanent_no_interworking_available",
+	StatusCodes.PermanentValidityPeriodExpired:        "permanent_validity_period_expired",
+	StatusCodes.PermanentDeletedBeSender:              "permanent_deleted_by_sender",
+	StatusCodes.PermanentDeletedByAdministration:      "permanent_deleted_by_administration",
+	StatusCodes.PermanentUnknownMessage:               "permanent_unknown_message",
+
+	StatusCodes.FinalCongestion:                   "final_congestion",
+	StatusCodes.FinalBusy:                         "final_busy",
+	StatusCodes.FinalNoResponseFromRecipient:      "final_no_response_from_recipient",
+	StatusCodes.FinalServiceRejected:              "final_service_rejected",
+	StatusCodes.FinalQualityOfServiceNotAvailable: "final_quality_of_service_not_available",
+	StatusCodes.FinalErrorInRecipient:             "final_error_in_recipient",
+}
+
+// MarshalJSON implements json.Marshaler, the status is represented by its name,
+// e.g. "completed_received", the reserved and SC-specific ones are represented
+// by their hexadecimal codes, e.g. "0x10".
+func (s Status) MarshalJSON() ([]byte, error) {
+	if name, ok := statusNames[s]; ok {
+		return json.Marshal(name)
+	}
+	return json.Marshal(fmt.Sprintf("0x%02X", byte(s)))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the numeric codes are accepted as well.
+func (s *Status) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		var code byte
+		if err := json.Unmarshal(data, &code); err != nil {
+			return err
+		}
+		*s = Status(code)
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	for status, name := range statusNames {
+		if name == str {
+			*s = status
+			return nil
+		}
+	}
+	code, err := strconv.ParseUint(str, 0, 8)
+	if err != nil {
+		return fmt.Errorf("%w: status %q", ErrUnknownJSONValue, str)
+	}
+	*s = Status(code)
+	return nil
+}
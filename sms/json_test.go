@@ -0,0 +1,112 @@
+package sms
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestMessageJSON(t *testing.T) {
+	t.Parallel()
+
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes("00440B919799674523F1000422206151457440" + "0A0605040B8423F0010203"))
+	require.NoError(t, err)
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "deliver",
+		"encoding": 4,
+		"service_center_time": "2022-02-16T15:54:47+01:00",
+		"address": "+79997654321",
+		"data": "AQID",
+		"user_data_header": {"destination_port": 2948, "source_port": 9200},
+		"message_reference": 0
+	}`, string(data))
+
+	var parsed Message
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, msg.Data, parsed.Data)
+	assert.Equal(t, msg.UserDataHeader, parsed.UserDataHeader)
+	assert.True(t, parsed.UserDataStartsWithHeader)
+	assert.True(t, time.Time(msg.ServiceCenterTime).Equal(time.Time(parsed.ServiceCenterTime)))
+
+	report := Message{
+		Type:             MessageTypes.StatusReport,
+		Address:          "+79997654321",
+		MessageReference: 5,
+		Status:           StatusCodes.TemporaryQualityOfServiceNotAvailable,
+	}
+	data, err = json.Marshal(&report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "status_report",
+		"encoding": 0,
+		"address": "+79997654321",
+		"message_reference": 5,
+		"status": "temporary_quality_of_service_not_available"
+	}`, string(data))
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, report, parsed)
+
+	submit := Message{
+		Type:     MessageTypes.Submit,
+		Address:  "79997654321",
+		Text:     "hi",
+		VPFormat: ValidityPeriodFormats.Relative,
+		VP:       ValidityPeriod(24 * time.Hour),
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "submit",
+		"encoding": 0,
+		"address": 79997654321,
+		"text": "hi",
+		"vp_format": "relative",
+		"vp": 86400,
+		"message_reference": 0
+	}`), &parsed))
+	assert.Equal(t, submit, parsed)
+	data, err = json.Marshal(submit)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"vp":"24h0m0s"`)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"type":"command"}`), &parsed))
+	assert.Error(t, json.Unmarshal([]byte(`{"type":"submit","vp_format":"forever"}`), &parsed))
+}
+
+func TestStatusJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(Status(0x10))
+	require.NoError(t, err)
+	assert.Equal(t, `"0x10"`, string(data))
+
+	var status Status
+	require.NoError(t, json.Unmarshal(data, &status))
+	assert.Equal(t, Status(0x10), status)
+	require.NoError(t, json.Unmarshal([]byte(`"permanent_validity_period_expired"`), &status))
+	assert.Equal(t, StatusCodes.PermanentValidityPeriodExpired, status)
+	require.NoError(t, json.Unmarshal([]byte(`2`), &status))
+	assert.Equal(t, StatusCodes.CompletedReplaced, status)
+	assert.Error(t, json.Unmarshal([]byte(`"lost"`), &status))
+}
+
+func TestTimestampJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(Timestamp{})
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+
+	ts := Timestamp(time.Date(2020, 5, 18, 12, 0, 0, 0, time.FixedZone("", 3*3600)))
+	data, err = json.Marshal(ts)
+	require.NoError(t, err)
+	assert.Equal(t, `"2020-05-18T12:00:00+03:00"`, string(data))
+	var parsed Timestamp
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.True(t, time.Time(ts).Equal(time.Time(parsed)))
+}
@@ -0,0 +1,41 @@
+package sms
+
+// MessageWaiting represents a message waiting indication, e.g. the voicemail notification.
+// The indication is conveyed either by the message waiting indication groups of the data
+// coding scheme (3GPP TS 23.038, section 4) or by the special SMS message indication element
+// of the user data header (3GPP TS 23.040, section 9.2.3.24.2).
+type MessageWaiting struct {
+	Type IndicationType `json:"type"`
+	// Active is true if there are messages waiting, false clears the indication.
+	Active bool `json:"active"`
+	// Count is the number of waiting messages, it's known from the user data header only.
+	Count int `json:"count,omitempty"`
+	// Store is true if the message carrying the indication is to be stored,
+	// it may be discarded otherwise.
+	Store bool `json:"store,omitempty"`
+}
+
+// decodeMessageWaiting collects the message waiting indications of the message,
+// the ones of the user data header follow the one of the data coding scheme.
+func (s *Message) decodeMessageWaiting() {
+	s.MessageWaiting = nil
+	dcs := s.Encoding.DCS()
+	if dcs.Group == CodingGroups.MWIDiscard || dcs.Group == CodingGroups.MWIStore {
+		s.MessageWaiting = append(s.MessageWaiting, MessageWaiting{
+			Type:   dcs.IndicationType,
+			Active: dcs.IndicationActive,
+			Store:  dcs.Group == CodingGroups.MWIStore,
+		})
+	}
+	for _, ie := range s.UserDataHeader.Elements {
+		if ie.ID != IEIs.SpecialMessage || len(ie.Data) != 2 {
+			continue
+		}
+		s.MessageWaiting = append(s.MessageWaiting, MessageWaiting{
+			Type:   IndicationType(ie.Data[0] & 0x03),
+			Active: ie.Data[1] > 0,
+			Count:  int(ie.Data[1]),
+			Store:  ie.Data[0]&0x80 != 0,
+		})
+	}
+}
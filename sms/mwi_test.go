@@ -0,0 +1,39 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestMessageWaiting(t *testing.T) {
+	t.Parallel()
+
+	// the voicemail indication of the discard group
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes("00040B919799674523F100C82220615145744000"))
+	require.NoError(t, err)
+	assert.Equal(t, []MessageWaiting{{Type: IndicationTypes.Voicemail, Active: true}}, msg.MessageWaiting)
+
+	// the fax indication of the UCS2 store group is cleared
+	_, err = msg.ReadFrom(util.MustBytes("00040B919799674523F100E1222061514574400400680069"))
+	require.NoError(t, err)
+	assert.Equal(t, []MessageWaiting{{Type: IndicationTypes.Fax, Store: true}}, msg.MessageWaiting)
+	assert.Equal(t, "hi", msg.Text)
+
+	// the special message indications of the user data header
+	var parsed Message
+	_, err = parsed.ReadFrom(util.MustBytes("00440B919799674523F1000022206151457440" + "0D" + "080102800301020200" + "009D06"))
+	require.NoError(t, err)
+	assert.Equal(t, []MessageWaiting{
+		{Type: IndicationTypes.Voicemail, Active: true, Count: 3, Store: true},
+		{Type: IndicationTypes.Email},
+	}, parsed.MessageWaiting)
+	assert.Equal(t, "hi", parsed.Text)
+
+	_, err = parsed.ReadFrom(util.MustBytes("00040B919799674523F100002220615145744002E834"))
+	require.NoError(t, err)
+	assert.Empty(t, parsed.MessageWaiting)
+}
@@ -1,10 +1,9 @@
 package sms
 
 import (
-	"bytes"
 	"fmt"
-	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/xlab/at/pdu"
 )
@@ -67,31 +66,52 @@ var NumberingPlans = struct {
 	Reserved:               0b1111,
 }
 
+// maxAlphanumericLen is the maximum number of characters in an alphanumeric address.
+const maxAlphanumericLen = 11
+
+// Alphanumeric reports whether the address is alphanumeric, e.g. the sender name,
+// i.e. it contains letters. The other characters besides digits are ignored in the numbers.
+func (p PhoneNumber) Alphanumeric() bool {
+	return strings.IndexFunc(string(p), unicode.IsLetter) >= 0
+}
+
 // PDU returns the number of digits in address and octets of semi-octet encoded address.
+// The alphanumeric address is encoded with GSM 7-bit packing, the number of semi-octets
+// occupied by the packed septets is returned then.
 func (p PhoneNumber) PDU() (int, []byte, error) {
-	digitStr := strings.TrimPrefix(string(p), "+")
-	var str string
-	for _, r := range digitStr {
+	if p.Alphanumeric() {
+		septets := pdu.Len7Bit(string(p))
+		if septets > maxAlphanumericLen {
+			return 0, nil, ErrAddressTooLong
+		}
+		octets := pdu.AppendEncode7Bit(make([]byte, 1, 1+blocks(septets*7, 8)+1), string(p))
+		octets[0] = p.Type()
+		return blocks(septets*7, 4), octets, nil
+	}
+	// the digits are kept as is, e.g. the leading zeros of the short codes
+	digits := strings.Map(func(r rune) rune {
 		if r >= '0' && r <= '9' {
-			str = str + string(r)
+			return r
 		}
+		return -1
+	}, string(p))
+	if digits == "" {
+		return 0, nil, fmt.Errorf("%w: %q has no digits", ErrInvalidAddress, p)
 	}
-	n := len(str)
-	number, err := strconv.ParseUint(str, 10, 64)
+	octets, err := pdu.AppendBCD(append(make([]byte, 0, 1+len(digits)/2+1), p.Type()), digits)
 	if err != nil {
 		return 0, nil, err
 	}
-	var buf bytes.Buffer
-	buf.WriteByte(p.Type())
-	buf.Write(pdu.EncodeSemi(number))
-	return n, buf.Bytes(), nil
+	return len(digits), octets, nil
 }
 
 // Type returns the type of address (a combination of type-of-number and
 // numbering-plan-identification). Currently, only national and
-// international E.164 numbers are understood. While ReadFrom() can
-// parse alphanumeric numbers, Type() doesn't recognize it.
+// international E.164 numbers and alphanumeric addresses are understood.
 func (p PhoneNumber) Type() byte {
+	if p.Alphanumeric() {
+		return 0x80 | byte(PhoneNumberTypes.Alphanumeric) | byte(NumberingPlans.Unknown)
+	}
 	typ := PhoneNumberTypes.National
 	if strings.HasPrefix(string(p), "+") {
 		typ = PhoneNumberTypes.International
@@ -114,13 +134,38 @@ func (p *PhoneNumber) ReadFrom(octets []byte) error {
 		}
 		*p = PhoneNumber(addr)
 	case PhoneNumberTypes.International:
-		addr := pdu.DecodeSemiAddress(octets[1:])
+		addr, err := pdu.DecodeBCD(octets[1:])
+		if err != nil {
+			return err
+		}
 		*p = PhoneNumber("+" + addr)
 	case PhoneNumberTypes.National:
-		addr := pdu.DecodeSemiAddress(octets[1:])
+		addr, err := pdu.DecodeBCD(octets[1:])
+		if err != nil {
+			return err
+		}
 		*p = PhoneNumber(addr)
 	default:
 		return fmt.Errorf("%w: Type(0x%x)", ErrUnsupportedTypeOfNumber, typ)
 	}
 	return nil
 }
+
+// readAddress reads the address field that starts with the address length in semi-octets,
+// the length tells the number of septets of alphanumeric address, since the padding bits
+// of the last octet could hold one more septet.
+func (p *PhoneNumber) readAddress(field []byte) error {
+	if len(field) < 2 {
+		return ErrIncorrectSize
+	}
+	if PhoneNumberType(field[1]&0b0111_0000) != PhoneNumberTypes.Alphanumeric {
+		return p.ReadFrom(field[1:])
+	}
+	// the length counts the semi-octets, so the septets are decoded exactly
+	addr, err := pdu.Decode7BitLen(field[2:], int(field[0])*4/7)
+	if err != nil {
+		return err
+	}
+	*p = PhoneNumber(addr)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/pdu"
 	"github.com/xlab/at/util"
 )
 
@@ -31,9 +32,7 @@ func TestPhoneNumber(t *testing.T) {
 		"alphanumeric": {
 			pdu:    util.MustBytes("D061F1985C3603"),
 			number: "abcdef",
-			// FIXME: we don't have proper support for alphanumeric numbers
-			// yet, so Type() will just use "national" as type.
-			typ: PhoneNumberTypes.National,
+			typ:    PhoneNumberTypes.Alphanumeric,
 		},
 	} {
 		tc := tc
@@ -45,7 +44,63 @@ func TestPhoneNumber(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.EqualValues(t, tc.number, subject)
-			assert.Equal(t, 0x81|byte(tc.typ), subject.Type())
+			assert.Equal(t, tc.pdu[0], subject.Type())
+			assert.Equal(t, tc.typ, PhoneNumberType(subject.Type()&0b0111_0000))
 		})
 	}
 }
+
+func TestPhoneNumberPDU(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		number PhoneNumber
+		digits int
+		pdu    string
+		parsed PhoneNumber
+	}{
+		{"0012", 4, "A10021", "0012"},
+		{"+12345678901234567890", 20, "9121436587092143658709", "+12345678901234567890"},
+		{"+7 (999) 765-43-21", 11, "919799674523F1", "+79997654321"},
+	} {
+		n, octets, err := tc.number.PDU()
+		require.NoError(t, err, tc.number)
+		assert.Equal(t, tc.digits, n, tc.number)
+		assert.Equal(t, util.MustBytes(tc.pdu), octets, tc.number)
+
+		var parsed PhoneNumber
+		require.NoError(t, parsed.readAddress(append([]byte{byte(n)}, octets...)), tc.number)
+		assert.Equal(t, tc.parsed, parsed)
+	}
+
+	_, _, err := PhoneNumber("+").PDU()
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+	var parsed PhoneNumber
+	assert.ErrorIs(t, parsed.ReadFrom(util.MustBytes("91F121")), pdu.ErrUnexpectedFiller)
+}
+
+func TestPhoneNumberAlphanumeric(t *testing.T) {
+	t.Parallel()
+
+	// the last octet of 7 characters has room for one more septet
+	for _, name := range []PhoneNumber{"Bank", "Telecom", "MyOperator1"} {
+		msg := Message{
+			Type:     MessageTypes.Deliver,
+			Encoding: Encodings.Gsm7Bit,
+			Address:  name,
+			Text:     "hi",
+		}
+		_, octets, err := msg.PDU()
+		require.NoError(t, err)
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err)
+		assert.Equal(t, name, parsed.Address)
+	}
+
+	_, octets, err := PhoneNumber("abcdef").PDU()
+	require.NoError(t, err)
+	assert.Equal(t, util.MustBytes("D061F1985C3603"), octets)
+	_, _, err = PhoneNumber("VeryLongSender").PDU()
+	assert.Equal(t, ErrAddressTooLong, err)
+}
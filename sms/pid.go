@@ -0,0 +1,65 @@
+package sms
+
+// ProtocolIdentifier represents the TP-Protocol-Identifier, i.e. the higher layer protocol
+// being used or the interworking with a telematic device, as specified in 3GPP TS 23.040,
+// section 9.2.3.9.
+type ProtocolIdentifier byte
+
+// ProtocolIdentifiers represent the commonly used protocol identifiers.
+var ProtocolIdentifiers = struct {
+	// Default is the plain SME-to-SME protocol without interworking.
+	Default ProtocolIdentifier
+	// Email is the telematic interworking with an Internet electronic mail.
+	Email ProtocolIdentifier
+	// ShortMessageType0 is acknowledged by the mobile station, but its contents are discarded.
+	ShortMessageType0 ProtocolIdentifier
+	// ReplaceType1..ReplaceType7 replace the stored message with the same protocol identifier
+	// and originating address.
+	ReplaceType1 ProtocolIdentifier
+	ReplaceType2 ProtocolIdentifier
+	ReplaceType3 ProtocolIdentifier
+	ReplaceType4 ProtocolIdentifier
+	ReplaceType5 ProtocolIdentifier
+	ReplaceType6 ProtocolIdentifier
+	ReplaceType7 ProtocolIdentifier
+	// SimDataDownload passes the message to the (U)SIM, e.g. the OTA commands.
+	SimDataDownload ProtocolIdentifier
+}{
+	0x00, 0x32,
+	0x40, 0x41, 0x42, 0x43, 0x44, 0x45, 0x46, 0x47,
+	0x7F,
+}
+
+// Replace returns the replace short message type in range 1..7 or zero
+// if the protocol identifier isn't one of the replace types.
+func (p ProtocolIdentifier) Replace() int {
+	if p >= ProtocolIdentifiers.ReplaceType1 && p <= ProtocolIdentifiers.ReplaceType7 {
+		return int(p - ProtocolIdentifiers.ShortMessageType0)
+	}
+	return 0
+}
+
+// SIMSpecific returns true if the message is meant for the (U)SIM rather than the user,
+// i.e. it's a class 2 message or it has the SIM Data Download protocol identifier.
+func (s *Message) SIMSpecific() bool {
+	return s.Encoding.DCS().Class == MessageClasses.Class2 ||
+		s.ProtocolIdentifier == ProtocolIdentifiers.SimDataDownload
+}
+
+// ReplaceType returns the replace short message type of the SMS-DELIVER or SMS-SUBMIT in range 1..7,
+// see ProtocolIdentifier.Replace. It's zero for the messages that don't replace anything.
+func (s *Message) ReplaceType() int {
+	if s.Type != MessageTypes.Deliver && s.Type != MessageTypes.Submit {
+		return 0
+	}
+	return s.ProtocolIdentifier.Replace()
+}
+
+// Replaces returns true if the message supersedes the other one, i.e. both are of the same type
+// and replace type and have the same address and service center address, as specified
+// in 3GPP TS 23.040, section 9.2.3.9.
+func (s *Message) Replaces(other *Message) bool {
+	typ := s.ReplaceType()
+	return typ > 0 && typ == other.ReplaceType() && s.Type == other.Type &&
+		s.Address == other.Address && s.ServiceCenterAddress == other.ServiceCenterAddress
+}
@@ -0,0 +1,121 @@
+package sms
+
+import (
+	"unicode/utf16"
+
+	"github.com/xlab/at/pdu"
+)
+
+// concatHeaderLen is the length of the user data header with the concatenation
+// element with 8-bit reference number only, including the header length octet.
+const concatHeaderLen = 6
+
+// Segmentation describes how the text is encoded and split into the concatenated parts by Split.
+type Segmentation struct {
+	Encoding Encoding
+	// Units is the number of septets of GSM 7-bit encoded text,
+	// or the number of UTF-16 code units of UCS2 encoded one.
+	Units int
+	// Segments is the number of the messages the text occupies.
+	Segments int
+	// Remaining is the number of units left in the last segment.
+	Remaining int
+}
+
+// ChooseEncoding returns the minimal encoding of the text, i.e. GSM 7-bit
+// if the text could be encoded with it, UCS2 otherwise.
+func ChooseEncoding(text string) Encoding {
+	if _, invalid := pdu.Check7Bit(text); invalid < 0 {
+		return Encodings.Gsm7Bit
+	}
+	return Encodings.UCS2
+}
+
+// SegmentInfo returns the minimal encoding of the text and the number of the segments it occupies,
+// e.g. to estimate the cost of the message before it's sent. A single message holds 160 septets
+// or 70 UCS2 characters, each concatenated part holds 153 septets or 67 UCS2 characters.
+func SegmentInfo(text string) Segmentation {
+	info := Segmentation{Encoding: Encodings.Gsm7Bit}
+	septets, invalid := pdu.Check7Bit(text)
+	if invalid >= 0 {
+		info.Encoding = Encodings.UCS2
+	}
+	single := maxUserDataLen * 8 / 7
+	multi := (maxUserDataLen*8 - concatHeaderLen*8 - fillBits(concatHeaderLen)) / 7
+	size := func(r rune) int {
+		return pdu.Len7Bit(string(r))
+	}
+	info.Units = septets
+	if info.Encoding == Encodings.UCS2 {
+		single = maxUserDataLen / 2
+		multi = (maxUserDataLen - concatHeaderLen) / 2
+		size = func(r rune) int {
+			return len(utf16.Encode([]rune{r}))
+		}
+		info.Units = len(utf16.Encode([]rune(text)))
+	}
+	if info.Units <= single {
+		info.Segments = 1
+		info.Remaining = single - info.Units
+		return info
+	}
+	parts := splitRunes(text, multi, size)
+	info.Segments = len(parts)
+	info.Remaining = multi
+	for _, r := range parts[len(parts)-1] {
+		info.Remaining -= size(r)
+	}
+	return info
+}
+
+// EncodeInfo describes the user data of the message as it's encoded by PDU, see Message.EncodeInfo.
+type EncodeInfo struct {
+	// Units is the user data length, i.e. the number of septets of GSM 7-bit encoded user data
+	// or the number of octets otherwise, the user data header and the fill bits included.
+	Units int
+	// Free is the number of units left in the message, it's negative if the user data doesn't fit
+	// into a single message, PDU would truncate it then.
+	Free int
+	// Header is true if the user data header is included.
+	Header bool
+	// Segments is the number of the parts the message is split into by Split.
+	Segments int
+}
+
+// EncodeInfo returns the size of the user data of the message as it's encoded by PDU, e.g. to check
+// the message fits before it's sent. The message is not modified and no reference number is taken.
+func (s *Message) EncodeInfo() (EncodeInfo, error) {
+	userData, _, err := s.encodedUserData()
+	if err != nil {
+		return EncodeInfo{}, err
+	}
+	info := EncodeInfo{
+		Units:    len(userData),
+		Free:     maxUserDataLen - len(userData),
+		Header:   s.UserDataStartsWithHeader,
+		Segments: 1,
+	}
+	if s.DCS().Alphabet == Alphabets.Gsm7Bit {
+		// the encoded length is truncated to an octet, so it's counted again
+		var headerLen int
+		if s.UserDataStartsWithHeader {
+			headerLen = len(s.UserDataHeader.Bytes())
+		}
+		locking, single := s.languages()
+		info.Units = (headerLen*8+fillBits(headerLen))/7 + pdu.Len7BitWithTables(s.text(), locking, single)
+		info.Free = maxUserDataLen*8/7 - info.Units
+	}
+	if info.Free < 0 {
+		tpl := *s
+		if tpl.UserDataHeader.Tag == 0 {
+			// any reference number will do
+			tpl.UserDataHeader.Tag = 1
+		}
+		parts, err := Split(&tpl)
+		if err != nil {
+			return info, err
+		}
+		info.Segments = len(parts)
+	}
+	return info, nil
+}
@@ -0,0 +1,80 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentInfo(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Encodings.Gsm7Bit, ChooseEncoding("hello {}"))
+	assert.Equal(t, Encodings.UCS2, ChooseEncoding("привет"))
+
+	for _, tc := range []struct {
+		text     string
+		expected Segmentation
+	}{
+		{"", Segmentation{Encodings.Gsm7Bit, 0, 1, 160}},
+		{"hello", Segmentation{Encodings.Gsm7Bit, 5, 1, 155}},
+		{strings.Repeat("a", 160), Segmentation{Encodings.Gsm7Bit, 160, 1, 0}},
+		{strings.Repeat("a", 161), Segmentation{Encodings.Gsm7Bit, 161, 2, 145}},
+		{strings.Repeat("€", 80), Segmentation{Encodings.Gsm7Bit, 160, 1, 0}},
+		// the escaped character isn't split across the parts
+		{strings.Repeat("a", 152) + "€" + strings.Repeat("a", 7), Segmentation{Encodings.Gsm7Bit, 161, 2, 144}},
+		{strings.Repeat("ж", 70), Segmentation{Encodings.UCS2, 70, 1, 0}},
+		{strings.Repeat("ж", 71), Segmentation{Encodings.UCS2, 71, 2, 63}},
+		{strings.Repeat("ж", 66) + "😀", Segmentation{Encodings.UCS2, 68, 1, 2}},
+	} {
+		assert.Equal(t, tc.expected, SegmentInfo(tc.text), tc.text)
+	}
+
+	// the number of segments matches the parts of Split
+	text := strings.Repeat("ж", 66) + "😀" + strings.Repeat("ж", 70)
+	info := SegmentInfo(text)
+	parts, err := Split(&Message{Type: MessageTypes.Submit, Address: "+79991234567", Encoding: info.Encoding, Text: text})
+	assert.NoError(t, err)
+	assert.Len(t, parts, info.Segments)
+	assert.Equal(t, 3, info.Segments)
+}
+
+func TestEncodeInfo(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{Type: MessageTypes.Submit, Encoding: Encodings.Gsm7Bit, Address: "1234", Text: "hello"}
+	info, err := msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 5, Free: 155, Segments: 1}, info)
+
+	// the header of 7 octets takes 8 septets
+	msg.SetPorts(2948, 9200)
+	info, err = msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 13, Free: 147, Header: true, Segments: 1}, info)
+
+	// the user data length would be truncated by PDU
+	msg = Message{Type: MessageTypes.Submit, Encoding: Encodings.Gsm7Bit, Address: "1234", Text: strings.Repeat("a", 300)}
+	info, err = msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 300, Free: -140, Segments: 2}, info)
+	assert.Zero(t, msg.UserDataHeader.Tag)
+
+	msg = Message{Type: MessageTypes.Submit, Encoding: Encodings.UCS2, Address: "1234", Text: strings.Repeat("ж", 71)}
+	info, err = msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 142, Free: -2, Segments: 2}, info)
+
+	msg = Message{Type: MessageTypes.Submit, Address: "1234", Text: strings.Repeat("é", 141)}
+	msg.SetDCS(DCS{Alphabet: Alphabets.Latin1})
+	info, err = msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 141, Free: -1, Segments: 2}, info)
+
+	msg = Message{Type: MessageTypes.Submit, Encoding: Encodings.Data8Bit, Address: "1234", Data: make([]byte, 100)}
+	info, err = msg.EncodeInfo()
+	require.NoError(t, err)
+	assert.Equal(t, EncodeInfo{Units: 100, Free: 40, Segments: 1}, info)
+}
@@ -4,36 +4,72 @@ package sms
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
-	"unicode/utf8"
+	"strings"
 
 	"github.com/xlab/at/pdu"
+	"github.com/xlab/at/sms/tpdu"
+	"github.com/xlab/at/util"
 )
 
 // Common errors.
 var (
 	ErrUnknownEncoding               = errors.New("sms: unsupported encoding")
 	ErrUnknownMessageType            = errors.New("sms: unsupported message type")
-	ErrIncorrectSize                 = errors.New("sms: decoded incorrect size of field")
-	ErrNonRelative                   = errors.New("sms: non-relative validity period support is not implemented yet")
+	ErrIncorrectSize                 = tpdu.ErrIncorrectSize
+	ErrNonRelative                   = errors.New("sms: enhanced validity period support is not implemented yet")
 	ErrIncorrectUserDataHeaderLength = errors.New("sms: incorrect user data header length ")
 	ErrUnsupportedTypeOfNumber       = errors.New("sms: unsupported type-of-number")
+	ErrAddressTooLong                = errors.New("sms: alphanumeric address is longer than 11 characters")
+
+	// ErrCompressed is returned for the user data compressed as specified in 3GPP TS 23.042.
+	// Neither the decompression nor the compression is supported, so it's an ErrUnknownEncoding
+	// as well. The compressed octets are kept as Data in lenient mode for an external decoder.
+	ErrCompressed = fmt.Errorf("%w: compressed user data", ErrUnknownEncoding)
 )
 
 // Message represents an SMS message, including some advanced fields. This
 // is a user-friendly high-level representation that should be used around.
 // Complies with 3GPP TS 23.040.
 type Message struct {
-	Type                 MessageType
-	Encoding             Encoding
-	VP                   ValidityPeriod
-	VPFormat             ValidityPeriodFormat
+	Type     MessageType
+	Encoding Encoding
+	VP       ValidityPeriod
+	VPFormat ValidityPeriodFormat
+	// VPTime is the time the message expires at, used instead of VP
+	// with the absolute validity period format.
+	VPTime               Timestamp
 	ServiceCenterTime    Timestamp
 	DischargeTime        Timestamp
 	ServiceCenterAddress PhoneNumber
-	Address              PhoneNumber
-	Text                 string
-	UserDataHeader       UserDataHeader
+	// ServiceCenterType is the type-of-address octet of the service center address, it's set
+	// when the message is decoded and overrides the one of the address when it's encoded if not zero.
+	ServiceCenterType  byte
+	Address            PhoneNumber
+	Text               string
+	UserDataHeader     UserDataHeader
+	ProtocolIdentifier ProtocolIdentifier
+
+	// Data is the payload of the messages with 8-bit data encoding, Text is empty then.
+	// The user data length of such messages counts the octets of the header and the payload,
+	// the octets beyond it are ignored. The user data of the reserved and the compressed
+	// data coding schemes is decoded as Data in lenient mode, see DecodeModes.
+	Data []byte
+	// Latin1 selects Text in ISO-8859-1 instead of Data with 8-bit data encoding,
+	// see Alphabets.Latin1, SetDCS and DecodeLatin1.
+	Latin1 bool
+
+	// MessageWaiting are the message waiting indications of the received message,
+	// they're set when the message is decoded, see MessageWaiting.
+	MessageWaiting []MessageWaiting
+	// EMS are the Enhanced Messaging Service objects of the message, e.g. pictures and melodies,
+	// they're set when the message is decoded from the elements of the user data header.
+	EMS []EMSObject
+	// Email is the email header of the message interworking with Internet electronic mail,
+	// see ProtocolIdentifiers.Email. It's taken from the text when the message is decoded
+	// and put before the text when the message is encoded.
+	Email *Email
 
 	// Advanced
 	MessageReference         byte
@@ -48,6 +84,24 @@ type Message struct {
 	RejectDuplicates         bool
 }
 
+// Ports returns the application ports the message is addressed to, ok is false
+// if the user data header has no application port addressing element.
+func (s *Message) Ports() (dst, src int, ok bool) {
+	udh := &s.UserDataHeader
+	if !s.UserDataStartsWithHeader || udh.DestinationPort == 0 && udh.SourcePort == 0 {
+		return 0, 0, false
+	}
+	return udh.DestinationPort, udh.SourcePort, true
+}
+
+// SetPorts addresses the message to the given application ports, the user data header
+// is turned on. The 16-bit addressing is used unless the header selects the 8-bit one.
+func (s *Message) SetPorts(dst, src int) {
+	s.UserDataStartsWithHeader = true
+	s.UserDataHeader.DestinationPort = dst
+	s.UserDataHeader.SourcePort = src
+}
+
 func blocks(n, block int) int {
 	if n%block == 0 {
 		return n / block
@@ -55,28 +109,13 @@ func blocks(n, block int) int {
 	return n/block + 1
 }
 
-func cutStr(str string, n int) string {
-	runes := []rune(str)
-	if n < len(str) {
-		return string(runes[0:n])
-	}
-	return str
-}
-
 // PDU serializes the message into octets ready to be transferred.
 // Returns the number of TPDU bytes in the produced PDU.
 // Complies with 3GPP TS 23.040.
 func (s *Message) PDU() (int, []byte, error) {
 	var buf bytes.Buffer
-	if len(s.ServiceCenterAddress) < 1 {
-		buf.WriteByte(0x00) // SMSC info length
-	} else {
-		_, octets, err := s.ServiceCenterAddress.PDU()
-		if err != nil {
-			return 0, nil, err
-		}
-		buf.WriteByte(byte(len(octets)))
-		buf.Write(octets)
+	if err := writeServiceCenter(&buf, s.ServiceCenterAddress, s.ServiceCenterType); err != nil {
+		return 0, nil, err
 	}
 
 	var n int
@@ -100,7 +139,7 @@ func (s *Message) PDU() (int, []byte, error) {
 }
 
 func (s *Message) encodeDeliver(buf *bytes.Buffer) (n int, err error) {
-	var sms smsDeliver
+	var sms tpdu.Deliver
 	sms.MessageTypeIndicator = byte(s.Type)
 	sms.MoreMessagesToSend = s.MoreMessagesToSend
 	sms.LoopPrevention = s.LoopPrevention
@@ -117,7 +156,7 @@ func (s *Message) encodeDeliver(buf *bytes.Buffer) (n int, err error) {
 	addrBuf.Write(addr)
 	sms.OriginatingAddress = addrBuf.Bytes()
 
-	sms.ProtocolIdentifier = 0x00 // Short Message Type 0
+	sms.ProtocolIdentifier = byte(s.ProtocolIdentifier)
 	sms.DataCodingScheme = byte(s.Encoding)
 	sms.ServiceCentreTimestamp = s.ServiceCenterTime.PDU()
 	sms.UserData, sms.UserDataLength, err = s.encodedUserData()
@@ -129,7 +168,7 @@ func (s *Message) encodeDeliver(buf *bytes.Buffer) (n int, err error) {
 }
 
 func (s *Message) encodeSubmit(buf *bytes.Buffer) (n int, err error) {
-	var sms smsSubmit
+	var sms tpdu.Submit
 	sms.MessageTypeIndicator = byte(s.Type)
 	sms.RejectDuplicates = s.RejectDuplicates
 	sms.ValidityPeriodFormat = byte(s.VPFormat)
@@ -147,13 +186,15 @@ func (s *Message) encodeSubmit(buf *bytes.Buffer) (n int, err error) {
 	addrBuf.Write(addr)
 	sms.DestinationAddress = addrBuf.Bytes()
 
-	sms.ProtocolIdentifier = 0x00 // Short Message Type 0
+	sms.ProtocolIdentifier = byte(s.ProtocolIdentifier)
 	sms.DataCodingScheme = byte(s.Encoding)
 
 	switch s.VPFormat {
 	case ValidityPeriodFormats.Relative:
-		sms.ValidityPeriod = byte(s.VP.Octet())
-	case ValidityPeriodFormats.Absolute, ValidityPeriodFormats.Enhanced:
+		sms.ValidityPeriod = []byte{s.VP.Octet()}
+	case ValidityPeriodFormats.Absolute:
+		sms.ValidityPeriod = s.VPTime.PDU()
+	case ValidityPeriodFormats.Enhanced:
 		return 0, ErrNonRelative
 	}
 
@@ -165,7 +206,7 @@ func (s *Message) encodeSubmit(buf *bytes.Buffer) (n int, err error) {
 }
 
 func (s *Message) encodeStatusReport(buf *bytes.Buffer) (n int, err error) {
-	var sms smsStatusReport
+	var sms tpdu.StatusReport
 	sms.MessageTypeIndicator = byte(s.Type)
 	sms.UserDataHeaderIndicator = s.UserDataStartsWithHeader
 	sms.MoreMessagesToSend = s.MoreMessagesToSend
@@ -185,6 +226,8 @@ func (s *Message) encodeStatusReport(buf *bytes.Buffer) (n int, err error) {
 	sms.ServiceCentreTimestamp = s.ServiceCenterTime.PDU()
 	sms.DischargeTimestamp = s.DischargeTime.PDU()
 	sms.Status = byte(s.Status)
+	sms.ProtocolIdentifier = byte(s.ProtocolIdentifier)
+	sms.DataCodingScheme = byte(s.Encoding)
 	sms.UserData, sms.UserDataLength, err = s.encodedUserData()
 	if err != nil {
 		return 0, err
@@ -193,139 +236,287 @@ func (s *Message) encodeStatusReport(buf *bytes.Buffer) (n int, err error) {
 	return buf.Write(sms.Bytes())
 }
 
+// PDUHex is like PDU, but returns the hex-encoded PDU ready to be sent with AT+CMGS.
+func (s *Message) PDUHex() (string, int, error) {
+	n, octets, err := s.PDU()
+	if err != nil {
+		return "", 0, err
+	}
+	return util.HexString(octets), n, nil
+}
+
 // ReadFrom constructs a message from the supplied PDU octets. Returns the number of bytes read.
 // Complies with 3GPP TS 23.040.
 func (s *Message) ReadFrom(octets []byte) (n int, err error) {
-	*s = Message{}
-	buf := bytes.NewReader(octets)
-	scLen, err := buf.ReadByte()
-	n++
+	n, _, err = s.ReadFromMode(octets, DecodeModes.Default)
+	return
+}
+
+// ReadFromHex constructs a message from the hex-encoded PDU, e.g. the one listed by AT+CMGL,
+// the surrounding whitespace is ignored. Returns the number of bytes read.
+func (s *Message) ReadFromHex(str string) (n int, err error) {
+	octets, err := util.Bytes(strings.TrimSpace(str))
 	if err != nil {
-		return
-	}
-	if scLen > 16 {
-		return 0, ErrIncorrectSize
+		return 0, err
 	}
-	addr := make([]byte, scLen)
-	off, err := io.ReadFull(buf, addr)
-	n += off
-	if err != nil {
+	return s.ReadFrom(octets)
+}
+
+// ReadFromMode is like ReadFrom, but handles the violations of the specification according
+// to the given mode. The warnings are the violations tolerated in lenient mode.
+func (s *Message) ReadFromMode(octets []byte, mode DecodeMode) (n int, warnings []error, err error) {
+	*s = Message{}
+	d := &decoder{mode: mode}
+	if s.ServiceCenterAddress, s.ServiceCenterType, n, err = readServiceCenter(octets); err != nil {
 		return
 	}
-	s.ServiceCenterAddress.ReadFrom(addr)
-	msgType, err := buf.ReadByte()
-	n++
-	if err != nil {
-		return
+	octets = octets[n:]
+	if len(octets) == 0 {
+		return n, nil, io.EOF
 	}
-	n--
-	buf.UnreadByte()
-	s.Type = MessageType(msgType & 0x03)
+	s.Type = MessageType(octets[0] & 0x03)
 
 	var decBytes int
-	octets = octets[1+scLen:]
+	var kind string
 
 	switch s.Type {
 	case MessageTypes.Deliver:
-		decBytes, err = s.decodeDeliver(octets)
+		kind = "deliver"
+		decBytes, err = s.decodeDeliver(octets, d)
 	case MessageTypes.Submit:
-		decBytes, err = s.decodeSubmit(octets)
+		kind = "submit"
+		decBytes, err = s.decodeSubmit(octets, d)
 	case MessageTypes.StatusReport:
-		decBytes, err = s.decodeStatusReport(octets)
+		kind = "status report"
+		decBytes, err = s.decodeStatusReport(octets, d)
 	default:
-		return n, ErrUnknownMessageType
+		return n, nil, ErrUnknownMessageType
+	}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		// the offsets of the TPDU fields follow the SMSC information
+		fieldErr.Kind = kind
+		fieldErr.Offset += n
 	}
+	s.decodeEMS()
+	s.decodeEmail()
 
 	n += decBytes
-	return n, err
+	if err == nil && decBytes < len(octets) {
+		err = d.violation(fmt.Errorf("%w: %d trailing octets", ErrUserDataLength, len(octets)-decBytes))
+	}
+	return n, d.warnings, err
 }
 
-func (s *Message) decodeDeliver(data []byte) (n int, err error) {
-	var sms smsDeliver
+// writeServiceCenter writes the SMSC information that precedes the TPDU,
+// the given type-of-address is used instead of the one of the address if it's not zero.
+func writeServiceCenter(buf *bytes.Buffer, addr PhoneNumber, typ byte) error {
+	if len(addr) < 1 {
+		buf.WriteByte(0x00) // SMSC info length
+		return nil
+	}
+	_, octets, err := addr.PDU()
+	if err != nil {
+		return err
+	}
+	if typ != 0 {
+		octets[0] = typ
+	}
+	buf.WriteByte(byte(len(octets)))
+	buf.Write(octets)
+	return nil
+}
+
+// readServiceCenter reads the SMSC information that precedes the TPDU, the length of the SMSC
+// information is given in octets, unlike the one of the other addresses. The digits of the address
+// with an unsupported type-of-number or a misplaced filler are read anyway. Returns the number
+// of read octets.
+func readServiceCenter(octets []byte) (addr PhoneNumber, typ byte, n int, err error) {
+	if len(octets) == 0 {
+		return "", 0, 0, io.EOF
+	}
+	scLen := int(octets[0])
+	if len(octets) < 1+scLen {
+		return "", 0, len(octets), fieldError("service center address", 0, io.ErrUnexpectedEOF)
+	}
+	if scLen == 0 {
+		return "", 0, 1, nil
+	}
+	field := octets[1 : 1+scLen]
+	typ = field[0]
+	switch err := addr.ReadFrom(field); {
+	case errors.Is(err, ErrUnsupportedTypeOfNumber), errors.Is(err, pdu.ErrUnexpectedFiller):
+		addr = PhoneNumber(pdu.DecodeSemiAddress(field[1:]))
+	case err != nil:
+		return "", 0, 1 + scLen, fieldError("service center address", 0, err)
+	case addr.Alphanumeric() && (scLen-1)*8%7 == 0:
+		// the last septet is padding if the address doesn't fill the octets up
+		if last := len(addr) - 1; last > 0 && (addr[last] == '@' || addr[last] == '\r') {
+			addr = addr[:last]
+		}
+	}
+	return addr, typ, 1 + scLen, nil
+}
+
+func (s *Message) decodeDeliver(data []byte, d *decoder) (n int, err error) {
+	var sms tpdu.Deliver
 	n, err = sms.FromBytes(data)
 	if err != nil {
-		return
+		return n, tpduError(err)
 	}
 	s.MoreMessagesToSend = sms.MoreMessagesToSend
 	s.LoopPrevention = sms.LoopPrevention
 	s.ReplyPathExists = sms.ReplyPath
 	s.UserDataStartsWithHeader = sms.UserDataHeaderIndicator
-	if sms.UserDataHeaderIndicator {
-		err = s.UserDataHeader.ReadFrom(sms.UserData)
-		if err != nil {
-			return
-		}
+	userDataOffset := n - len(sms.UserData)
+	if err = d.readUserDataHeader(s, sms.UserData); err != nil {
+		return n, fieldError("user data header", userDataOffset, err)
 	}
 	s.StatusReportIndication = sms.StatusReportIndication
-	s.Address.ReadFrom(sms.OriginatingAddress[1:])
+	if err = d.readAddress(&s.Address, sms.OriginatingAddress); err != nil {
+		return n, fieldError("originating address", 1, err)
+	}
+	s.ProtocolIdentifier = ProtocolIdentifier(sms.ProtocolIdentifier)
 	s.Encoding = Encoding(sms.DataCodingScheme)
 	s.ServiceCenterTime.ReadFrom(sms.ServiceCentreTimestamp)
-	err = s.decodeUserData(sms.UserData, sms.UserDataLength)
-	return n, err
+	s.decodeMessageWaiting()
+	if err = s.decodeUserData(sms.UserData, sms.UserDataLength, d); err != nil {
+		return n, fieldError("user data", userDataOffset, err)
+	}
+	return n, nil
 }
 
-func (s *Message) decodeSubmit(data []byte) (n int, err error) {
-	var sms smsSubmit
+func (s *Message) decodeSubmit(data []byte, d *decoder) (n int, err error) {
+	var sms tpdu.Submit
 	n, err = sms.FromBytes(data)
 	if err != nil {
-		return
+		return n, tpduError(err)
 	}
 	s.RejectDuplicates = sms.RejectDuplicates
 
+	s.VPFormat = ValidityPeriodFormat(sms.ValidityPeriodFormat)
 	switch s.VPFormat {
-	case ValidityPeriodFormats.Absolute, ValidityPeriodFormats.Enhanced:
-		return n, ErrNonRelative
-	default:
-		s.VPFormat = ValidityPeriodFormat(sms.ValidityPeriodFormat)
+	case ValidityPeriodFormats.Relative:
+		s.VP.ReadFrom(sms.ValidityPeriod[0])
+	case ValidityPeriodFormats.Absolute:
+		s.VPTime.ReadFrom(sms.ValidityPeriod)
+	case ValidityPeriodFormats.Enhanced:
+		return n, fieldError("validity period", 4+len(sms.DestinationAddress), ErrNonRelative)
 	}
 
 	s.MessageReference = sms.MessageReference
 	s.ReplyPathExists = sms.ReplyPath
 	s.UserDataStartsWithHeader = sms.UserDataHeaderIndicator
 	s.StatusReportRequest = sms.StatusReportRequest
-	s.Address.ReadFrom(sms.DestinationAddress[1:])
+	userDataOffset := n - len(sms.UserData)
+	if err = d.readUserDataHeader(s, sms.UserData); err != nil {
+		return n, fieldError("user data header", userDataOffset, err)
+	}
+	if err = d.readAddress(&s.Address, sms.DestinationAddress); err != nil {
+		return n, fieldError("destination address", 2, err)
+	}
+	s.ProtocolIdentifier = ProtocolIdentifier(sms.ProtocolIdentifier)
 	s.Encoding = Encoding(sms.DataCodingScheme)
 
-	if s.VPFormat != ValidityPeriodFormats.FieldNotPresent {
-		s.VP.ReadFrom(sms.ValidityPeriod)
+	if err = s.decodeUserData(sms.UserData, sms.UserDataLength, d); err != nil {
+		return n, fieldError("user data", userDataOffset, err)
 	}
-	err = s.decodeUserData(sms.UserData, sms.UserDataLength)
-	return n, err
+	return n, nil
 }
 
-func (s *Message) decodeStatusReport(data []byte) (n int, err error) {
-	var sms smsStatusReport
+func (s *Message) decodeStatusReport(data []byte, d *decoder) (n int, err error) {
+	var sms tpdu.StatusReport
 	n, err = sms.FromBytes(data)
 	if err != nil {
-		return
+		return n, tpduError(err)
 	}
 	s.MessageReference = sms.MessageReference
 	s.MoreMessagesToSend = sms.MoreMessagesToSend
 	s.LoopPrevention = sms.LoopPrevention
 	s.UserDataStartsWithHeader = sms.UserDataHeaderIndicator
-	if sms.UserDataHeaderIndicator {
-		err = s.UserDataHeader.ReadFrom(sms.UserData)
-		if err != nil {
-			return
-		}
+	userDataOffset := n - len(sms.UserData)
+	if err = d.readUserDataHeader(s, sms.UserData); err != nil {
+		return n, fieldError("user data header", userDataOffset, err)
 	}
 	s.StatusReportQualificator = sms.StatusReportQualificator
 	s.Status = Status(sms.Status)
-	s.Address.ReadFrom(sms.DestinationAddress[1:])
+	if err = d.readAddress(&s.Address, sms.DestinationAddress); err != nil {
+		return n, fieldError("recipient address", 2, err)
+	}
+	s.ProtocolIdentifier = ProtocolIdentifier(sms.ProtocolIdentifier)
 	s.Encoding = Encoding(sms.DataCodingScheme)
 	s.ServiceCenterTime.ReadFrom(sms.ServiceCentreTimestamp)
 	s.DischargeTime.ReadFrom(sms.DischargeTimestamp)
-	err = s.decodeUserData(sms.UserData, sms.UserDataLength)
-	return n, err
+	if sms.Parameters&0x04 == 0 {
+		// the user data is optional
+		return n, nil
+	}
+	if err = s.decodeUserData(sms.UserData, sms.UserDataLength, d); err != nil {
+		return n, fieldError("user data", userDataOffset, err)
+	}
+	return n, nil
+}
+
+// DCS returns the data coding scheme of the message, the alphabet is Latin1
+// for the 8-bit data encoding if Latin1 is set.
+func (s *Message) DCS() DCS {
+	dcs := s.Encoding.DCS()
+	if s.Latin1 && dcs.Alphabet == Alphabets.Data8Bit {
+		dcs.Alphabet = Alphabets.Latin1
+	}
+	return dcs
+}
+
+// SetDCS sets the encoding of the message to the data coding scheme,
+// Latin1 is set if the alphabet is Latin1.
+func (s *Message) SetDCS(dcs DCS) {
+	s.Encoding = dcs.Encoding()
+	s.Latin1 = dcs.Alphabet == Alphabets.Latin1
+}
+
+// DecodeLatin1 decodes the 8-bit data of the received message as ISO-8859-1 text of the legacy
+// SMSCs, Text is set instead of Data and Latin1 is set. ErrEncodingMismatch is returned if the
+// message isn't 8-bit encoded.
+func (s *Message) DecodeLatin1() error {
+	if alphabet := s.DCS().Alphabet; alphabet != Alphabets.Data8Bit && alphabet != Alphabets.Latin1 {
+		return fmt.Errorf("%w: %s isn't 8-bit encoded", ErrEncodingMismatch, alphabetNames[alphabet])
+	}
+	if !s.Latin1 {
+		s.Text, s.Data, s.Latin1 = pdu.DecodeLatin1(s.Data), nil, true
+	}
+	return nil
 }
 
 func (s *Message) encodedUserData() (userData []byte, length byte, err error) {
-	switch s.Encoding {
-	case Encodings.Gsm7Bit, Encodings.Gsm7Bit_2, Encodings.Gsm7Bit_3:
-		userData = pdu.Encode7Bit(s.Text)
-		length = byte(utf8.RuneCountInString(s.Text))
-	case Encodings.UCS2:
-		userData = pdu.EncodeUcs2(s.Text)
+	var header []byte
+	if s.UserDataStartsWithHeader {
+		header = s.UserDataHeader.Bytes()
+	}
+	dcs := s.DCS()
+	if dcs.Compressed {
+		return nil, 0, ErrCompressed
+	}
+	switch dcs.Alphabet {
+	case Alphabets.Gsm7Bit:
+		// the text starts on the septet boundary after the header
+		fill := fillBits(len(header))
+		locking, single := s.languages()
+		text := s.text()
+		septets := pdu.Len7BitWithTables(text, locking, single)
+		userData = make([]byte, 0, len(header)+blocks(fill+septets*7, 8)+1)
+		userData = pdu.AppendEncode7BitWithFill(append(userData, header...), text, fill, locking, single)
+		// UDL counts the septets, so the <CR> appended to the one ending on the octet boundary is left out
+		userData = userData[:len(header)+blocks(fill+septets*7, 8)]
+		length = byte((len(header)*8+fill)/7 + septets)
+	case Alphabets.UCS2:
+		text := s.text()
+		userData = pdu.AppendUcs2(append(make([]byte, 0, len(header)+len(text)*2), header...), text)
+		length = byte(len(userData))
+	case Alphabets.Data8Bit:
+		userData = append(header, s.Data...)
+		length = byte(len(userData))
+	case Alphabets.Latin1:
+		userData = pdu.AppendLatin1(header, s.text())
 		length = byte(len(userData))
 	default:
 		err = ErrUnknownEncoding
@@ -334,15 +525,65 @@ func (s *Message) encodedUserData() (userData []byte, length byte, err error) {
 	return
 }
 
-func (s *Message) decodeUserData(data []byte, dataLen byte) (err error) {
-	switch s.Encoding {
-	case Encodings.Gsm7Bit, Encodings.Gsm7Bit_2, Encodings.Gsm7Bit_3:
-		if s.Text, err = pdu.Decode7Bit(data); err != nil {
-			return
+// languages returns the national language shift tables selected by the user data header.
+func (s *Message) languages() (locking, single pdu.Language) {
+	if !s.UserDataStartsWithHeader {
+		return pdu.Languages.Default, pdu.Languages.Default
+	}
+	return s.UserDataHeader.LockingShift, s.UserDataHeader.SingleShift
+}
+
+// fillBits returns the number of bits required to align the 7-bit encoded
+// text that follows the user data header of the given length on the septet boundary.
+func fillBits(headerLen int) int {
+	return (7 - headerLen*8%7) % 7
+}
+
+func (s *Message) decodeUserData(data []byte, dataLen byte, d *decoder) (err error) {
+	alphabet, err := d.alphabet(s.Encoding)
+	if err != nil {
+		return
+	}
+	if err = d.checkUserDataLength(alphabet, data, dataLen); err != nil {
+		return
+	}
+	switch alphabet {
+	case Alphabets.Gsm7Bit:
+		// UDL counts the septets, so the text is decoded exactly
+		var fill int
+		septets := int(dataLen)
+		if s.UserDataStartsWithHeader && len(data) > 0 {
+			headerLen := int(data[0]) + 1
+			if headerLen > len(data) {
+				return ErrIncorrectUserDataHeaderLength
+			}
+			fill = fillBits(headerLen)
+			data = data[headerLen:]
+			if septets -= (headerLen*8 + fill) / 7; septets < 0 {
+				septets = 0
+			}
 		}
-		s.Text = cutStr(s.Text, int(dataLen))
-	case Encodings.UCS2:
+		locking, single := s.languages()
+		s.Text, err = pdu.Decode7BitWithFillLen(data, fill, septets, locking, single)
+	case Alphabets.UCS2:
 		s.Text, err = pdu.DecodeUcs2(data, s.UserDataStartsWithHeader)
+		if err == nil && d.mode == DecodeModes.Lenient {
+			// the big-endian byte order mark is just removed
+			s.Text = strings.TrimPrefix(s.Text, "\uFEFF")
+			text, _ := pdu.DecodeUcs2WithOrder(data, s.UserDataStartsWithHeader, pdu.ByteOrders.Auto)
+			if text != s.Text && d.tolerate(ErrUcs2ByteOrder) {
+				s.Text = text
+			}
+		}
+	case Alphabets.Data8Bit:
+		if s.UserDataStartsWithHeader && len(data) > 0 {
+			headerLen := int(data[0]) + 1
+			if headerLen > len(data) {
+				return ErrIncorrectUserDataHeaderLength
+			}
+			data = data[headerLen:]
+		}
+		s.Data = append([]byte(nil), data...)
 	default:
 		return ErrUnknownEncoding
 	}
@@ -1,11 +1,14 @@
 package sms
 
 import (
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/pdu"
 	"github.com/xlab/at/util"
 )
 
@@ -35,6 +38,7 @@ var (
 		Type:                 MessageTypes.Deliver,
 		Address:              "+79269965690",
 		ServiceCenterAddress: "+79168999100",
+		ServiceCenterType:    0x91,
 		ServiceCenterTime:    parseTimestamp("2014-06-26T21:36:30+04:00"),
 	}
 	smsDeliverGsm7 = Message{
@@ -43,6 +47,7 @@ var (
 		Type:                 MessageTypes.Deliver,
 		Address:              "+79269965690",
 		ServiceCenterAddress: "+79262000331",
+		ServiceCenterType:    0x91,
 		ServiceCenterTime:    parseTimestamp("2014-06-26T19:04:51+04:00"),
 	}
 	smsDeliverGsm7_2 = Message{
@@ -51,6 +56,7 @@ var (
 		Type:                 MessageTypes.Deliver,
 		Address:              "+5561999256868",
 		ServiceCenterAddress: "+550101102010",
+		ServiceCenterType:    0x91,
 		ServiceCenterTime:    parseTimestamp("2017-09-22T21:24:51-03:00"),
 	}
 	smsSubmitUCS2 = Message{
@@ -59,6 +65,7 @@ var (
 		Type:                 MessageTypes.Submit,
 		Address:              "+79269965690",
 		ServiceCenterAddress: "+79168999100",
+		ServiceCenterType:    0x91,
 		VP:                   ValidityPeriod(time.Hour * 24 * 4),
 		VPFormat:             ValidityPeriodFormats.Relative,
 	}
@@ -68,6 +75,7 @@ var (
 		Type:                 MessageTypes.Submit,
 		Address:              "+79269965690",
 		ServiceCenterAddress: "+79262000331",
+		ServiceCenterType:    0x91,
 		VP:                   ValidityPeriod(time.Hour * 24 * 4),
 		VPFormat:             ValidityPeriodFormats.Relative,
 	}
@@ -77,6 +85,7 @@ var (
 		MessageReference:     54,
 		Address:              "+4917600000001",
 		ServiceCenterAddress: "+491760000470",
+		ServiceCenterType:    0x91,
 		ServiceCenterTime:    parseTimestamp("2022-02-16T15:54:47+01:00"),
 		DischargeTime:        parseTimestamp("2022-02-16T15:54:48+01:00"),
 	}
@@ -198,6 +207,137 @@ func TestSmsSubmitPduGsm7(t *testing.T) {
 	assert.Equal(t, data, octets)
 }
 
+func TestSmsSubmitAbsoluteVP(t *testing.T) {
+	t.Parallel()
+
+	msg := smsSubmitGsm7
+	msg.VPFormat = ValidityPeriodFormats.Absolute
+	msg.VP = 0
+	msg.VPTime = parseTimestamp("2022-02-16T15:54:47+01:00")
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	assert.Contains(t, string(octets), string(util.MustBytes("22206151457440")))
+
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, ValidityPeriodFormats.Absolute, parsed.VPFormat)
+	assert.True(t, time.Time(msg.VPTime).Equal(time.Time(parsed.VPTime)))
+	assert.Equal(t, msg.Text, parsed.Text)
+
+	msg.VPFormat = ValidityPeriodFormats.Enhanced
+	_, _, err = msg.PDU()
+	assert.Equal(t, ErrNonRelative, err)
+}
+
+func TestSmsDeliverReadFromData(t *testing.T) {
+	t.Parallel()
+
+	// WAP push with the application port addressing header
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes("00440B919799674523F1000422206151457440" + "0A0605040B8423F0010203"))
+	require.NoError(t, err)
+	assert.Equal(t, Encodings.Data8Bit, msg.Encoding)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, msg.Data)
+	assert.Empty(t, msg.Text)
+	assert.Equal(t, 2948, msg.UserDataHeader.DestinationPort)
+	assert.Equal(t, 9200, msg.UserDataHeader.SourcePort)
+}
+
+func TestSmsDeliverReadFromDataLength(t *testing.T) {
+	t.Parallel()
+
+	const header = "00440B919799674523F1"
+	const scts = "22206151457440"
+	// the class 1 data, UDL counts the header and the payload
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes(header + "00F5" + scts + "0A0605040B8423F0010203" + "FFFF"))
+	require.NoError(t, err)
+	assert.Equal(t, Alphabets.Data8Bit, msg.DCS().Alphabet)
+	assert.Equal(t, MessageClasses.Class1, msg.DCS().Class)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, msg.Data)
+
+	_, _, err = msg.ReadFromMode(util.MustBytes(header+"00F5"+scts+"0A0605040B8423F0010203"+"FFFF"), DecodeModes.Strict)
+	assert.ErrorIs(t, err, ErrUserDataLength)
+
+	// the header alone
+	_, err = msg.ReadFrom(util.MustBytes(header + "0004" + scts + "070605040B8423F0"))
+	require.NoError(t, err)
+	assert.Empty(t, msg.Data)
+	assert.Equal(t, 2948, msg.UserDataHeader.DestinationPort)
+}
+
+func TestSmsProtocolIdentifier(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		Type:               MessageTypes.Submit,
+		Encoding:           Encodings.Gsm7Bit,
+		Address:            "+79991234567",
+		VPFormat:           ValidityPeriodFormats.Relative,
+		Text:               "hi",
+		ProtocolIdentifier: ProtocolIdentifiers.ReplaceType3,
+	}
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	assert.Equal(t, util.MustBytes("0011000B919799214365F743000002E834"), octets)
+
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolIdentifiers.ReplaceType3, parsed.ProtocolIdentifier)
+	assert.Equal(t, 3, parsed.ProtocolIdentifier.Replace())
+	assert.Equal(t, 0, ProtocolIdentifiers.ShortMessageType0.Replace())
+
+	_, err = parsed.ReadFrom(util.MustBytes("00440B919799674523F17F0422206151457440" + "0A0605040B8423F0010203"))
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolIdentifiers.SimDataDownload, parsed.ProtocolIdentifier)
+}
+
+func TestSmsServiceCenter(t *testing.T) {
+	t.Parallel()
+
+	const tpdu = "040B919762995696F0000041606291401561066379180E8200"
+	var msg Message
+	data := util.MustBytes("03812143" + tpdu)
+	n, err := msg.ReadFrom(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, PhoneNumber("1234"), msg.ServiceCenterAddress)
+	assert.Equal(t, byte(0x81), msg.ServiceCenterType)
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	assert.Equal(t, data, octets)
+
+	for _, addr := range []PhoneNumber{"MegaFon", "Operator"} {
+		msg := smsDeliverGsm7
+		msg.ServiceCenterAddress = addr
+		msg.ServiceCenterType = 0
+		_, octets, err := msg.PDU()
+		require.NoError(t, err)
+
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err, addr)
+		assert.Equal(t, addr, parsed.ServiceCenterAddress)
+		assert.Equal(t, octets[1], parsed.ServiceCenterType)
+		assert.Equal(t, PhoneNumberTypes.Alphanumeric, PhoneNumberType(parsed.ServiceCenterType&0b0111_0000))
+		assert.Equal(t, smsDeliverGsm7.Text, parsed.Text)
+	}
+
+	// the SMSC info longer than 16 octets
+	smsc := pdu.Encode7Bit("Service Center 123")
+	data = append([]byte{byte(len(smsc) + 1), 0xD0}, append(smsc, util.MustBytes(tpdu)...)...)
+	n, err = msg.ReadFrom(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, PhoneNumber("Service Center 123"), msg.ServiceCenterAddress)
+	assert.Equal(t, byte(0xD0), msg.ServiceCenterType)
+
+	_, err = msg.ReadFrom(util.MustBytes("0B91"))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
 func TestSmsStatusReport(t *testing.T) {
 	t.Parallel()
 
@@ -212,3 +352,227 @@ func TestSmsStatusReport(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, data, octets)
 }
+
+func TestSmsSubmitConcatenated(t *testing.T) {
+	t.Parallel()
+
+	for _, enc := range []Encoding{Encodings.Gsm7Bit, Encodings.UCS2} {
+		msg := Message{
+			Text:                     "hello world {concatenated}",
+			Type:                     MessageTypes.Submit,
+			Encoding:                 enc,
+			Address:                  "+79997654321",
+			VPFormat:                 ValidityPeriodFormats.Relative,
+			VP:                       ValidityPeriod(time.Hour),
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           UserDataHeader{TotalNumber: 3, Sequence: 2, Tag: 0x42},
+		}
+		_, octets, err := msg.PDU()
+		require.NoError(t, err)
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err)
+		assert.Equal(t, msg, parsed)
+	}
+}
+
+func TestSmsSubmitBinary(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		Data:                     []byte{0xde, 0xad, 0xbe, 0xef},
+		Type:                     MessageTypes.Submit,
+		Encoding:                 Encodings.Data8Bit,
+		Address:                  "+79997654321",
+		VPFormat:                 ValidityPeriodFormats.Relative,
+		VP:                       ValidityPeriod(time.Hour),
+		UserDataStartsWithHeader: true,
+		UserDataHeader: UserDataHeader{
+			TotalNumber: 2, Sequence: 1, Tag: 0x42,
+			DestinationPort: 2948, SourcePort: 9200,
+		},
+	}
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	// UDL, UDHL, the concatenation IE, the port addressing IE, the payload
+	assert.Equal(t, util.MustBytes("100B000342020105040B8423F0DEADBEEF"), octets[len(octets)-17:])
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, msg, parsed)
+}
+
+func TestSmsHex(t *testing.T) {
+	t.Parallel()
+
+	hex, n, err := smsSubmitGsm7.PDUHex()
+	require.NoError(t, err)
+	assert.Equal(t, pduSubmitGsm7, hex)
+	assert.Equal(t, len(pduSubmitGsm7)/2-8, n)
+
+	var msg Message
+	n, err = msg.ReadFromHex(" " + pduSubmitGsm7 + "\r\n")
+	require.NoError(t, err)
+	assert.Equal(t, len(pduSubmitGsm7)/2, n)
+	assert.Equal(t, smsSubmitGsm7, msg)
+
+	_, err = msg.ReadFromHex(pduSubmitGsm7[1:])
+	assert.ErrorIs(t, err, util.ErrUnevenLength)
+}
+
+func TestSmsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, hex := range []string{
+		pduDeliverGsm7,
+		pduSubmitUCS2,
+		pduStatusReport,
+		"00440B919799674523F1000422206151457440" + "0A0605040B8423F0010203",
+		// the application port addressing precedes the concatenation
+		"00440B919799674523F1000422206151457440" + "100B05040B8423F0000305020101020304",
+		// the concatenation with 16-bit reference and a text formatting element, the text follows the fill bits
+		"00440B919799674523F1000022206151457440" + "130B0804123403010A03000510A0CB6CF61B",
+	} {
+		var msg Message
+		_, err := msg.ReadFromHex(hex)
+		require.NoError(t, err, hex)
+		actual, _, err := msg.PDUHex()
+		require.NoError(t, err, hex)
+		assert.Equal(t, strings.ToUpper(hex), actual)
+	}
+
+	deliver := Message{
+		Type:                   MessageTypes.Deliver,
+		Encoding:               Encodings.Gsm7Bit,
+		Address:                "+79997654321",
+		ServiceCenterTime:      parseTimestamp("2022-02-16T15:54:47+01:00"),
+		Text:                   "hello",
+		StatusReportIndication: true,
+		ReplyPathExists:        true,
+
+		UserDataStartsWithHeader: true,
+		UserDataHeader:           UserDataHeader{TotalNumber: 3, Sequence: 1, Tag: 5},
+	}
+	_, octets, err := deliver.PDU()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0xE4), octets[1]) // TP-MMS, TP-SRI, TP-UDHI and TP-RP
+	var msg Message
+	_, err = msg.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, deliver, msg)
+
+	report := smsReport
+	report.StatusReportQualificator = true
+	_, octets, err = report.PDU()
+	require.NoError(t, err)
+	_, err = msg.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.True(t, msg.StatusReportQualificator)
+}
+
+func TestSmsStatusReportParameters(t *testing.T) {
+	t.Parallel()
+
+	// the report without the parameter indicator
+	var msg Message
+	_, err := msg.ReadFromHex("07911326060032F006D60B911326880736F4111011719551401110117195714000")
+	require.NoError(t, err)
+	assert.Equal(t, byte(214), msg.MessageReference)
+	assert.EqualValues(t, "+31628870634", msg.Address)
+	assert.Equal(t, StatusCodes.CompletedReceived, msg.Status)
+	actual, _, err := msg.PDUHex()
+	require.NoError(t, err)
+	assert.Equal(t, "07911326060032F006D60B911326880736F4111011719551401110117195714000"+"00", actual)
+
+	for _, tc := range []struct {
+		name     string
+		report   func(msg *Message)
+		expected string
+	}{
+		{"protocol identifier", func(msg *Message) {
+			msg.ProtocolIdentifier = ProtocolIdentifiers.ReplaceType1
+		}, "0141"},
+		{"data coding scheme", func(msg *Message) {
+			msg.Encoding = Encodings.UCS2
+		}, "0208"},
+		{"text", func(msg *Message) {
+			msg.Text = "hi"
+		}, "0402E834"},
+		{"all", func(msg *Message) {
+			msg.ProtocolIdentifier = ProtocolIdentifiers.ReplaceType1
+			msg.Encoding = Encodings.UCS2
+			msg.Text = "hi"
+		}, "074108040068" + "0069"},
+		{"header", func(msg *Message) {
+			msg.Encoding = Encodings.Data8Bit
+			msg.SetPorts(2948, 9200)
+			msg.Data = []byte{0x01}
+		}, "0604080605040B8423F001"},
+	} {
+		report := smsReport
+		tc.report(&report)
+		actual, _, err := report.PDUHex()
+		require.NoError(t, err, tc.name)
+		// the optional parameters follow the status
+		assert.True(t, strings.HasSuffix(actual, "00"+tc.expected), tc.name)
+		var parsed Message
+		_, err = parsed.ReadFromHex(actual)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, report, parsed, tc.name)
+	}
+
+	// the extension octets of the parameter indicator are skipped
+	_, err = msg.ReadFromHex(pduStatusReport[:len(pduStatusReport)-2] + "8100" + "41")
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolIdentifiers.ReplaceType1, msg.ProtocolIdentifier)
+}
+
+func TestSmsFillBits(t *testing.T) {
+	t.Parallel()
+
+	for _, udh := range []*UserDataHeader{
+		nil,
+		{TotalNumber: 2, Sequence: 1, Tag: 5},
+		{TotalNumber: 2, Sequence: 1, Tag: 0x1234, Tag16Bit: true},
+		{DestinationPort: 2948, SourcePort: 9200},
+		{TotalNumber: 2, Sequence: 2, Tag: 5, DestinationPort: 2948, SourcePort: 9200},
+	} {
+		// the texts ending with <CR> are padded on the octet boundary, see pdu.Encode7Bit
+		for _, text := range []string{"hi", "hi\r", "hello\r", "1234567\r", "hello {world}"} {
+			msg := Message{
+				Type:              MessageTypes.Deliver,
+				Encoding:          Encodings.Gsm7Bit,
+				Address:           "+79997654321",
+				ServiceCenterTime: parseTimestamp("2022-02-16T15:54:47+01:00"),
+				Text:              text,
+			}
+			if udh != nil {
+				msg.UserDataStartsWithHeader = true
+				msg.UserDataHeader = *udh
+			}
+			_, octets, err := msg.PDU()
+			require.NoError(t, err, text)
+			var parsed Message
+			_, warnings, err := parsed.ReadFromMode(octets, DecodeModes.Strict)
+			require.NoError(t, err, text)
+			assert.Empty(t, warnings, text)
+			assert.Equal(t, msg, parsed, "%q with header %X", text, msg.UserDataHeader.Bytes())
+		}
+	}
+}
+
+func TestSmsZeroPadding(t *testing.T) {
+	t.Parallel()
+
+	// the text ends with '@' and the 7 spare bits are zeroes instead of <CR>
+	var msg Message
+	_, err := msg.ReadFromHex("00040B919799674523F1000022206151457440" + "0731D98C56B30100")
+	require.NoError(t, err)
+	assert.Equal(t, "123456@", msg.Text)
+
+	// the alphanumeric address ends with '@' as well
+	_, err = msg.ReadFromHex("00040DD031D98C56B30100000022206151457440" + "02E834")
+	require.NoError(t, err)
+	assert.Equal(t, PhoneNumber("123456@"), msg.Address)
+	assert.Equal(t, "hi", msg.Text)
+}
@@ -0,0 +1,110 @@
+package sms
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf16"
+
+	"github.com/xlab/at/pdu"
+)
+
+// maxParts is the maximum number of parts of a concatenated message.
+const maxParts = 0xFF
+
+// concatRef is the reference number of the last message split by Split.
+var concatRef uint32
+
+// Split splits the message into the concatenated parts if its user data doesn't fit into
+// a single message, the message itself is returned otherwise. Each part carries the concatenation
+// element in the user data header along with the other elements of the message. The reference
+// number is the tag of the header, the next one of the package-wide counter is used if it's zero.
+//
+// The parts are filled up to the limits left by the header, e.g. 153 septets, 67 UCS2 characters,
+// 134 ISO-8859-1 characters or 134 octets of 8-bit data with the 8-bit reference number and no other elements. The text is split
+// on the character boundaries, so the escaped characters of GSM 7-bit encoding and the surrogate pairs
+// of UCS2 encoding are never split across the parts.
+func Split(msg *Message) ([]Message, error) {
+	userData, _, err := msg.encodedUserData()
+	if err != nil {
+		return nil, err
+	}
+	if len(userData) <= maxUserDataLen {
+		return []Message{*msg}, nil
+	}
+
+	tpl := *msg
+	// the email header precedes the text of the first part
+	tpl.Text, tpl.Email = msg.text(), nil
+	tpl.UserDataStartsWithHeader = true
+	tpl.UserDataHeader.TotalNumber = 1
+	tpl.UserDataHeader.Sequence = 1
+	if tpl.UserDataHeader.Tag == 0 {
+		ref := atomic.AddUint32(&concatRef, 1)
+		if tpl.UserDataHeader.Tag16Bit {
+			tpl.UserDataHeader.Tag = int(uint16(ref))
+		} else {
+			tpl.UserDataHeader.Tag = int(byte(ref))
+		}
+	}
+	headerLen := len(tpl.UserDataHeader.Bytes())
+
+	var texts []string
+	var data [][]byte
+	switch tpl.DCS().Alphabet {
+	case Alphabets.Gsm7Bit:
+		locking, single := tpl.languages()
+		septets := (maxUserDataLen*8 - headerLen*8 - fillBits(headerLen)) / 7
+		texts = splitRunes(tpl.Text, septets, func(r rune) int {
+			return pdu.Len7BitWithTables(string(r), locking, single)
+		})
+	case Alphabets.UCS2:
+		texts = splitRunes(tpl.Text, (maxUserDataLen-headerLen)/2, func(r rune) int {
+			return len(utf16.Encode([]rune{r}))
+		})
+	case Alphabets.Latin1:
+		texts = splitRunes(tpl.Text, maxUserDataLen-headerLen, func(rune) int {
+			return 1
+		})
+	case Alphabets.Data8Bit:
+		size := maxUserDataLen - headerLen
+		for rest := tpl.Data; len(rest) > 0; {
+			n := size
+			if len(rest) < n {
+				n = len(rest)
+			}
+			data = append(data, rest[:n])
+			rest = rest[n:]
+		}
+	}
+	n := len(texts) + len(data)
+	if n > maxParts {
+		return nil, fmt.Errorf("%w: %d parts, the limit is %d", ErrUserDataTooLong, n, maxParts)
+	}
+
+	parts := make([]Message, n)
+	for i := range parts {
+		parts[i] = tpl
+		parts[i].UserDataHeader.TotalNumber = n
+		parts[i].UserDataHeader.Sequence = i + 1
+		if texts != nil {
+			parts[i].Text = texts[i]
+		} else {
+			parts[i].Data = data[i]
+		}
+	}
+	return parts, nil
+}
+
+// splitRunes splits the text into the parts of the given size, the size of each rune is given by the function.
+func splitRunes(text string, size int, runeSize func(r rune) int) []string {
+	var parts []string
+	var start, n int
+	for i, r := range text {
+		if n+runeSize(r) > size {
+			parts = append(parts, text[start:i])
+			start, n = i, 0
+		}
+		n += runeSize(r)
+	}
+	return append(parts, text[start:])
+}
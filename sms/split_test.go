@@ -0,0 +1,95 @@
+package sms
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		Type:     MessageTypes.Submit,
+		Encoding: Encodings.Gsm7Bit,
+		Address:  "+79991234567",
+		VPFormat: ValidityPeriodFormats.Relative,
+		Text:     strings.Repeat("a", 160),
+	}
+	parts, err := Split(&msg)
+	require.NoError(t, err)
+	assert.Equal(t, []Message{msg}, parts)
+
+	// the escaped character is moved to the next part as a whole
+	msg.Text = strings.Repeat("a", 152) + "{" + strings.Repeat("b", 10)
+	msg.UserDataHeader.Tag = 0x42
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, strings.Repeat("a", 152), parts[0].Text)
+	assert.Equal(t, "{"+strings.Repeat("b", 10), parts[1].Text)
+	for i, part := range parts {
+		assert.True(t, part.UserDataStartsWithHeader)
+		assert.Equal(t, UserDataHeader{TotalNumber: 2, Sequence: i + 1, Tag: 0x42}, part.UserDataHeader)
+		_, _, err = part.PDU()
+		require.NoError(t, err)
+	}
+
+	msg.Text = strings.Repeat("a", 306)
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Len(t, parts[0].Text, 153)
+	assert.NoError(t, parts[0].Validate())
+
+	// the 16-bit reference number takes one more octet
+	msg.UserDataHeader = UserDataHeader{Tag16Bit: true}
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	assert.Len(t, parts[0].Text, 152)
+	assert.NotZero(t, parts[0].UserDataHeader.Tag)
+	assert.Equal(t, parts[0].UserDataHeader.Tag, parts[2].UserDataHeader.Tag)
+
+	// the surrogate pairs aren't split
+	msg = Message{Type: MessageTypes.Submit, Encoding: Encodings.UCS2, Address: "+79991234567",
+		Text: strings.Repeat("ж", 66) + "😀" + "жжж"}
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, strings.Repeat("ж", 66), parts[0].Text)
+	assert.Equal(t, "😀жжж", parts[1].Text)
+
+	// the ISO-8859-1 text is split at an octet per character
+	msg = Message{Type: MessageTypes.Submit, Address: "+79991234567", Text: strings.Repeat("é", 134) + strings.Repeat("à", 10)}
+	msg.SetDCS(DCS{Alphabet: Alphabets.Latin1})
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, strings.Repeat("é", 134), parts[0].Text)
+	assert.Equal(t, strings.Repeat("à", 10), parts[1].Text)
+	for _, part := range parts {
+		assert.Nil(t, part.Data)
+		require.NoError(t, part.Validate())
+	}
+
+	// the application port addressing is kept in each part
+	data := bytes.Repeat([]byte{0xAB}, 200)
+	msg = Message{Type: MessageTypes.Submit, Encoding: Encodings.Data8Bit, Address: "+79991234567", Data: data}
+	msg.SetPorts(2948, 9200)
+	parts, err = Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Len(t, parts[0].Data, 128)
+	assert.Equal(t, data, append(append([]byte(nil), parts[0].Data...), parts[1].Data...))
+	dst, src, ok := parts[1].Ports()
+	assert.True(t, ok)
+	assert.Equal(t, [2]int{2948, 9200}, [2]int{dst, src})
+
+	msg.Data = bytes.Repeat([]byte{0xAB}, 128*256)
+	_, err = Split(&msg)
+	assert.ErrorIs(t, err, ErrUserDataTooLong)
+}
@@ -1,5 +1,7 @@
 package sms
 
+import "fmt"
+
 // StatusCategory
 type StatusCategory byte
 
@@ -37,6 +39,47 @@ func (s Status) Category() StatusCategory {
 	}
 }
 
+// IsTemporary returns true if the service center is still trying to transfer the message.
+func (s Status) IsTemporary() bool {
+	return s.Category() == StatusCategories.TemporaryError
+}
+
+// IsPermanent returns true if the message wasn't delivered and the service center is not making
+// any more transfer attempts, whether the error is permanent or temporary.
+func (s Status) IsPermanent() bool {
+	switch s.Category() {
+	case StatusCategories.PermanentError, StatusCategories.FinalError:
+		return true
+	}
+	return false
+}
+
+// Description returns the description of the status as in 3GPP TS 23.040, section 9.2.3.15,
+// e.g. "Quality of service not available".
+func (s Status) Description() string {
+	if desc, ok := statusDescriptions[s]; ok {
+		return desc
+	}
+	if s < 0x80 && s&0x1F >= 0x10 {
+		return "Value specific to the SC"
+	}
+	return "Reserved"
+}
+
+// String returns the category and the description of the status along with its code,
+// e.g. "temporary error: Quality of service not available (0x24)".
+func (s Status) String() string {
+	return fmt.Sprintf("%s: %s (0x%02X)", statusCategoryNames[s.Category()], s.Description(), byte(s))
+}
+
+var statusCategoryNames = map[StatusCategory]string{
+	StatusCategories.Complete:       "completed",
+	StatusCategories.TemporaryError: "temporary error",
+	StatusCategories.PermanentError: "permanent error",
+	StatusCategories.FinalError:     "temporary error, no more attempts",
+	StatusCategories.Unknown:        "unknown",
+}
+
 // StatusCodes represents possible values for the Status field in
 // SMS-STATUS-REPORT TPDUs.
 var StatusCodes = struct {
@@ -113,3 +156,34 @@ var StatusCodes = struct {
 
 	// 1000 0000 .. 1111 1111 // reserved
 }
+
+var statusDescriptions = map[Status]string{
+	StatusCodes.CompletedReceived: "Short message received by the SME",
+	StatusCodes.CompletedForwared: "Short message forwarded by the SC to the SME but the SC is unable to confirm delivery",
+	StatusCodes.CompletedReplaced: "Short message replaced by the SC",
+
+	StatusCodes.TemporaryCongestion:                   "Congestion",
+	StatusCodes.TemporaryBusy:                         "SME busy",
+	StatusCodes.TemporaryNoResponseFromRecipient:      "No response from SME",
+	StatusCodes.TemporaryServiceRejected:              "Service rejected",
+	StatusCodes.TemporaryQualityOfServiceNotAvailable: "Quality of service not available",
+	StatusCodes.TemporaryErrorInRecipient:             "Error in SME",
+
+	StatusCodes.PermanentRemoteProcedureError:         "Remote procedure error",
+	StatusCodes.PermanentIncompatibleDestination:      "Incompatible destination",
+	StatusCodes.PermanentConnectionRejected:           "Connection rejected by SME",
+	StatusCodes.PermanentNotObtainable:                "Not obtainable",
+	StatusCodes.PermanentQualityOfServiceNotAvailable: "Quality of service not available",
+	StatusCodes.PermanentNoInterworkingAvailable:      "No interworking available",
+	StatusCodes.PermanentValidityPeriodExpired:        "SM Validity Period Expired",
+	StatusCodes.PermanentDeletedBeSender:              "SM Deleted by originating SME",
+	StatusCodes.PermanentDeletedByAdministration:      "SM Deleted by SC Administration",
+	StatusCodes.PermanentUnknownMessage:               "SM does not exist",
+
+	StatusCodes.FinalCongestion:                   "Congestion",
+	StatusCodes.FinalBusy:                         "SME busy",
+	StatusCodes.FinalNoResponseFromRecipient:      "No response from SME",
+	StatusCodes.FinalServiceRejected:              "Service rejected",
+	StatusCodes.FinalQualityOfServiceNotAvailable: "Quality of service not available",
+	StatusCodes.FinalErrorInRecipient:             "Error in SME",
+}
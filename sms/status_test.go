@@ -71,3 +71,25 @@ func TestStatusCategorys(t *testing.T) {
 		}
 	})
 }
+
+func TestStatusDescription(t *testing.T) {
+	t.Parallel()
+
+	s := StatusCodes.TemporaryQualityOfServiceNotAvailable
+	assert.Equal(t, "Quality of service not available", s.Description())
+	assert.Equal(t, "temporary error: Quality of service not available (0x24)", s.String())
+	assert.True(t, s.IsTemporary())
+	assert.False(t, s.IsPermanent())
+
+	s = StatusCodes.FinalBusy
+	assert.Equal(t, "temporary error, no more attempts: SME busy (0x61)", s.String())
+	assert.False(t, s.IsTemporary())
+	assert.True(t, s.IsPermanent())
+
+	assert.True(t, StatusCodes.PermanentValidityPeriodExpired.IsPermanent())
+	assert.False(t, StatusCodes.CompletedReceived.IsPermanent())
+	assert.False(t, StatusCodes.CompletedReceived.IsTemporary())
+	assert.Equal(t, "unknown: Value specific to the SC (0x35)", Status(0x35).String())
+	assert.Equal(t, "Reserved", Status(0x27).Description())
+	assert.Equal(t, "Reserved", Status(0x90).Description())
+}
@@ -45,3 +45,27 @@ func TestTimestamp_ReadFrom(t *testing.T) {
 		assert.Equal(t, tc.expected, time.Time(subject).Format(time.RFC3339))
 	}
 }
+
+func TestTimestamp_QuarterHourOffsets(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		date     string
+		expected string
+	}{
+		{"2021-03-04T05:06:07+05:30", "12304050607022"},
+		{"2021-03-04T05:06:07+05:45", "12304050607032"},
+		{"2021-03-04T05:06:07+09:30", "12304050607083"},
+		{"2021-03-04T05:06:07+03:30", "12304050607041"},
+		{"2021-03-04T05:06:07-03:30", "12304050607049"},
+		{"2021-03-04T05:06:07+12:45", "12304050607015"},
+	} {
+		ts := parseTimestamp(tc.date)
+		octets := ts.PDU()
+		assert.Equal(t, tc.expected, util.HexString(octets), tc.date)
+
+		var parsed Timestamp
+		parsed.ReadFrom(octets)
+		assert.Equal(t, tc.date, time.Time(parsed).Format(time.RFC3339))
+	}
+}
@@ -1,12 +1,13 @@
-package sms
+package tpdu
 
 import (
 	"bytes"
 	"io"
 )
 
-// Low-level representation of an deliver-type SMS message (3GPP TS 23.040).
-type smsDeliver struct {
+// Deliver is the field-level representation of the SMS-DELIVER TPDU (3GPP TS 23.040, section 9.2.2.1),
+// the address, the time stamp and the user data are kept encoded as is.
+type Deliver struct {
 	MessageTypeIndicator    byte
 	MoreMessagesToSend      bool
 	LoopPrevention          bool
@@ -22,7 +23,8 @@ type smsDeliver struct {
 	UserData               []byte
 }
 
-func (s *smsDeliver) Bytes() []byte {
+// Bytes returns the encoded TPDU.
+func (s *Deliver) Bytes() []byte {
 	var buf bytes.Buffer
 	header := s.MessageTypeIndicator // 0-1 bits
 	if !s.MoreMessagesToSend {
@@ -32,13 +34,13 @@ func (s *smsDeliver) Bytes() []byte {
 		header |= 0x01 << 3 // 3 bit
 	}
 	if s.StatusReportIndication {
-		header |= 0x01 << 4 // 4 bit
+		header |= 0x01 << 5 // 5 bit
 	}
 	if s.UserDataHeaderIndicator {
-		header |= 0x01 << 5 // 5 bit
+		header |= 0x01 << 6 // 6 bit
 	}
 	if s.ReplyPath {
-		header |= 0x01 << 6 // 6 bit
+		header |= 0x01 << 7 // 7 bit
 	}
 	buf.WriteByte(header)
 	buf.Write(s.OriginatingAddress)
@@ -69,13 +71,15 @@ func (s *smsDeliver) Bytes() []byte {
 // The Address-Length field is an integer representation of the number of useful semi-octets
 // within the Address-Value field, i.e. excludes any semi octet containing only fill bits.
 
-func (s *smsDeliver) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
+// FromBytes decodes the TPDU and returns the number of octets read, the user data is cut to the available
+// octets if it's shorter than the user data length. The error is a *FieldError if a field can't be read.
+func (s *Deliver) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
 	buf := bytes.NewReader(octets)
-	*s = smsDeliver{}
+	*s = Deliver{}
 	header, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("first octet", n-1, err)
 	}
 	s.MessageTypeIndicator = header & 0x03
 	if header>>2&0x01 == 0x00 {
@@ -84,7 +88,7 @@ func (s *smsDeliver) FromBytes(octets []byte) (n int, err error) { //nolint:funl
 	if header>>3&0x01 == 0x01 {
 		s.LoopPrevention = true
 	}
-	if header>>4&0x01 == 0x01 {
+	if header>>5&0x01 == 0x01 {
 		s.StatusReportIndication = true
 	}
 
@@ -94,7 +98,7 @@ func (s *smsDeliver) FromBytes(octets []byte) (n int, err error) { //nolint:funl
 	oaLen, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("originating address", n-1, err)
 	}
 	buf.UnreadByte() // will read length again
 	n--
@@ -102,28 +106,28 @@ func (s *smsDeliver) FromBytes(octets []byte) (n int, err error) { //nolint:funl
 	off, err := io.ReadFull(buf, s.OriginatingAddress)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("originating address", n-off, err)
 	}
 	s.ProtocolIdentifier, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("protocol identifier", n-1, err)
 	}
 	s.DataCodingScheme, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("data coding scheme", n-1, err)
 	}
 	s.ServiceCentreTimestamp = make([]byte, 7)
 	off, err = io.ReadFull(buf, s.ServiceCentreTimestamp)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("service center time stamp", n-off, err)
 	}
 	s.UserDataLength, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("user data length", n-1, err)
 	}
 	s.UserData = make([]byte, int(s.UserDataLength))
 	off, _ = io.ReadFull(buf, s.UserData)
@@ -1,12 +1,15 @@
-package sms
+package tpdu
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 )
 
-// Low-level representation of an status-report-type SMS message (3GPP TS 23.040).
-type smsStatusReport struct {
+// StatusReport is the field-level representation of the SMS-STATUS-REPORT TPDU (3GPP TS 23.040,
+// section 9.2.2.3). The optional parameters follow the parameter indicator, Parameters is set
+// when the TPDU is decoded, while Bytes derives the indicator from the values of the parameters.
+type StatusReport struct {
 	MessageTypeIndicator     byte
 	MoreMessagesToSend       bool
 	LoopPrevention           bool
@@ -25,7 +28,8 @@ type smsStatusReport struct {
 	UserData               []byte
 }
 
-func (s *smsStatusReport) Bytes() []byte {
+// Bytes returns the encoded TPDU.
+func (s *StatusReport) Bytes() []byte {
 	var buf bytes.Buffer
 	header := s.MessageTypeIndicator // 0-1 bits
 	if !s.MoreMessagesToSend {
@@ -47,35 +51,41 @@ func (s *smsStatusReport) Bytes() []byte {
 	buf.Write(s.DischargeTimestamp)
 	buf.WriteByte(s.Status)
 
-	var trailer bytes.Buffer
+	// the parameter indicator is written even if no optional parameters follow,
+	// the default values are assumed for the absent TP-PID and TP-DCS then
 	var indicator byte
 	if s.ProtocolIdentifier != 0 {
 		indicator |= 0x01 << 0 // 0 bit
-		trailer.WriteByte(s.ProtocolIdentifier)
 	}
 	if s.DataCodingScheme != 0 {
 		indicator |= 0x01 << 1 // 1 bit
-		trailer.WriteByte(s.DataCodingScheme)
 	}
-	if s.UserDataHeaderIndicator {
+	if s.UserDataHeaderIndicator || s.UserDataLength > 0 {
 		indicator |= 0x01 << 2 // 2 bit
-		trailer.WriteByte(s.UserDataLength)
-		trailer.Write(s.UserData)
 	}
 	buf.WriteByte(indicator)
-	if indicator != 0 {
-		trailer.WriteTo(&buf)
+	if indicator&0x01 != 0 {
+		buf.WriteByte(s.ProtocolIdentifier)
+	}
+	if indicator&0x02 != 0 {
+		buf.WriteByte(s.DataCodingScheme)
+	}
+	if indicator&0x04 != 0 {
+		buf.WriteByte(s.UserDataLength)
+		buf.Write(s.UserData)
 	}
 	return buf.Bytes()
 }
 
-func (s *smsStatusReport) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
+// FromBytes decodes the TPDU and returns the number of octets read, see Deliver.FromBytes.
+// The parameter indicator and the optional parameters may be absent.
+func (s *StatusReport) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
 	buf := bytes.NewReader(octets)
-	*s = smsStatusReport{}
+	*s = StatusReport{}
 	header, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("first octet", n-1, err)
 	}
 	s.MessageTypeIndicator = header & 0x03
 	if header>>2&0x01 == 0x00 {
@@ -84,7 +94,7 @@ func (s *smsStatusReport) FromBytes(octets []byte) (n int, err error) { //nolint
 	if header>>3&0x01 == 0x01 {
 		s.LoopPrevention = true
 	}
-	if header>>4&0x01 == 0x01 {
+	if header>>5&0x01 == 0x01 {
 		s.StatusReportQualificator = true
 	}
 	s.UserDataHeaderIndicator = header&(0x01<<6) != 0
@@ -92,16 +102,16 @@ func (s *smsStatusReport) FromBytes(octets []byte) (n int, err error) { //nolint
 	s.MessageReference, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("message reference", n-1, err)
 	}
 
 	daLen, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("recipient address", n-1, err)
 	}
 	if daLen > 16 {
-		return n, ErrIncorrectSize
+		return n, fieldError("recipient address", n-1, fmt.Errorf("%w: %d digits", ErrIncorrectSize, daLen))
 	}
 	buf.UnreadByte() // will read length again
 	n--
@@ -109,49 +119,56 @@ func (s *smsStatusReport) FromBytes(octets []byte) (n int, err error) { //nolint
 	off, err := io.ReadFull(buf, s.DestinationAddress)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("recipient address", n-off, err)
 	}
 	s.ServiceCentreTimestamp = make([]byte, 7)
 	off, err = io.ReadFull(buf, s.ServiceCentreTimestamp)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("service center time stamp", n-off, err)
 	}
 	s.DischargeTimestamp = make([]byte, 7)
 	off, err = io.ReadFull(buf, s.DischargeTimestamp)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("discharge time", n-off, err)
 	}
 	s.Status, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("status", n-1, err)
 	}
 	s.Parameters, err = buf.ReadByte()
 	n++
 	if err != nil {
+		// the parameter indicator is optional
 		return n - 1, nil
 	}
+	// the extension octets of the parameter indicator have no parameters defined
+	for ext := s.Parameters; ext&0x80 != 0; n++ {
+		if ext, err = buf.ReadByte(); err != nil {
+			return n, fieldError("parameter indicator", n, err)
+		}
+	}
 	if s.Parameters&0x01 != 0 {
 		s.ProtocolIdentifier, err = buf.ReadByte()
 		n++
 		if err != nil {
-			return
+			return n, fieldError("protocol identifier", n-1, err)
 		}
 	}
 	if s.Parameters&0x02 != 0 {
 		s.DataCodingScheme, err = buf.ReadByte()
 		n++
 		if err != nil {
-			return
+			return n, fieldError("data coding scheme", n-1, err)
 		}
 	}
 	if s.Parameters&0x04 != 0 {
 		s.UserDataLength, err = buf.ReadByte()
 		n++
 		if err != nil {
-			return
+			return n, fieldError("user data length", n-1, err)
 		}
 		s.UserData = make([]byte, int(s.UserDataLength))
 		off, _ = io.ReadFull(buf, s.UserData)
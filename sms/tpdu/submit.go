@@ -1,12 +1,14 @@
-package sms
+package tpdu
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 )
 
-// Low-level representation of an submit-type SMS message (3GPP TS 23.040).
-type smsSubmit struct {
+// Submit is the field-level representation of the SMS-SUBMIT TPDU (3GPP TS 23.040, section 9.2.2.2),
+// the address, the validity period and the user data are kept encoded as is.
+type Submit struct {
 	MessageTypeIndicator    byte
 	RejectDuplicates        bool
 	ValidityPeriodFormat    byte
@@ -18,12 +20,13 @@ type smsSubmit struct {
 	DestinationAddress []byte
 	ProtocolIdentifier byte
 	DataCodingScheme   byte
-	ValidityPeriod     byte
+	ValidityPeriod     []byte
 	UserDataLength     byte
 	UserData           []byte
 }
 
-func (s *smsSubmit) Bytes() []byte {
+// Bytes returns the encoded TPDU.
+func (s *Submit) Bytes() []byte {
 	var buf bytes.Buffer
 	header := s.MessageTypeIndicator // 0-1 bits
 	if s.RejectDuplicates {
@@ -44,21 +47,22 @@ func (s *smsSubmit) Bytes() []byte {
 	buf.Write(s.DestinationAddress)
 	buf.WriteByte(s.ProtocolIdentifier)
 	buf.WriteByte(s.DataCodingScheme)
-	if ValidityPeriodFormat(s.ValidityPeriodFormat) != ValidityPeriodFormats.FieldNotPresent {
-		buf.WriteByte(s.ValidityPeriod)
+	if s.ValidityPeriodFormat != vpfNotPresent {
+		buf.Write(s.ValidityPeriod)
 	}
 	buf.WriteByte(s.UserDataLength)
 	buf.Write(s.UserData)
 	return buf.Bytes()
 }
 
-func (s *smsSubmit) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
-	*s = smsSubmit{}
+// FromBytes decodes the TPDU and returns the number of octets read, see Deliver.FromBytes.
+func (s *Submit) FromBytes(octets []byte) (n int, err error) { //nolint:funlen
+	*s = Submit{}
 	buf := bytes.NewReader(octets)
 	header, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("first octet", n-1, err)
 	}
 	s.MessageTypeIndicator = header & 0x03
 	if header&(0x01<<2) > 0 {
@@ -77,15 +81,15 @@ func (s *smsSubmit) FromBytes(octets []byte) (n int, err error) { //nolint:funle
 	s.MessageReference, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("message reference", n-1, err)
 	}
 	daLen, err := buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("destination address", n-1, err)
 	}
 	if daLen > 16 {
-		return n, ErrIncorrectSize
+		return n, fieldError("destination address", n-1, fmt.Errorf("%w: %d digits", ErrIncorrectSize, daLen))
 	}
 	buf.UnreadByte() // read length again
 	n--
@@ -93,29 +97,33 @@ func (s *smsSubmit) FromBytes(octets []byte) (n int, err error) { //nolint:funle
 	off, err := io.ReadFull(buf, s.DestinationAddress)
 	n += off
 	if err != nil {
-		return
+		return n, fieldError("destination address", n-off, err)
 	}
 	s.ProtocolIdentifier, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("protocol identifier", n-1, err)
 	}
 	s.DataCodingScheme, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("data coding scheme", n-1, err)
 	}
-	if ValidityPeriodFormat(s.ValidityPeriodFormat) != ValidityPeriodFormats.FieldNotPresent {
-		s.ValidityPeriod, err = buf.ReadByte()
-		n++
-		if err != nil {
-			return
-		}
+	switch s.ValidityPeriodFormat {
+	case vpfRelative:
+		s.ValidityPeriod = make([]byte, 1)
+	case vpfAbsolute, vpfEnhanced:
+		s.ValidityPeriod = make([]byte, 7)
+	}
+	off, err = io.ReadFull(buf, s.ValidityPeriod)
+	n += off
+	if err != nil {
+		return n, fieldError("validity period", n-off, err)
 	}
 	s.UserDataLength, err = buf.ReadByte()
 	n++
 	if err != nil {
-		return
+		return n, fieldError("user data length", n-1, err)
 	}
 	s.UserData = make([]byte, int(s.UserDataLength))
 	off, _ = io.ReadFull(buf, s.UserData)
@@ -0,0 +1,55 @@
+// Package tpdu provides the field-level representations of the transfer protocol data units
+// of SMS as specified in 3GPP TS 23.040, i.e. the TPDUs with the fields kept as close to the wire
+// as possible. It allows to manipulate the fields the high-level sms.Message doesn't expose,
+// e.g. to emulate the service center or to craft the malformed PDUs for testing.
+// The TPDUs follow the SMSC information of the PDUs, which is not handled here.
+package tpdu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The values of TP-Validity-Period-Format, see sms.ValidityPeriodFormats.
+const (
+	vpfNotPresent = 0x00
+	vpfEnhanced   = 0x01
+	vpfRelative   = 0x02
+	vpfAbsolute   = 0x03
+)
+
+// ErrIncorrectSize is returned when the length of a field is out of range.
+var ErrIncorrectSize = errors.New("tpdu: decoded incorrect size of field")
+
+// FieldError is returned when a field of the TPDU can't be read. The offset is the one of the octet
+// the field starts at, counted from the start of the TPDU.
+type FieldError struct {
+	Field  string
+	Offset int
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("tpdu: %s at offset %d: %v", e.Field, e.Offset, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError wraps the error of the field that starts at the given offset,
+// the TPDU that ends before the field is truncated.
+func fieldError(field string, offset int, err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return &FieldError{Field: field, Offset: offset, Err: err}
+}
+
+func blocks(n, block int) int {
+	if n%block == 0 {
+		return n / block
+	}
+	return n/block + 1
+}
@@ -0,0 +1,77 @@
+package tpdu
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/util"
+)
+
+func TestDeliver(t *testing.T) {
+	t.Parallel()
+
+	octets := util.MustBytes("040B919762995696F0000041606291401561066379180E8200")
+	var d Deliver
+	n, err := d.FromBytes(octets)
+	require.NoError(t, err)
+	assert.Equal(t, len(octets), n)
+	assert.Equal(t, util.MustBytes("0B919762995696F0"), d.OriginatingAddress)
+	assert.Equal(t, byte(6), d.UserDataLength)
+	assert.False(t, d.MoreMessagesToSend)
+
+	// the fields the high-level message doesn't expose are kept as is
+	d.DataCodingScheme = 0xFF
+	d.MoreMessagesToSend = true
+	var parsed Deliver
+	_, err = parsed.FromBytes(d.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, d, parsed)
+}
+
+func TestSubmit(t *testing.T) {
+	t.Parallel()
+
+	octets := util.MustBytes("11000B919762995696F00008AA0C043F04400438043204350442")
+	var s Submit
+	n, err := s.FromBytes(octets)
+	require.NoError(t, err)
+	assert.Equal(t, len(octets), n)
+	assert.Equal(t, byte(vpfRelative), s.ValidityPeriodFormat)
+	assert.Equal(t, []byte{0xAA}, s.ValidityPeriod)
+	assert.Equal(t, octets, s.Bytes())
+
+	_, err = s.FromBytes(util.MustBytes("1100110B919762995696F0"))
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "destination address", fieldErr.Field)
+	assert.Equal(t, 2, fieldErr.Offset)
+	assert.ErrorIs(t, err, ErrIncorrectSize)
+	assert.EqualError(t, err, "tpdu: destination address at offset 2: tpdu: decoded incorrect size of field: 17 digits")
+
+	_, err = s.FromBytes(util.MustBytes("1100"))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestStatusReport(t *testing.T) {
+	t.Parallel()
+
+	octets := util.MustBytes("06360d91947106000000f122206151457440222061514584400000")
+	var r StatusReport
+	n, err := r.FromBytes(octets)
+	require.NoError(t, err)
+	assert.Equal(t, len(octets), n)
+	assert.Equal(t, byte(54), r.MessageReference)
+	assert.Equal(t, octets, r.Bytes())
+
+	// the parameter indicator is optional
+	n, err = r.FromBytes(octets[:len(octets)-1])
+	require.NoError(t, err)
+	assert.Equal(t, len(octets)-1, n)
+
+	r.ProtocolIdentifier = 0x41
+	r.UserDataLength = 1
+	r.UserData = []byte{0x31}
+	assert.Equal(t, append(octets[:len(octets)-1:len(octets)-1], 0x05, 0x41, 0x01, 0x31), r.Bytes())
+}
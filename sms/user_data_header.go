@@ -1,22 +1,207 @@
 package sms
 
+import "github.com/xlab/at/pdu"
+
+// IEI represents the identifier of an information element of the user data header.
+type IEI byte
+
+// IEIs represent the known identifiers of the information elements (3GPP TS 23.040, section 9.2.3.24).
+var IEIs = struct {
+	Concatenated8Bit     IEI
+	SpecialMessage       IEI
+	ApplicationPort8Bit  IEI
+	ApplicationPort16Bit IEI
+	SMSCControl          IEI
+	SourceIndicator      IEI
+	Concatenated16Bit    IEI
+	WirelessControl      IEI
+	TextFormatting       IEI
+	PredefinedSound      IEI
+	UserDefinedSound     IEI
+	LargePicture         IEI
+	SmallPicture         IEI
+	VariablePicture      IEI
+	NationalSingleShift  IEI
+	NationalLockingShift IEI
+}{
+	0x00, 0x01, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A,
+	0x0B, 0x0C, 0x10, 0x11, 0x12,
+	0x24, 0x25,
+}
+
+// InformationElement represents a single information element of the user data header.
+type InformationElement struct {
+	ID   IEI    `json:"id"`
+	Data []byte `json:"data"`
+}
+
+// UserDataHeader represents the information elements of the user data header.
+// The concatenation information is set if TotalNumber is not zero,
+// the application port addressing is set if any of the ports is not zero.
 type UserDataHeader struct {
-	TotalNumber int
-	Sequence    int
-	Tag         int
+	TotalNumber int `json:"total_number,omitempty"`
+	Sequence    int `json:"sequence,omitempty"`
+	Tag         int `json:"tag,omitempty"`
+	// Tag16Bit selects the concatenation element with 16-bit reference number,
+	// it's used anyway if the tag doesn't fit into a single octet.
+	Tag16Bit bool `json:"tag_16bit,omitempty"`
+
+	// DestinationPort and SourcePort address the application the message
+	// is intended for, e.g. 2948 for WAP Push or 9200 for a WAP connectionless session.
+	DestinationPort int `json:"destination_port,omitempty"`
+	SourcePort      int `json:"source_port,omitempty"`
+	// Ports8Bit selects the application port addressing element with 8-bit addresses,
+	// it's ignored if any of the ports doesn't fit into a single octet.
+	Ports8Bit bool `json:"ports_8bit,omitempty"`
+
+	// SingleShift and LockingShift select the national language shift tables
+	// of the GSM 7-bit encoded text, the default tables are used if they're zero.
+	SingleShift  pdu.Language `json:"single_shift,omitempty"`
+	LockingShift pdu.Language `json:"locking_shift,omitempty"`
+
+	// Elements are the other information elements in order of appearance,
+	// they are preserved as is when the header is encoded again.
+	Elements []InformationElement `json:"elements,omitempty"`
+
+	// order is the order of appearance of the decoded elements,
+	// it's set only if it differs from the one of InformationElements.
+	order []IEI
 }
 
 func (udh *UserDataHeader) ReadFrom(octets []byte) error {
+	*udh = UserDataHeader{}
 	octetsLng := len(octets)
+	if octetsLng == 0 {
+		return ErrIncorrectUserDataHeaderLength
+	}
 	headerLng := int(octets[0]) + 1
-	if (octetsLng-headerLng) <= 0 || headerLng <= 5 {
+	if octetsLng < headerLng || headerLng < 3 {
 		return ErrIncorrectUserDataHeaderLength
 	}
 
-	h := octets[:headerLng]
-	udh.Sequence = int(h[5])
-	udh.TotalNumber = int(h[4])
-	udh.Tag = int(h[3])
+	var order []IEI
+	h := octets[1:headerLng]
+	for len(h) > 0 {
+		if len(h) < 2 || len(h) < int(h[1])+2 {
+			return ErrIncorrectUserDataHeaderLength
+		}
+		id, ie := IEI(h[0]), h[2:int(h[1])+2]
+		h = h[len(ie)+2:]
+		order = append(order, id)
+		switch {
+		case id == IEIs.Concatenated8Bit && len(ie) == 3:
+			udh.Tag = int(ie[0])
+			udh.TotalNumber = int(ie[1])
+			udh.Sequence = int(ie[2])
+		case id == IEIs.Concatenated16Bit && len(ie) == 4:
+			udh.Tag = int(ie[0])<<8 | int(ie[1])
+			udh.Tag16Bit = true
+			udh.TotalNumber = int(ie[2])
+			udh.Sequence = int(ie[3])
+		case id == IEIs.ApplicationPort8Bit && len(ie) == 2:
+			udh.DestinationPort = int(ie[0])
+			udh.SourcePort = int(ie[1])
+			udh.Ports8Bit = true
+		case id == IEIs.ApplicationPort16Bit && len(ie) == 4:
+			udh.DestinationPort = int(ie[0])<<8 | int(ie[1])
+			udh.SourcePort = int(ie[2])<<8 | int(ie[3])
+		case id == IEIs.NationalSingleShift && len(ie) == 1:
+			udh.SingleShift = pdu.Language(ie[0])
+		case id == IEIs.NationalLockingShift && len(ie) == 1:
+			udh.LockingShift = pdu.Language(ie[0])
+		default:
+			data := append([]byte(nil), ie...)
+			udh.Elements = append(udh.Elements, InformationElement{ID: id, Data: data})
+		}
+	}
 
+	// the header is encoded again in the same order, e.g. the application
+	// port addressing often precedes the concatenation in WAP Push messages
+	ies := udh.InformationElements()
+	if len(ies) != len(order) {
+		udh.order = order
+		return nil
+	}
+	for i := range ies {
+		if ies[i].ID != order[i] {
+			udh.order = order
+			break
+		}
+	}
 	return nil
 }
+
+// InformationElements returns all information elements of the header: the concatenated short
+// message information element with 8-bit or 16-bit reference number (sections 9.2.3.24.1
+// and 9.2.3.24.8), the application port addressing scheme with 8-bit or 16-bit addresses
+// (sections 9.2.3.24.3 and 9.2.3.24.4), the national language shifts (sections 9.2.3.24.15
+// and 9.2.3.24.16) and the other elements.
+func (udh *UserDataHeader) InformationElements() []InformationElement {
+	var ies []InformationElement
+	switch {
+	case udh.TotalNumber == 0:
+	case udh.Tag16Bit || udh.Tag > 0xFF:
+		ies = append(ies, InformationElement{IEIs.Concatenated16Bit, []byte{
+			byte(udh.Tag >> 8), byte(udh.Tag), byte(udh.TotalNumber), byte(udh.Sequence),
+		}})
+	default:
+		ies = append(ies, InformationElement{IEIs.Concatenated8Bit, []byte{
+			byte(udh.Tag), byte(udh.TotalNumber), byte(udh.Sequence),
+		}})
+	}
+	switch {
+	case udh.DestinationPort == 0 && udh.SourcePort == 0:
+	case udh.Ports8Bit && udh.DestinationPort <= 0xFF && udh.SourcePort <= 0xFF:
+		ies = append(ies, InformationElement{IEIs.ApplicationPort8Bit, []byte{
+			byte(udh.DestinationPort), byte(udh.SourcePort),
+		}})
+	default:
+		ies = append(ies, InformationElement{IEIs.ApplicationPort16Bit, []byte{
+			byte(udh.DestinationPort >> 8), byte(udh.DestinationPort),
+			byte(udh.SourcePort >> 8), byte(udh.SourcePort),
+		}})
+	}
+	if udh.SingleShift != pdu.Languages.Default {
+		ies = append(ies, InformationElement{IEIs.NationalSingleShift, []byte{byte(udh.SingleShift)}})
+	}
+	if udh.LockingShift != pdu.Languages.Default {
+		ies = append(ies, InformationElement{IEIs.NationalLockingShift, []byte{byte(udh.LockingShift)}})
+	}
+	ies = append(ies, udh.Elements...)
+	if udh.order == nil {
+		return ies
+	}
+	// the elements of the decoded header keep their order, the added ones follow them
+	ordered := make([]InformationElement, 0, len(ies))
+	for _, id := range udh.order {
+		for i := range ies {
+			if ies[i].ID == id {
+				ordered = append(ordered, ies[i])
+				ies = append(ies[:i], ies[i+1:]...)
+				break
+			}
+		}
+	}
+	return append(ordered, ies...)
+}
+
+// Element returns the first information element with the given identifier.
+func (udh *UserDataHeader) Element(id IEI) (ie InformationElement, ok bool) {
+	for _, ie := range udh.InformationElements() {
+		if ie.ID == id {
+			return ie, true
+		}
+	}
+	return InformationElement{}, false
+}
+
+// Bytes returns the user data header that consists of the information elements, see InformationElements.
+func (udh *UserDataHeader) Bytes() []byte {
+	h := []byte{0x00}
+	for _, ie := range udh.InformationElements() {
+		h = append(h, byte(ie.ID), byte(len(ie.Data)))
+		h = append(h, ie.Data...)
+	}
+	h[0] = byte(len(h) - 1)
+	return h
+}
@@ -0,0 +1,134 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/pdu"
+	"github.com/xlab/at/util"
+)
+
+func TestUserDataHeader(t *testing.T) {
+	t.Parallel()
+
+	// the special message indication, the concatenation and a reserved element
+	header := util.MustBytes("0E" + "01020104" + "0003420302" + "7003AABBCC")
+	var udh UserDataHeader
+	require.NoError(t, udh.ReadFrom(append(header, 0x00)))
+	assert.Equal(t, 3, udh.TotalNumber)
+	assert.Equal(t, 2, udh.Sequence)
+	assert.Equal(t, 0x42, udh.Tag)
+	assert.Equal(t, []InformationElement{
+		{IEIs.SpecialMessage, []byte{0x01, 0x04}},
+		{0x70, []byte{0xAA, 0xBB, 0xCC}},
+	}, udh.Elements)
+	assert.Len(t, udh.InformationElements(), 3)
+	ie, ok := udh.Element(IEIs.SpecialMessage)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x01, 0x04}, ie.Data)
+	_, ok = udh.Element(IEIs.ApplicationPort16Bit)
+	assert.False(t, ok)
+
+	// the decoded elements keep their order
+	assert.Equal(t, header, udh.Bytes())
+	// the well-known elements go first otherwise
+	built := UserDataHeader{TotalNumber: 3, Sequence: 2, Tag: 0x42, Elements: udh.Elements}
+	assert.Equal(t, util.MustBytes("0E"+"0003420302"+"01020104"+"7003AABBCC"), built.Bytes())
+
+	assert.Equal(t, ErrIncorrectUserDataHeaderLength, udh.ReadFrom(util.MustBytes("0400030102FF")))
+	assert.Equal(t, ErrIncorrectUserDataHeaderLength, udh.ReadFrom(util.MustBytes("05000301")))
+}
+
+func TestUserDataHeaderConcatenated16Bit(t *testing.T) {
+	t.Parallel()
+
+	var udh UserDataHeader
+	require.NoError(t, udh.ReadFrom(util.MustBytes("060804BEEF0201"+"00")))
+	assert.Equal(t, UserDataHeader{TotalNumber: 2, Sequence: 1, Tag: 0xBEEF, Tag16Bit: true}, udh)
+	assert.Equal(t, util.MustBytes("060804BEEF0201"), udh.Bytes())
+
+	// the tag doesn't fit into a single octet
+	udh = UserDataHeader{TotalNumber: 2, Sequence: 2, Tag: 0x1234}
+	assert.Equal(t, util.MustBytes("06080412340202"), udh.Bytes())
+
+	for _, enc := range []Encoding{Encodings.Gsm7Bit, Encodings.UCS2} {
+		msg := Message{
+			Text:                     "hello world {concatenated}",
+			Type:                     MessageTypes.Submit,
+			Encoding:                 enc,
+			Address:                  "+79997654321",
+			UserDataStartsWithHeader: true,
+			UserDataHeader:           UserDataHeader{TotalNumber: 3, Sequence: 3, Tag: 0x4242, Tag16Bit: true},
+		}
+		_, octets, err := msg.PDU()
+		require.NoError(t, err)
+		var parsed Message
+		_, err = parsed.ReadFrom(octets)
+		require.NoError(t, err)
+		assert.Equal(t, msg, parsed)
+	}
+}
+
+func TestUserDataHeaderPorts(t *testing.T) {
+	t.Parallel()
+
+	var msg Message
+	_, err := msg.ReadFrom(util.MustBytes("00440B919799674523F1000422206151457440" + "0604040210F501"))
+	require.NoError(t, err)
+	dst, src, ok := msg.Ports()
+	assert.True(t, ok)
+	assert.Equal(t, 0x10, dst)
+	assert.Equal(t, 0xF5, src)
+	assert.Equal(t, []byte{0x01}, msg.Data)
+	assert.Equal(t, util.MustBytes("04040210F5"), msg.UserDataHeader.Bytes())
+
+	// the 8-bit addressing can't hold the port
+	msg.SetPorts(2948, 0xF5)
+	assert.Equal(t, util.MustBytes("0605040B8400F5"), msg.UserDataHeader.Bytes())
+
+	msg = Message{}
+	_, _, ok = msg.Ports()
+	assert.False(t, ok)
+}
+
+func TestUserDataHeaderNationalLanguage(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		Text:                     "Güzel bir gün, İstanbul'da çay içtik",
+		Type:                     MessageTypes.Submit,
+		Encoding:                 Encodings.Gsm7Bit,
+		Address:                  "+79997654321",
+		UserDataStartsWithHeader: true,
+		UserDataHeader: UserDataHeader{
+			SingleShift:  pdu.Languages.Turkish,
+			LockingShift: pdu.Languages.Turkish,
+		},
+	}
+	assert.Equal(t, util.MustBytes("06240101250101"), msg.UserDataHeader.Bytes())
+	_, octets, err := msg.PDU()
+	require.NoError(t, err)
+	var parsed Message
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, msg, parsed)
+
+	// the national characters are lost without the shift tables
+	parsed.UserDataStartsWithHeader = false
+	parsed.UserDataHeader = UserDataHeader{}
+	_, octets, err = parsed.PDU()
+	require.NoError(t, err)
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.NotEqual(t, msg.Text, parsed.Text)
+
+	msg.Text = "नमस्ते, आज कैसे हैं? १२३"
+	msg.UserDataHeader = UserDataHeader{SingleShift: pdu.Languages.Hindi, LockingShift: pdu.Languages.Hindi}
+	require.NoError(t, msg.Validate())
+	_, octets, err = msg.PDU()
+	require.NoError(t, err)
+	_, err = parsed.ReadFrom(octets)
+	require.NoError(t, err)
+	assert.Equal(t, msg, parsed)
+}
@@ -0,0 +1,163 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xlab/at/pdu"
+)
+
+// Validation errors, the errors returned by Validate wrap them with the details.
+var (
+	ErrMissingAddress        = errors.New("sms: address is empty")
+	ErrInvalidAddress        = errors.New("sms: invalid address")
+	ErrInvalidValidityPeriod = errors.New("sms: validity period doesn't match its format")
+	ErrEncodingMismatch      = errors.New("sms: content doesn't match the encoding")
+	ErrNotEncodable          = errors.New("sms: text can't be encoded with GSM 7-bit alphabet")
+	ErrUserDataTooLong       = errors.New("sms: user data doesn't fit into a single message")
+)
+
+const (
+	// maxUserDataLen is the maximum number of user data octets in a single message.
+	maxUserDataLen = 140
+	// maxAddressDigits is the maximum number of digits in a numeric address.
+	maxAddressDigits = 20
+	// maxRelativeVP is the longest relative validity period, i.e. 63 weeks.
+	maxRelativeVP = ValidityPeriod(63 * 7 * 24 * time.Hour)
+)
+
+// Validate checks the combinations of the fields before the message is encoded with PDU,
+// so the message could be fixed instead of being rejected by the modem or the service center,
+// or silently changed by the encoding. The message must fit into a single PDU, the text
+// that doesn't fit should be split into concatenated parts.
+func (s *Message) Validate() error {
+	switch s.Type {
+	case MessageTypes.Deliver, MessageTypes.Submit, MessageTypes.StatusReport:
+	default:
+		return ErrUnknownMessageType
+	}
+	if s.Address == "" {
+		return ErrMissingAddress
+	}
+	if err := validateAddress(s.Address); err != nil {
+		return err
+	}
+	if s.ServiceCenterAddress != "" {
+		if s.ServiceCenterAddress.Alphanumeric() {
+			return fmt.Errorf("%w: service center address %q must be a number",
+				ErrInvalidAddress, s.ServiceCenterAddress)
+		}
+		if err := validateAddress(s.ServiceCenterAddress); err != nil {
+			return err
+		}
+	}
+	if s.Type == MessageTypes.Submit {
+		if err := s.validateValidityPeriod(); err != nil {
+			return err
+		}
+	}
+	return s.validateUserData()
+}
+
+// validateAddress checks the address is encodable, the numbers may contain
+// the formatting characters like spaces and dashes which are skipped.
+func validateAddress(addr PhoneNumber) error {
+	if addr.Alphanumeric() {
+		n, invalid := pdu.Check7Bit(string(addr))
+		if n > maxAlphanumericLen {
+			return fmt.Errorf("%w: %q takes %d septets", ErrAddressTooLong, addr, n)
+		}
+		if invalid >= 0 {
+			return fmt.Errorf("%w: %q can't be encoded with GSM 7-bit alphabet", ErrInvalidAddress, addr)
+		}
+		return nil
+	}
+	var digits int
+	for i, r := range string(addr) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0:
+		case strings.ContainsRune(" -()", r):
+		default:
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidAddress, addr, r)
+		}
+	}
+	if digits == 0 || digits > maxAddressDigits {
+		return fmt.Errorf("%w: %q must have from 1 to %d digits", ErrInvalidAddress, addr, maxAddressDigits)
+	}
+	return nil
+}
+
+func (s *Message) validateValidityPeriod() error {
+	switch s.VPFormat {
+	case ValidityPeriodFormats.FieldNotPresent:
+		if s.VP != 0 || !time.Time(s.VPTime).IsZero() {
+			return fmt.Errorf("%w: the validity period is set, but VPFormat is FieldNotPresent",
+				ErrInvalidValidityPeriod)
+		}
+	case ValidityPeriodFormats.Relative:
+		if s.VP < 0 || s.VP > maxRelativeVP {
+			return fmt.Errorf("%w: the relative validity period %v must be from 0 to %v",
+				ErrInvalidValidityPeriod, time.Duration(s.VP), time.Duration(maxRelativeVP))
+		}
+	case ValidityPeriodFormats.Absolute:
+		if time.Time(s.VPTime).IsZero() {
+			return fmt.Errorf("%w: VPTime must be set with the absolute format", ErrInvalidValidityPeriod)
+		}
+	case ValidityPeriodFormats.Enhanced:
+		return ErrNonRelative
+	default:
+		return fmt.Errorf("%w: unknown format %d", ErrInvalidValidityPeriod, s.VPFormat)
+	}
+	return nil
+}
+
+func (s *Message) validateUserData() error {
+	dcs := s.DCS()
+	if dcs.Compressed {
+		return ErrCompressed
+	}
+	switch dcs.Alphabet {
+	case Alphabets.Data8Bit:
+		if s.Text != "" {
+			return fmt.Errorf("%w: the text is set with 8-bit data encoding, use Data instead",
+				ErrEncodingMismatch)
+		}
+	case Alphabets.Gsm7Bit:
+		if len(s.Data) > 0 {
+			return fmt.Errorf("%w: the data is set with GSM 7-bit encoding", ErrEncodingMismatch)
+		}
+		locking, single := s.languages()
+		if _, invalid := pdu.Check7BitWithTables(s.text(), locking, single); invalid >= 0 {
+			r, _ := utf8.DecodeRuneInString(s.text()[invalid:])
+			return fmt.Errorf("%w: %q at offset %d, use UCS2 encoding instead", ErrNotEncodable, r, invalid)
+		}
+	case Alphabets.UCS2:
+		if len(s.Data) > 0 {
+			return fmt.Errorf("%w: the data is set with UCS2 encoding", ErrEncodingMismatch)
+		}
+	case Alphabets.Latin1:
+		if len(s.Data) > 0 {
+			return fmt.Errorf("%w: the data is set with ISO-8859-1 text", ErrEncodingMismatch)
+		}
+		for i, r := range s.text() {
+			if r > 0xFF {
+				return fmt.Errorf("%w: %q at offset %d isn't ISO-8859-1, use UCS2 encoding instead", ErrNotEncodable, r, i)
+			}
+		}
+	default:
+		return ErrUnknownEncoding
+	}
+	userData, _, err := s.encodedUserData()
+	if err != nil {
+		return err
+	}
+	if len(userData) > maxUserDataLen {
+		return fmt.Errorf("%w: %d octets, the limit is %d", ErrUserDataTooLong, len(userData), maxUserDataLen)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := Message{
+		Type:     MessageTypes.Submit,
+		Encoding: Encodings.Gsm7Bit,
+		Address:  "+7 (999) 123-45-67",
+		VPFormat: ValidityPeriodFormats.Relative,
+		VP:       ValidityPeriod(time.Hour),
+		Text:     strings.Repeat("a", 160),
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(msg *Message)
+		err    error
+	}{
+		{"type", func(msg *Message) { msg.Type = 0x03 }, ErrUnknownMessageType},
+		{"no address", func(msg *Message) { msg.Address = "" }, ErrMissingAddress},
+		{"address symbols", func(msg *Message) { msg.Address = "+7999#123" }, ErrInvalidAddress},
+		{"address digits", func(msg *Message) { msg.Address = "+" }, ErrInvalidAddress},
+		{"long address", func(msg *Message) { msg.Address = PhoneNumber(strings.Repeat("1", 21)) }, ErrInvalidAddress},
+		{"long sender", func(msg *Message) { msg.Address = "LongSenderName" }, ErrAddressTooLong},
+		{"sc address", func(msg *Message) { msg.ServiceCenterAddress = "Operator" }, ErrInvalidAddress},
+		{"vp not present", func(msg *Message) { msg.VPFormat = ValidityPeriodFormats.FieldNotPresent }, ErrInvalidValidityPeriod},
+		{"vp too long", func(msg *Message) { msg.VP = ValidityPeriod(500 * 24 * time.Hour) }, ErrInvalidValidityPeriod},
+		{"vp absolute", func(msg *Message) { msg.VPFormat = ValidityPeriodFormats.Absolute }, ErrInvalidValidityPeriod},
+		{"vp enhanced", func(msg *Message) { msg.VPFormat = ValidityPeriodFormats.Enhanced }, ErrNonRelative},
+		{"7-bit data", func(msg *Message) { msg.Data = []byte{0x01} }, ErrEncodingMismatch},
+		{"8-bit text", func(msg *Message) { msg.Encoding = Encodings.Data8Bit }, ErrEncodingMismatch},
+		{"not encodable", func(msg *Message) { msg.Text = "ü→" }, ErrNotEncodable},
+		{"7-bit too long", func(msg *Message) { msg.Text += "{" }, ErrUserDataTooLong},
+		{"ucs2 too long", func(msg *Message) { msg.Encoding = Encodings.UCS2; msg.Text = msg.Text[:71] }, ErrUserDataTooLong},
+		{"compressed", func(msg *Message) { msg.Encoding = 0x20 }, ErrUnknownEncoding},
+	}
+	for _, tt := range tests {
+		msg := valid
+		tt.modify(&msg)
+		assert.ErrorIs(t, msg.Validate(), tt.err, tt.name)
+	}
+
+	// the validity period isn't checked in the other messages
+	deliver := valid
+	deliver.Type = MessageTypes.Deliver
+	deliver.VPFormat = ValidityPeriodFormats.Enhanced
+	assert.NoError(t, deliver.Validate())
+}
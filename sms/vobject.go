@@ -0,0 +1,129 @@
+package sms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors of the vCard and vCalendar objects.
+var (
+	ErrNotVObject       = errors.New("sms: message doesn't carry a vCard or vCalendar object")
+	ErrMalformedVObject = errors.New("sms: malformed vCard or vCalendar object")
+)
+
+// VObjectPorts represent the application ports the vCard and vCalendar objects are sent to (WAP WDP).
+var VObjectPorts = struct {
+	VCard           int
+	VCalendar       int
+	VCardSecure     int
+	VCalendarSecure int
+}{
+	9204, 9205, 9206, 9207,
+}
+
+// VProperty represents a property of the vCard or vCalendar object, e.g. TEL;CELL:+79991234567.
+type VProperty struct {
+	// Name is the upper-cased name of the property, e.g. TEL.
+	Name string
+	// Params are the parameters of the property as is, e.g. CELL or TYPE=HOME.
+	Params []string
+	// Value is the unfolded value of the property, it's not decoded otherwise.
+	Value string
+}
+
+// VObject represents the vCard or vCalendar object sent as the smart message by the feature phones.
+type VObject struct {
+	// Type is the type of the object, i.e. VCARD or VCALENDAR.
+	Type string
+	// Raw is the object as is.
+	Raw []byte
+	// Properties are the properties of the object in order of appearance, the nested objects,
+	// e.g. VEVENT of vCalendar, are flattened with their BEGIN and END properties.
+	Properties []VProperty
+}
+
+// Get returns the value of the first property with the given name.
+func (v *VObject) Get(name string) string {
+	name = strings.ToUpper(name)
+	for _, prop := range v.Properties {
+		if prop.Name == name {
+			return prop.Value
+		}
+	}
+	return ""
+}
+
+// All returns the values of all properties with the given name, e.g. all TEL numbers.
+func (v *VObject) All(name string) []string {
+	name = strings.ToUpper(name)
+	var values []string
+	for _, prop := range v.Properties {
+		if prop.Name == name {
+			values = append(values, prop.Value)
+		}
+	}
+	return values
+}
+
+// VObject returns the vCard or vCalendar object carried by the message addressed to one of VObjectPorts.
+// The object is taken from the data of 8-bit messages and from the text otherwise. The parts of
+// the concatenated message should be reassembled first, see Assembler.
+func (s *Message) VObject() (*VObject, error) {
+	dst, _, ok := s.Ports()
+	switch {
+	case !ok:
+		return nil, ErrNotVObject
+	case dst == VObjectPorts.VCard, dst == VObjectPorts.VCalendar,
+		dst == VObjectPorts.VCardSecure, dst == VObjectPorts.VCalendarSecure:
+	default:
+		return nil, fmt.Errorf("%w: destination port %d", ErrNotVObject, dst)
+	}
+	raw := s.Data
+	if s.DCS().Alphabet != Alphabets.Data8Bit {
+		raw = []byte(s.Text)
+	}
+	return ParseVObject(raw)
+}
+
+// ParseVObject parses the vCard or vCalendar object, the folded lines are unfolded.
+func ParseVObject(raw []byte) (*VObject, error) {
+	var lines []string
+	for _, line := range strings.Split(string(bytes.TrimSpace(raw)), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if n := len(lines); n > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[n-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	obj := &VObject{Raw: append([]byte(nil), raw...)}
+	for _, line := range lines {
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q has no value", ErrMalformedVObject, line)
+		}
+		params := strings.Split(line[:i], ";")
+		prop := VProperty{Name: strings.ToUpper(params[0]), Value: line[i+1:]}
+		if len(params) > 1 {
+			prop.Params = params[1:]
+		}
+		obj.Properties = append(obj.Properties, prop)
+	}
+
+	props := obj.Properties
+	if len(props) < 2 || props[0].Name != "BEGIN" || props[len(props)-1].Name != "END" ||
+		!strings.EqualFold(props[0].Value, props[len(props)-1].Value) {
+		return nil, fmt.Errorf("%w: BEGIN and END don't match", ErrMalformedVObject)
+	}
+	obj.Type = strings.ToUpper(props[0].Value)
+	if obj.Type != "VCARD" && obj.Type != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrMalformedVObject, props[0].Value)
+	}
+	obj.Properties = props[1 : len(props)-1]
+	return obj, nil
+}
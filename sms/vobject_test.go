@@ -0,0 +1,70 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageVObject(t *testing.T) {
+	t.Parallel()
+
+	vcard := "BEGIN:VCARD\r\nVERSION:2.1\r\nN:Doe;John\r\nTEL;CELL:+79991234567\r\nTEL;TYPE=HOME:+74951234567\r\n" +
+		"NOTE:" + strings.Repeat("long note ", 20) + "\r\n continued\r\nEND:VCARD\r\n"
+	msg := Message{
+		Type:     MessageTypes.Deliver,
+		Encoding: Encodings.Data8Bit,
+		Address:  "+79997654321",
+		Data:     []byte(vcard),
+	}
+	msg.SetPorts(VObjectPorts.VCard, VObjectPorts.VCard)
+	parts, err := Split(&msg)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+
+	asm := NewAssembler(time.Minute)
+	var joined *Message
+	for i := range parts {
+		_, octets, err := parts[i].PDU()
+		require.NoError(t, err)
+		var part Message
+		_, err = part.ReadFrom(octets)
+		require.NoError(t, err)
+		joined, _ = asm.Add(&part)
+	}
+	require.NotNil(t, joined)
+
+	obj, err := joined.VObject()
+	require.NoError(t, err)
+	assert.Equal(t, "VCARD", obj.Type)
+	assert.Equal(t, []byte(vcard), obj.Raw)
+	assert.Equal(t, "Doe;John", obj.Get("n"))
+	assert.Equal(t, []string{"+79991234567", "+74951234567"}, obj.All("TEL"))
+	assert.Equal(t, VProperty{Name: "TEL", Params: []string{"TYPE=HOME"}, Value: "+74951234567"}, obj.Properties[3])
+	assert.True(t, strings.HasSuffix(obj.Get("NOTE"), "long note continued"))
+
+	// the text messages carry the objects as well
+	event := Message{
+		Type:     MessageTypes.Deliver,
+		Encoding: Encodings.Gsm7Bit,
+		Text:     "BEGIN:VCALENDAR\nVERSION:1.0\nBEGIN:VEVENT\nSUMMARY:Meeting\nDTSTART:20220216T150000Z\nEND:VEVENT\nEND:VCALENDAR",
+	}
+	event.SetPorts(VObjectPorts.VCalendar, 0)
+	obj, err = event.VObject()
+	require.NoError(t, err)
+	assert.Equal(t, "VCALENDAR", obj.Type)
+	assert.Equal(t, "Meeting", obj.Get("SUMMARY"))
+	assert.Equal(t, "20220216T150000Z", obj.Get("DTSTART"))
+
+	event.Text = "BEGIN:VCALENDAR\nSUMMARY:Meeting"
+	_, err = event.VObject()
+	assert.ErrorIs(t, err, ErrMalformedVObject)
+	event.SetPorts(2948, 9200)
+	_, err = event.VObject()
+	assert.ErrorIs(t, err, ErrNotVObject)
+	_, err = smsDeliverGsm7.VObject()
+	assert.ErrorIs(t, err, ErrNotVObject)
+}
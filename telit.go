@@ -0,0 +1,186 @@
+package at
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeviceTelit returns an instance of DeviceProfile implementation for Telit
+// modules such as LE910 and ME910.
+func DeviceTelit() DeviceProfile {
+	return &TelitProfile{}
+}
+
+var qss = optMap{
+	0: Opt{0, "SIM not inserted"},
+	1: Opt{1, "SIM inserted"},
+	2: Opt{2, "SIM inserted and PIN unlocked"},
+	3: Opt{3, "SIM inserted and ready"},
+}
+
+// TelitSimStates represent the SIM states reported by Telit modules with #QSS.
+var TelitSimStates = struct {
+	Resolve func(int) Opt
+
+	NotInserted Opt
+	Inserted    Opt
+	Unlocked    Opt
+	Ready       Opt
+}{
+	func(id int) Opt { return qss.Resolve(id) },
+
+	qss[0], qss[1], qss[2], qss[3],
+}
+
+// TelitProfile is a DeviceProfile implementation for Telit modules. It disables
+// the hardware flow control, enables the SIM status reports and reads the signal
+// strength using #MONI.
+type TelitProfile struct {
+	GenericProfile
+
+	// FlowControl keeps the hardware flow control enabled. Telit modules have it
+	// enabled by default, so the commands stall if RTS/CTS lines are not connected.
+	FlowControl bool
+}
+
+// Init makes the initial setup of the Telit module.
+func (p *TelitProfile) Init(d *Device) (err error) {
+	p.dev = d
+	p.dev.Send(NoopCmd) // kinda flush
+	if !p.FlowControl {
+		if _, err = p.dev.Send(`AT&K0`); err != nil {
+			return fmt.Errorf("at init: unable to disable flow control: %w", err)
+		}
+	}
+	if err = p.init(d, p); err != nil {
+		return err
+	}
+	p.QSS(true) // optional, no SIM status reports if not supported
+	return nil
+}
+
+// QSS sends AT#QSS to the device in order to toggle the SIM status reports.
+func (p *TelitProfile) QSS(reporting bool) (err error) {
+	var flag int
+	if reporting {
+		flag = 1
+	}
+	req := fmt.Sprintf(`AT#QSS=%d`, flag)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// SimStatus sends AT#QSS? to the device and returns the SIM status, see TelitSimStates.
+func (p *TelitProfile) SimStatus() (status Opt, err error) {
+	reply, err := p.dev.Send(`AT#QSS?`)
+	if err != nil {
+		return UnknownOpt, err
+	}
+	fields := splitFields(strings.TrimPrefix(reply, "#QSS:"))
+	if len(fields) < 2 {
+		return UnknownOpt, ErrParseReport
+	}
+	n, err := parseUint8(fields[1])
+	if err != nil {
+		return UnknownOpt, err
+	}
+	return TelitSimStates.Resolve(int(n)), nil
+}
+
+// TelitMonitorReport represents the report of the serving cell from the #MONI command.
+type TelitMonitorReport struct {
+	// Network is the name or the code of the network.
+	Network string
+	// Fields contain the reported values by their names, e.g. "RSRP" or "LAC".
+	Fields map[string]string
+	// Power is the received signal power in dBm.
+	Power int
+}
+
+// Parse scans the #MONI report into a non-nil TelitMonitorReport struct.
+func (r *TelitMonitorReport) Parse(str string) error {
+	r.Fields = make(map[string]string)
+	var names []string
+	for _, word := range strings.Fields(str) {
+		kv := strings.SplitN(word, ":", 2)
+		if len(kv) < 2 {
+			names = append(names, word)
+			continue
+		}
+		r.Fields[kv[0]] = kv[1]
+	}
+	r.Network = strings.Join(names, " ")
+	pwr, ok := r.Fields["PWR"]
+	if !ok {
+		return ErrParseReport
+	}
+	power, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(pwr), "dbm"))
+	if err != nil {
+		return ErrParseReport
+	}
+	r.Power = power
+	return nil
+}
+
+// SignalStrength returns the signal strength converted to the scale of the RSSI
+// reported by AT+CSQ, i.e. from 0 (-113 dBm or less) to 31 (-51 dBm or greater).
+func (r *TelitMonitorReport) SignalStrength() int {
+	rssi := (r.Power + 113) / 2
+	switch {
+	case rssi < 0:
+		return 0
+	case rssi > 31:
+		return 31
+	}
+	return rssi
+}
+
+// MONI sends AT#MONI to the device and returns the report of the serving cell.
+func (p *TelitProfile) MONI() (report *TelitMonitorReport, err error) {
+	reply, err := p.dev.Send(`AT#MONI`)
+	if err != nil {
+		return nil, err
+	}
+	report = new(TelitMonitorReport)
+	err = report.Parse(strings.TrimPrefix(reply, "#MONI:"))
+	return
+}
+
+// CSQ returns the signal strength reported by #MONI, since AT+CSQ is not
+// reliable on LTE-only modules. Falls back to AT+CSQ if #MONI fails.
+func (p *TelitProfile) CSQ() (rssi int, err error) {
+	report, err := p.MONI()
+	if err != nil {
+		return p.GenericProfile.CSQ()
+	}
+	return report.SignalStrength(), nil
+}
+
+// HandleReport handles the #QSS SIM status reports of Telit modules.
+func (p *TelitProfile) HandleReport(report string) (handled bool, err error) {
+	if !strings.HasPrefix(report, "#QSS:") {
+		return false, nil
+	}
+	n, err := parseUint8(strings.TrimSpace(strings.TrimPrefix(report, "#QSS:")))
+	if err != nil {
+		return true, err
+	}
+	var sim Opt
+	switch TelitSimStates.Resolve(int(n)) {
+	case TelitSimStates.NotInserted:
+		sim = SimStates.NoCard
+	case TelitSimStates.Inserted:
+		sim = SimStates.Invalid
+	default:
+		sim = SimStates.Valid
+	}
+	p.dev.UpdateState(func(s *DeviceState) bool {
+		if s.SimState == sim {
+			return false
+		}
+		s.SimState = sim
+		return true
+	})
+	return true, nil
+}
@@ -0,0 +1,40 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelitMonitorReport(t *testing.T) {
+	t.Parallel()
+
+	var report TelitMonitorReport
+	require.NoError(t, report.Parse(" Vodafone IT RSRP:-95 RSRQ:-10 TAC:5A3E Id:01A2B3C EARFCN:1850 PWR:-65dbm DRX:128"))
+	assert.Equal(t, "Vodafone IT", report.Network)
+	assert.Equal(t, "-95", report.Fields["RSRP"])
+	assert.Equal(t, -65, report.Power)
+	assert.Equal(t, 24, report.SignalStrength())
+
+	assert.Error(t, report.Parse("222 10 BSIC:3F RxQual:0"))
+}
+
+func TestTelitProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT&K0", "OK")
+	modem.Reply("AT#QSS=1", "OK")
+	modem.Reply("AT#MONI", "#MONI: I TIM BSIC:35 RxQual:0 LAC:D5BD Id:3A3D ARFCN:1014 PWR:-79dbm TA:1", "OK")
+	require.NoError(t, dev.Init(DeviceTelit()))
+	assert.Equal(t, 17, dev.State().SignalStrength)
+	assert.Equal(t, "AT&K0", modem.Sent()[1])
+
+	require.NoError(t, dev.handleReport("#QSS: 0"))
+	assert.Equal(t, SimStates.NoCard, dev.State().SimState)
+}
@@ -0,0 +1,275 @@
+package at
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xlab/at/pdu"
+	"github.com/xlab/at/sms"
+	"github.com/xlab/at/util"
+)
+
+// The text mode (AT+CMGF=1) is used with the modules that don't support the PDU mode.
+// The UCS2 character set is selected with AT+CSCS during Init, so the addresses and
+// the texts are passed as hex-encoded UCS2 strings and any characters could be sent.
+
+// textFlags are the text mode equivalents of MessageFlags.
+var textFlags = map[int]string{
+	0: "REC UNREAD",
+	1: "REC READ",
+	2: "STO UNSENT",
+	3: "STO SENT",
+	4: "ALL",
+}
+
+// textFlag returns the message status by its text mode equivalent.
+func textFlag(str string) Opt {
+	for id, flag := range textFlags {
+		if flag == str {
+			return MessageFlags.Resolve(id)
+		}
+	}
+	return UnknownOpt
+}
+
+// messageFormat selects the PDU mode or the text mode if it's set by the options.
+func (p *DefaultProfile) messageFormat() error {
+	if p.Options.TextMode {
+		return p.textFormat()
+	}
+	return p.CMGF(false)
+}
+
+// textFormat selects the text mode with the UCS2 character set and the detailed headers.
+func (p *DefaultProfile) textFormat() (err error) {
+	if err = p.CMGF(true); err != nil {
+		return
+	}
+	if err = p.SetCSCS(Charsets.UCS2); err != nil {
+		return
+	}
+	p.dev.Send(`AT+CSDH=1`) // optional, the headers are shorter otherwise
+	p.dev.textMode = true
+	return nil
+}
+
+// CSMP sends AT+CSMP with the given parameters of the outgoing messages in text mode:
+// the first octet of SMS-SUBMIT, the relative validity period, the protocol identifier
+// and the data coding scheme.
+func (p *DefaultProfile) CSMP(fo, vp, pid, dcs int) (err error) {
+	req := fmt.Sprintf(`AT+CSMP=%d,%d,%d,%d`, fo, vp, pid, dcs)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// CMGSText sends AT+CMGS with the given address and text to the device in text mode.
+// Returns the reference number of the sent message.
+func (p *DefaultProfile) CMGSText(address sms.PhoneNumber, text string) (byte, error) {
+	typ := 129
+	if strings.HasPrefix(string(address), "+") {
+		typ = 145
+	}
+	part1 := fmt.Sprintf(`AT+CMGS="%s",%d`, encodeText(string(address)), typ)
+	reply, err := p.dev.sendInteractive(part1, encodeText(text))
+	if err != nil {
+		return 0, err
+	}
+	if !strings.HasPrefix(reply, "+CMGS: ") {
+		return 0, fmt.Errorf("unable to get sequence number of reply '%s'", reply)
+	}
+	number, err := parseUint8(reply[7:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse sequence number of reply '%s': %w", reply, err)
+	}
+	return number, nil
+}
+
+// CMGRText sends AT+CMGR with the given index to the device in text mode
+// and returns the decoded message.
+func (p *DefaultProfile) CMGRText(index uint16) (msg *sms.Message, err error) {
+	req := fmt.Sprintf(`AT+CMGR=%d`, index)
+	reply, err := p.dev.Send(req)
+	if err != nil {
+		return
+	}
+	lines := strings.SplitN(reply, "\n", 2)
+	if !strings.HasPrefix(lines[0], "+CMGR: ") {
+		return nil, ErrParseReport
+	}
+	fields := splitFields(strings.TrimPrefix(lines[0], "+CMGR: "))
+	if len(lines) < 2 {
+		// the status reports consist of the header only
+		return parseTextStatusReport(fields[1:])
+	}
+	return parseTextMessage(fields, lines[1])
+}
+
+// CMGLText sends AT+CMGL with the given filtering flag to the device in text mode
+// and returns the decoded messages, see MessageFlags.
+func (p *DefaultProfile) CMGLText(flag Opt) (result []MessageSlot, err error) {
+	req := fmt.Sprintf(`AT+CMGL="%s"`, textFlags[flag.ID])
+	reply, err := p.dev.Send(req)
+	if err != nil {
+		return
+	}
+	lines := strings.Split(reply, "\n")
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "+CMGL: ") {
+			continue
+		}
+		fields := splitFields(strings.TrimPrefix(lines[i], "+CMGL: "))
+		if len(fields) < 3 {
+			return nil, ErrParseReport
+		}
+		n, err := parseUint16(fields[0])
+		if err != nil {
+			return nil, ErrParseReport
+		}
+		var text string
+		if i+1 < len(lines) && !strings.HasPrefix(lines[i+1], "+CMGL: ") {
+			i++
+			text = lines[i]
+		}
+		msg, err := parseTextMessage(fields[1:], text)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, MessageSlot{Index: n, Status: textFlag(fields[1]), Message: msg})
+	}
+	return
+}
+
+// sendText sends the message in text mode.
+func (d *Device) sendText(parts []sms.Message) (ref byte, err error) {
+	if len(parts) > 1 {
+		return 0, ErrTextTooLong
+	}
+	msg := &parts[0]
+	fo := 0x11 // SMS-SUBMIT with the relative validity period
+	if msg.StatusReportRequest {
+		fo |= 0x20
+	}
+	if err = d.Commands.CSMP(fo, int(msg.VP.Octet()), int(msg.ProtocolIdentifier), int(msg.Encoding)); err != nil {
+		return
+	}
+	return d.Commands.CMGSText(msg.Address, msg.Text)
+}
+
+// parseTextMessage parses the message reported in text mode, the fields of the header
+// start with the message status (+CMGR, +CMGL) or with the address (+CMT).
+// The detailed header of +CMGR and +CMT contains the protocol identifier and the data coding scheme,
+// the 8-bit data is reported as hex-encoded octets regardless of the character set.
+func parseTextMessage(fields []string, text string) (*sms.Message, error) {
+	msg := &sms.Message{Type: sms.MessageTypes.Deliver}
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "STO") {
+		msg.Type = sms.MessageTypes.Submit
+		fields = fields[1:]
+	} else if len(fields) > 0 && strings.HasPrefix(fields[0], "REC") {
+		fields = fields[1:]
+	}
+	if len(fields) < 1 {
+		return nil, ErrParseReport
+	}
+	msg.Address = sms.PhoneNumber(decodeText(fields[0]))
+	if msg.Type == sms.MessageTypes.Deliver && len(fields) > 2 {
+		t, err := parseTextTime(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		msg.ServiceCenterTime = sms.Timestamp(t)
+	}
+	dcsField := 6 // <oa>,<alpha>,<scts>,<tooa>,<fo>,<pid>,<dcs>
+	if msg.Type == sms.MessageTypes.Submit {
+		dcsField = 5 // <da>,<alpha>,<toda>,<fo>,<pid>,<dcs>
+	}
+	if len(fields) > dcsField {
+		if pid, err := parseUint8(fields[dcsField-1]); err == nil {
+			msg.ProtocolIdentifier = sms.ProtocolIdentifier(pid)
+		}
+		if dcs, err := parseUint8(fields[dcsField]); err == nil {
+			msg.Encoding = sms.Encoding(dcs)
+		}
+	}
+	if msg.Encoding.DCS().Alphabet == sms.Alphabets.Data8Bit {
+		data, err := util.Bytes(text)
+		if err != nil {
+			return nil, ErrParseReport
+		}
+		msg.Data = data
+		return msg, nil
+	}
+	msg.Text = decodeText(text)
+	return msg, nil
+}
+
+// parseTextStatusReport parses the status report in text mode, the fields are
+// <fo>,<mr>,[<ra>],[<tora>],<scts>,<dt>,<st>.
+func parseTextStatusReport(fields []string) (*sms.Message, error) {
+	if len(fields) < 7 {
+		return nil, ErrParseReport
+	}
+	mr, err := parseUint8(fields[1])
+	if err != nil {
+		return nil, ErrParseReport
+	}
+	st, err := parseUint8(fields[6])
+	if err != nil {
+		return nil, ErrParseReport
+	}
+	scts, err := parseTextTime(fields[4])
+	if err != nil {
+		return nil, err
+	}
+	dt, err := parseTextTime(fields[5])
+	if err != nil {
+		return nil, err
+	}
+	return &sms.Message{
+		Type:              sms.MessageTypes.StatusReport,
+		MessageReference:  mr,
+		Address:           sms.PhoneNumber(decodeText(fields[2])),
+		ServiceCenterTime: sms.Timestamp(scts),
+		DischargeTime:     sms.Timestamp(dt),
+		Status:            sms.Status(st),
+	}, nil
+}
+
+// parseTextTime parses the time in text mode, i.e. yy/MM/dd,hh:mm:ss±zz
+// where zz is the time zone in quarters of an hour.
+func parseTextTime(str string) (time.Time, error) {
+	if len(str) < 20 {
+		return time.Time{}, ErrParseReport
+	}
+	t, err := time.Parse("06/01/02,15:04:05", str[:17])
+	if err != nil {
+		return time.Time{}, ErrParseReport
+	}
+	quarters, err := strconv.Atoi(str[17:])
+	if err != nil {
+		return time.Time{}, ErrParseReport
+	}
+	offset := quarters * 15 * 60
+	loc := time.FixedZone("", offset)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+}
+
+// encodeText encodes the string with the UCS2 character set.
+func encodeText(str string) string {
+	return fmt.Sprintf("%02X", pdu.EncodeUcs2(str))
+}
+
+// decodeText decodes the string encoded with the UCS2 character set,
+// the string is returned as is if it's not hex-encoded.
+func decodeText(str string) string {
+	octets, err := util.Bytes(str)
+	if err != nil || len(octets)%2 != 0 {
+		return str
+	}
+	text, err := pdu.DecodeUcs2(octets, false)
+	if err != nil {
+		return str
+	}
+	return text
+}
@@ -0,0 +1,69 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+const testTextAddress = "002B00370039003900390037003600350034003300320031" // +79997654321
+
+func TestTextMode(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+CMGF=1", "OK")
+	modem.Reply(`AT+CSCS="UCS2"`, "OK")
+	modem.Reply("AT+CSDH=1", "OK")
+	modem.Reply(`AT+CMGL="ALL"`,
+		`+CMGL: 1,"REC UNREAD","`+testTextAddress+`",,"20/05/18,12:00:00+12",145,12`,
+		"043F04400438043204350442",
+		"OK")
+	modem.Reply("AT+CMGD=1,0", "OK")
+	profile := &GenericProfile{DefaultProfile{Options: InitOptions{TextMode: true}}}
+	require.NoError(t, dev.Init(profile))
+	assert.NotContains(t, modem.Sent(), "AT+CMGF=0")
+
+	require.Len(t, dev.IncomingSms(), 1)
+	msg := <-dev.IncomingSms()
+	assert.Equal(t, "привет", msg.Text)
+	assert.EqualValues(t, "+79997654321", msg.Address)
+	_, offset := time.Time(msg.ServiceCenterTime).Zone()
+	assert.Equal(t, 3*60*60, offset)
+
+	modem.Reply("AT+CSMP=17,170,0,8", "OK")
+	modem.Reply(`AT+CMGS="`+testTextAddress+`",145`, "> ")
+	modem.Reply("043F04400438043204350442"+Sub, "+CMGS: 5", "OK")
+	refs, err := dev.SendSMS("привет", "+79997654321")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, refs)
+
+	_, err = dev.SendSMS(string(make([]rune, 71)), "+79997654321")
+	assert.ErrorIs(t, err, ErrTextTooLong)
+
+	require.NoError(t, dev.handleReport(`+CMT: "`+testTextAddress+`",,"20/05/18,12:00:00+12"`))
+	require.NoError(t, dev.handleReport("00680069"))
+	msg = <-dev.IncomingSms()
+	assert.Equal(t, "hi", msg.Text)
+
+	// the detailed header with the 8-bit data coding scheme
+	require.NoError(t, dev.handleReport(`+CMT: "`+testTextAddress+`",,"20/05/18,12:00:00+12",145,4,127,245,"",129,3`))
+	require.NoError(t, dev.handleReport("DEADBE"))
+	// it's the SIM data download message
+	msg = (<-dev.SIMMessages()).Message
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe}, msg.Data)
+	assert.Empty(t, msg.Text)
+	assert.Equal(t, sms.ProtocolIdentifiers.SimDataDownload, msg.ProtocolIdentifier)
+
+	require.NoError(t, dev.handleReport(`+CDS: 6,5,"`+testTextAddress+`",145,"20/05/18,12:00:00+12","20/05/18,12:00:05+12",0`))
+	report := <-dev.StatusReports()
+	assert.EqualValues(t, 5, report.MessageReference)
+	assert.Equal(t, sms.StatusCodes.CompletedReceived, report.Status)
+}
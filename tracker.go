@@ -0,0 +1,138 @@
+package at
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xlab/at/sms"
+)
+
+// DeliveryUpdate represents a change of the delivery state of a sent message.
+type DeliveryUpdate struct {
+	// Reference is the message reference (TP-MR) returned by AT+CMGS.
+	Reference byte
+	// Address is the recipient address of the message.
+	Address sms.PhoneNumber
+	// State is the delivery state, see DeliveryStates.
+	State Opt
+	// Status is the status reported by the service center, it's meaningful
+	// only if the status report was received.
+	Status sms.Status
+	// Time is the time of the delivery or of the failure reported by
+	// the service center, zero while the message is pending.
+	Time time.Time
+}
+
+// DeliveryTracker correlates the references of the sent messages with the received
+// SMS-STATUS-REPORTs and keeps the delivery state of each pending message. The messages
+// should be sent with the status report request, otherwise they stay pending forever.
+// Once a message is delivered or failed it's no longer tracked, the final state is
+// reported with OnUpdate and Updates only.
+//
+// The message references are 8-bit and wrap around, so a newly tracked message
+// replaces the one with the same reference. The zero value is ready to use.
+type DeliveryTracker struct {
+	// OnUpdate is called on every change of the delivery state if not nil.
+	OnUpdate func(DeliveryUpdate)
+
+	mux      sync.Mutex
+	messages map[byte]DeliveryUpdate
+	updates  chan DeliveryUpdate
+}
+
+// NewDeliveryTracker returns a new DeliveryTracker.
+func NewDeliveryTracker() *DeliveryTracker {
+	return &DeliveryTracker{
+		messages: make(map[byte]DeliveryUpdate),
+		updates:  make(chan DeliveryUpdate, 100),
+	}
+}
+
+// Updates fires when the delivery state of a tracked message has changed.
+// The updates are dropped if the channel is full.
+func (t *DeliveryTracker) Updates() <-chan DeliveryUpdate {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.init()
+	return t.updates
+}
+
+// init makes the zero value usable, the caller must hold mux.
+func (t *DeliveryTracker) init() {
+	if t.messages == nil {
+		t.messages = make(map[byte]DeliveryUpdate)
+	}
+	if t.updates == nil {
+		t.updates = make(chan DeliveryUpdate, 100)
+	}
+}
+
+// Track starts tracking the message with the given reference.
+func (t *DeliveryTracker) Track(ref byte, address sms.PhoneNumber) {
+	t.update(DeliveryUpdate{
+		Reference: ref,
+		Address:   address,
+		State:     DeliveryStates.Pending,
+	})
+}
+
+// State returns the delivery state of the message with the given reference,
+// ok is false if the message is not tracked, e.g. it was delivered already.
+func (t *DeliveryTracker) State(ref byte) (state DeliveryUpdate, ok bool) {
+	t.mux.Lock()
+	state, ok = t.messages[ref]
+	t.mux.Unlock()
+	return
+}
+
+// Forget stops tracking the message with the given reference.
+func (t *DeliveryTracker) Forget(ref byte) {
+	t.mux.Lock()
+	delete(t.messages, ref)
+	t.mux.Unlock()
+}
+
+// HandleReport updates the state of the tracked message the given status report refers to.
+// Returns false if the report is not a status report of any tracked message.
+func (t *DeliveryTracker) HandleReport(report *sms.Message) bool {
+	if report.Type != sms.MessageTypes.StatusReport {
+		return false
+	}
+	state, ok := t.State(report.MessageReference)
+	if !ok {
+		return false
+	}
+	state.Status = report.Status
+	switch report.Status.Category() {
+	case sms.StatusCategories.Complete:
+		state.State = DeliveryStates.Delivered
+		state.Time = time.Time(report.DischargeTime)
+	case sms.StatusCategories.PermanentError, sms.StatusCategories.FinalError:
+		state.State = DeliveryStates.Failed
+		state.Time = time.Time(report.DischargeTime)
+	default:
+		// the service center is still trying to deliver the message
+		state.State = DeliveryStates.Pending
+	}
+	t.update(state)
+	return true
+}
+
+func (t *DeliveryTracker) update(state DeliveryUpdate) {
+	t.mux.Lock()
+	t.init()
+	if state.State == DeliveryStates.Pending {
+		t.messages[state.Reference] = state
+	} else {
+		delete(t.messages, state.Reference)
+	}
+	updates := t.updates
+	t.mux.Unlock()
+	select {
+	case updates <- state:
+	default:
+	}
+	if t.OnUpdate != nil {
+		t.OnUpdate(state)
+	}
+}
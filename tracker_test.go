@@ -0,0 +1,70 @@
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xlab/at/sms"
+)
+
+func TestDeliveryTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewDeliveryTracker()
+	var updates []DeliveryUpdate
+	tracker.OnUpdate = func(u DeliveryUpdate) { updates = append(updates, u) }
+	tracker.Track(0x36, "+79997654321")
+	tracker.Track(0x37, "+79997654321")
+
+	dev := &Device{statusReports: make(chan *sms.Message, 1), Tracker: tracker}
+	require.NoError(t, dev.handleReport("+CDS: 27"))
+	require.NoError(t, dev.handleReport("079194710600400706360d91947106000000f122206151457440222061514584400000"))
+	require.Len(t, dev.StatusReports(), 1)
+
+	require.Len(t, updates, 3)
+	state := updates[2]
+	assert.EqualValues(t, 0x36, state.Reference)
+	assert.Equal(t, DeliveryStates.Delivered, state.State)
+	assert.Equal(t, sms.StatusCodes.CompletedReceived, state.Status)
+	assert.False(t, state.Time.IsZero())
+	// the final states are not kept
+	_, ok := tracker.State(0x36)
+	assert.False(t, ok)
+
+	report := &sms.Message{Type: sms.MessageTypes.StatusReport, MessageReference: 0x37}
+	report.Status = sms.StatusCodes.TemporaryBusy
+	assert.True(t, tracker.HandleReport(report))
+	state, ok = tracker.State(0x37)
+	require.True(t, ok)
+	assert.Equal(t, DeliveryStates.Pending, state.State)
+	report.Status = sms.StatusCodes.PermanentUnknownMessage
+	assert.True(t, tracker.HandleReport(report))
+	assert.Equal(t, DeliveryStates.Failed, updates[len(updates)-1].State)
+	_, ok = tracker.State(0x37)
+	assert.False(t, ok)
+
+	tracker.Track(0x38, "+79997654321")
+	tracker.Forget(0x38)
+	_, ok = tracker.State(0x38)
+	assert.False(t, ok)
+	report.MessageReference = 0x38
+	assert.False(t, tracker.HandleReport(report))
+
+	assert.Len(t, updates, 6)
+	assert.Len(t, tracker.Updates(), 6)
+}
+
+func TestDeliveryTrackerZeroValue(t *testing.T) {
+	t.Parallel()
+
+	tracker := &DeliveryTracker{}
+	dev := &Device{statusReports: make(chan *sms.Message, 1), Tracker: tracker}
+	tracker.Track(0x36, "+79997654321")
+	require.NoError(t, dev.handleReport("+CDS: 27"))
+	require.NoError(t, dev.handleReport("079194710600400706360d91947106000000f122206151457440222061514584400000"))
+	update := <-tracker.Updates()
+	assert.Equal(t, DeliveryStates.Pending, update.State)
+	update = <-tracker.Updates()
+	assert.Equal(t, DeliveryStates.Delivered, update.State)
+}
@@ -0,0 +1,198 @@
+package at
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeviceUblox returns an instance of DeviceProfile implementation for u-blox
+// cellular modules such as SARA-R4/R5, LARA and TOBY series.
+func DeviceUblox() DeviceProfile {
+	return &UbloxProfile{}
+}
+
+var mwiType = optMap{
+	1: Opt{1, "Voice message"},
+	2: Opt{2, "Fax message"},
+	3: Opt{3, "Email message"},
+	4: Opt{4, "Other message"},
+}
+
+// MessageWaitingTypes represent the kinds of waiting messages reported by +UMWI.
+var MessageWaitingTypes = struct {
+	Resolve func(int) Opt
+
+	Voice Opt
+	Fax   Opt
+	Email Opt
+	Other Opt
+}{
+	func(id int) Opt { return mwiType.Resolve(id) },
+
+	mwiType[1], mwiType[2], mwiType[3], mwiType[4],
+}
+
+// MessageWaitingReport represents the +UMWI message waiting indication.
+type MessageWaitingReport struct {
+	Active bool
+	Type   Opt
+	// Count is the number of waiting messages, if reported by the device.
+	Count int
+}
+
+// Parse scans the +UMWI report into a non-nil MessageWaitingReport struct.
+func (r *MessageWaitingReport) Parse(str string) error {
+	fields := splitFields(str)
+	if len(fields) < 2 {
+		return ErrParseReport
+	}
+	status, err := parseUint8(fields[0])
+	if err != nil {
+		return err
+	}
+	typ, err := parseUint8(fields[1])
+	if err != nil {
+		return err
+	}
+	r.Active = status == 1
+	r.Type = MessageWaitingTypes.Resolve(int(typ))
+	r.Count = 0
+	if len(fields) > 2 {
+		count, err := parseUint16(fields[2])
+		if err != nil {
+			return err
+		}
+		r.Count = int(count)
+	}
+	return nil
+}
+
+// SocketDataReport represents the +UUSORD and +UUSORF reports that signal
+// the data available for reading from a socket.
+type SocketDataReport struct {
+	Socket int
+	Length int
+	// UDP is set for the +UUSORF reports.
+	UDP bool
+}
+
+// Parse scans the +UUSORD or +UUSORF report fields into a non-nil SocketDataReport struct.
+func (r *SocketDataReport) Parse(str string) error {
+	fields := splitFields(str)
+	if len(fields) < 2 {
+		return ErrParseReport
+	}
+	socket, err := parseUint8(fields[0])
+	if err != nil {
+		return err
+	}
+	length, err := parseUint16(fields[1])
+	if err != nil {
+		return err
+	}
+	r.Socket = int(socket)
+	r.Length = int(length)
+	return nil
+}
+
+// UbloxProfile is a DeviceProfile implementation for u-blox modules. It waits for
+// the module to power up, disables the power saving that drops the commands and
+// handles the message waiting and socket reports.
+type UbloxProfile struct {
+	GenericProfile
+
+	messageWaiting chan MessageWaitingReport
+	socketData     chan SocketDataReport
+}
+
+// MessageWaiting fires when a message waiting indication was received, e.g. voicemail.
+// The reports are dropped if the channel is not drained.
+func (p *UbloxProfile) MessageWaiting() <-chan MessageWaitingReport {
+	return p.messageWaiting
+}
+
+// SocketData fires when data is available for reading from a socket.
+// The reports are dropped if the channel is not drained.
+func (p *UbloxProfile) SocketData() <-chan SocketDataReport {
+	return p.socketData
+}
+
+// Init makes the initial setup of the u-blox module.
+func (p *UbloxProfile) Init(d *Device) (err error) {
+	p.dev = d
+	p.messageWaiting = make(chan MessageWaitingReport, 100)
+	p.socketData = make(chan SocketDataReport, 100)
+	if err = p.waitPowerUp(time.Now().Add(p.readyTimeout())); err != nil {
+		return fmt.Errorf("at init: device is not responding: %w", err)
+	}
+	p.UPSV(false) // optional, not all modules support power saving
+	if err = p.init(d, p); err != nil {
+		return err
+	}
+	p.UMWI(true) // optional, no message waiting reports if not supported
+	return nil
+}
+
+// waitPowerUp waits until the module is responsive, the modules ignore
+// the commands for a few seconds after power-up.
+func (p *UbloxProfile) waitPowerUp(deadline time.Time) error {
+	for {
+		_, err := p.dev.Send(NoopCmd)
+		if err == nil || !p.sleepUntil(deadline) {
+			return err
+		}
+	}
+}
+
+// UPSV sends AT+UPSV to the device in order to toggle the power saving mode.
+func (p *UbloxProfile) UPSV(enable bool) (err error) {
+	var flag int
+	if enable {
+		flag = 1
+	}
+	req := fmt.Sprintf(`AT+UPSV=%d`, flag)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// UMWI sends AT+UMWI to the device in order to toggle the message waiting reports.
+func (p *UbloxProfile) UMWI(enable bool) (err error) {
+	var flag int
+	if enable {
+		flag = 1
+	}
+	req := fmt.Sprintf(`AT+UMWI=%d`, flag)
+	_, err = p.dev.Send(req)
+	return
+}
+
+// HandleReport handles the message waiting and socket reports of u-blox modules.
+func (p *UbloxProfile) HandleReport(report string) (handled bool, err error) {
+	switch {
+	case strings.HasPrefix(report, "+UMWI:"):
+		var mwi MessageWaitingReport
+		if err = mwi.Parse(strings.TrimPrefix(report, "+UMWI:")); err != nil {
+			return true, err
+		}
+		select {
+		case p.messageWaiting <- mwi:
+		default:
+		}
+		return true, nil
+	case strings.HasPrefix(report, "+UUSORD:"), strings.HasPrefix(report, "+UUSORF:"):
+		var data SocketDataReport
+		if err = data.Parse(report[len("+UUSORD:"):]); err != nil {
+			return true, err
+		}
+		data.UDP = strings.HasPrefix(report, "+UUSORF:")
+		select {
+		case p.socketData <- data:
+		default:
+		}
+		return true, nil
+	case strings.HasPrefix(report, "+UUSOCL:"):
+		return true, nil
+	}
+	return false, nil
+}
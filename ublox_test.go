@@ -0,0 +1,33 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUbloxProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT+UPSV=0", "OK")
+	modem.Reply("AT+UMWI=1", "OK")
+	profile := &UbloxProfile{}
+	require.NoError(t, dev.Init(profile))
+	assert.Contains(t, modem.Sent(), "AT+UMWI=1")
+
+	require.NoError(t, dev.handleReport("+UMWI: 1,1,3"))
+	assert.Equal(t, MessageWaitingReport{
+		Active: true,
+		Type:   MessageWaitingTypes.Voice,
+		Count:  3,
+	}, <-profile.MessageWaiting())
+
+	require.NoError(t, dev.handleReport("+UUSORF: 2,128"))
+	assert.Equal(t, SocketDataReport{Socket: 2, Length: 128, UDP: true}, <-profile.SocketData())
+}
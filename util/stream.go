@@ -0,0 +1,84 @@
+package util
+
+import "io"
+
+// chunkSize is the number of octets the transcoders handle at once.
+const chunkSize = 512
+
+// NewHexEncoder returns the writer that writes the hex digits of the octets written to it
+// in the given case to w, the result is the same as of AppendHex.
+func NewHexEncoder(w io.Writer, c HexCase) io.Writer {
+	return &hexEncoder{w: w, c: c}
+}
+
+type hexEncoder struct {
+	w   io.Writer
+	c   HexCase
+	buf []byte
+}
+
+func (e *hexEncoder) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		e.buf = AppendHex(e.buf[:0], chunk, e.c)
+		written, err := e.w.Write(e.buf)
+		n += written / 2
+		if err != nil {
+			return n, err
+		}
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// NewHexDecoder returns the reader that decodes the hex digits read from r into octets,
+// the result is the same as of Bytes. ErrUnevenLength is returned if the digits end with
+// a half of the octet and ErrUnexpected on the characters that aren't hex digits.
+func NewHexDecoder(r io.Reader) io.Reader {
+	return &hexDecoder{r: r}
+}
+
+type hexDecoder struct {
+	r       io.Reader
+	buf     []byte
+	pending []byte // the digits not decoded yet
+	err     error
+}
+
+func (d *hexDecoder) Read(p []byte) (int, error) {
+	for len(d.pending) < 2 && d.err == nil {
+		if d.buf == nil {
+			d.buf = make([]byte, chunkSize)
+		}
+		n, err := d.r.Read(d.buf)
+		d.pending = append(d.pending, d.buf[:n]...)
+		d.err = err
+	}
+	digits := len(d.pending) - len(d.pending)%2
+	if digits > len(p)*2 {
+		digits = len(p) * 2
+	}
+	n := 0
+	for i := 0; i < digits; i += 2 {
+		hi, ok1 := fromHex(d.pending[i])
+		lo, ok2 := fromHex(d.pending[i+1])
+		if !ok1 || !ok2 {
+			d.pending, d.err = nil, ErrUnexpected
+			return n, d.err
+		}
+		p[n] = hi<<4 | lo
+		n++
+	}
+	d.pending = d.pending[digits:]
+	if len(d.pending) > 0 && d.err == io.EOF && digits == 0 {
+		d.pending, d.err = nil, ErrUnevenLength
+	}
+	if n > 0 || len(d.pending) > 0 {
+		return n, nil
+	}
+	return n, d.err
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexStream(t *testing.T) {
+	t.Parallel()
+
+	octets := bytes.Repeat([]byte{0x00, 0x0A, 0xBE, 0xEF, 0x41}, 300)
+	for _, c := range []HexCase{HexCases.Upper, HexCases.Lower} {
+		var buf bytes.Buffer
+		w := NewHexEncoder(&buf, c)
+		for i := 0; i < len(octets); i += 7 {
+			end := i + 7
+			if end > len(octets) {
+				end = len(octets)
+			}
+			n, err := w.Write(octets[i:end])
+			require.NoError(t, err)
+			assert.Equal(t, end-i, n)
+		}
+		assert.Equal(t, string(AppendHex(nil, octets, c)), buf.String())
+
+		out, err := ioutil.ReadAll(NewHexDecoder(iotest.OneByteReader(&buf)))
+		require.NoError(t, err)
+		assert.Equal(t, octets, out)
+	}
+
+	_, err := ioutil.ReadAll(NewHexDecoder(strings.NewReader("4160629")))
+	assert.ErrorIs(t, err, ErrUnevenLength)
+	out, err := ioutil.ReadAll(NewHexDecoder(strings.NewReader("41606K")))
+	assert.ErrorIs(t, err, ErrUnexpected)
+	assert.Equal(t, []byte{0x41, 0x60}, out)
+}
@@ -2,11 +2,7 @@
 // extract bytes from a string.
 package util
 
-import (
-	"errors"
-	"fmt"
-	"strconv"
-)
+import "errors"
 
 // Common errors.
 var (
@@ -14,19 +10,45 @@ var (
 	ErrUnexpected   = errors.New("parse octets: met a non-HEX rune in string")
 )
 
+// HexCase represents the case of the hex digits A to F the octets are encoded with.
+type HexCase byte
+
+// HexCases represent the possible cases of the hex digits.
+var HexCases = struct {
+	Upper HexCase
+	Lower HexCase
+}{
+	0x00, 0x01,
+}
+
+const (
+	hexUpper = "0123456789ABCDEF"
+	hexLower = "0123456789abcdef"
+)
+
 // Bytes parses the hex-string of odd length into bytes.
 func Bytes(hex string) ([]byte, error) {
+	octets, err := AppendBytes(make([]byte, 0, len(hex)/2), hex)
+	if err != nil {
+		return nil, err
+	}
+	return octets, nil
+}
+
+// AppendBytes is like Bytes, but appends the octets to dst and returns the extended buffer.
+// The dst is returned as is on error. Both cases of the hex digits are accepted.
+func AppendBytes(dst []byte, hex string) ([]byte, error) {
 	if len(hex)%2 != 0 {
-		return nil, ErrUnevenLength
+		return dst, ErrUnevenLength
 	}
-	octets := make([]byte, 0, len(hex)/2)
+	octets := dst
 	for i := 0; i < len(hex); i += 2 {
-		frame := hex[i : i+2]
-		oct, err := strconv.ParseUint(frame, 16, 8)
-		if err != nil {
-			return nil, ErrUnexpected
+		hi, ok1 := fromHex(hex[i])
+		lo, ok2 := fromHex(hex[i+1])
+		if !ok1 || !ok2 {
+			return dst, ErrUnexpected
 		}
-		octets = append(octets, byte(oct))
+		octets = append(octets, hi<<4|lo)
 	}
 	return octets, nil
 }
@@ -43,5 +65,31 @@ func MustBytes(hex string) []byte {
 
 // HexString produces a hex-string from bytes. Like a DEADBEEF, without prepending the 0x.
 func HexString(octets []byte) string {
-	return fmt.Sprintf("%2X", octets)
+	return string(AppendHex(make([]byte, 0, len(octets)*2), octets, HexCases.Upper))
+}
+
+// AppendHex appends the hex digits of the octets in the given case to dst
+// and returns the extended buffer, two digits per octet.
+func AppendHex(dst, octets []byte, c HexCase) []byte {
+	digits := hexUpper
+	if c == HexCases.Lower {
+		digits = hexLower
+	}
+	for _, oct := range octets {
+		dst = append(dst, digits[oct>>4], digits[oct&0x0F])
+	}
+	return dst
+}
+
+// fromHex returns the value of the hex digit, ok is false if it isn't one.
+func fromHex(c byte) (b byte, ok bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	}
+	return 0, false
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBytes(t *testing.T) {
@@ -29,3 +30,19 @@ func TestHexString(t *testing.T) {
 	exp := "4160629140050E"
 	assert.Equal(t, exp, out)
 }
+
+func TestAppendHex(t *testing.T) {
+	t.Parallel()
+
+	buf := []byte{0x00, 0x0A, 0xBE, 0xEF}
+	assert.Equal(t, "000ABEEF", HexString(buf))
+	assert.Equal(t, "0x000abeef", string(AppendHex([]byte("0x"), buf, HexCases.Lower)))
+	assert.Equal(t, "", HexString(nil))
+
+	out, err := AppendBytes([]byte{0x01}, "000aBeEf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x00, 0x0A, 0xBE, 0xEF}, out)
+	out, err = AppendBytes([]byte{0x01}, "0G")
+	assert.ErrorIs(t, err, ErrUnexpected)
+	assert.Equal(t, []byte{0x01}, out)
+}
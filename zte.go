@@ -0,0 +1,172 @@
+package at
+
+import (
+	"strings"
+)
+
+// DeviceZTE returns an instance of DeviceProfile implementation for ZTE USB modems
+// of the MF series, e.g. MF190, MF667 and MF823.
+func DeviceZTE() DeviceProfile {
+	return &ZTEProfile{}
+}
+
+// ZTEProfile is a DeviceProfile implementation for ZTE USB modems. It understands
+// the ZTE variants of the AT^SYSINFO report and handles the +ZPAS and +ZUSIMR reports.
+type ZTEProfile struct {
+	GenericProfile
+}
+
+// Init makes the initial setup of the ZTE modem.
+func (p *ZTEProfile) Init(d *Device) (err error) {
+	return p.init(d, p)
+}
+
+// zteSystemInfoReport is the AT^SYSINFO report of ZTE modems, it may lack the trailing
+// fields or have them empty, unknown system modes are reported as well.
+type zteSystemInfoReport SystemInfoReport
+
+func (s *zteSystemInfoReport) Parse(str string) error {
+	fields := strings.Split(str, ",")
+	if len(fields) < 5 {
+		return ErrParseReport
+	}
+	opts := []*Opt{
+		&s.ServiceState, &s.ServiceDomain, &s.RoamingState,
+		&s.SystemMode, &s.SimState, nil, &s.SystemSubmode,
+	}
+	resolvers := []func(int) Opt{
+		ServiceStates.Resolve, ServiceDomains.Resolve, RoamingStates.Resolve,
+		SystemModes.Resolve, SimStates.Resolve, nil, SystemSubmodes.Resolve,
+	}
+	s.SystemSubmode = UnknownOpt
+	for i, opt := range opts {
+		if opt == nil || i >= len(fields) {
+			continue
+		}
+		field := strings.TrimSpace(fields[i])
+		if len(field) == 0 {
+			*opt = UnknownOpt
+			continue
+		}
+		n, err := parseUint8(field)
+		if err != nil {
+			return ErrParseReport
+		}
+		*opt = resolvers[i](int(n))
+	}
+	return nil
+}
+
+// SYSINFO sends AT^SYSINFO to the device and parses the output, the report
+// is emulated using the standard commands if AT^SYSINFO fails.
+func (p *ZTEProfile) SYSINFO() (info *SystemInfoReport, err error) {
+	reply, err := p.dev.Send(`AT^SYSINFO`)
+	if err != nil {
+		return p.GenericProfile.SYSINFO()
+	}
+	var report zteSystemInfoReport
+	if err = report.Parse(strings.TrimPrefix(reply, `^SYSINFO:`)); err != nil {
+		return nil, err
+	}
+	info = (*SystemInfoReport)(&report)
+	return info, nil
+}
+
+// ZPASReport represents the network and the service domain reported by ZTE modems.
+type ZPASReport struct {
+	SystemMode    Opt
+	SystemSubmode Opt
+	ServiceDomain Opt
+}
+
+// Parse scans the +ZPAS report into a non-nil ZPASReport struct.
+// The report looks like "LTE","CS_PS".
+func (z *ZPASReport) Parse(str string) error {
+	fields := splitFields(str)
+	if len(fields) < 1 || len(fields[0]) == 0 {
+		return ErrParseReport
+	}
+	z.SystemMode, z.SystemSubmode = zteSystemMode(fields[0])
+	z.ServiceDomain = UnknownOpt
+	if len(fields) > 1 {
+		switch fields[1] {
+		case "CS_ONLY":
+			z.ServiceDomain = ServiceDomains.Resolve(1)
+		case "PS_ONLY":
+			z.ServiceDomain = ServiceDomains.Resolve(2)
+		case "CS_PS":
+			z.ServiceDomain = ServiceDomains.Resolve(3)
+		case "CAMPED":
+			z.ServiceDomain = ServiceDomains.Resolve(0)
+		}
+	}
+	return nil
+}
+
+// zteSystemMode maps the network names reported by ZTE modems to the system mode and submode.
+func zteSystemMode(str string) (mode, submode Opt) {
+	switch strings.ToUpper(str) {
+	case "NO SERVICE", "LIMITED SERVICE":
+		return SystemModes.NoService, SystemSubmodes.NoService
+	case "GSM":
+		return SystemModes.GsmGprs, SystemSubmodes.GSM
+	case "GPRS":
+		return SystemModes.GsmGprs, SystemSubmodes.GPRS
+	case "EDGE":
+		return SystemModes.GsmGprs, SystemSubmodes.EDGE
+	case "UMTS", "WCDMA":
+		return SystemModes.WCDMA, SystemSubmodes.WCDMA
+	case "HSDPA":
+		return SystemModes.WCDMA, SystemSubmodes.HSDPA
+	case "HSUPA":
+		return SystemModes.WCDMA, SystemSubmodes.HSUPA
+	case "HSPA":
+		return SystemModes.WCDMA, SystemSubmodes.HsdpaHsupa
+	case "HSPA+":
+		return SystemModes.WCDMA, SystemSubmodes.HspaPlus
+	case "TD-SCDMA":
+		return SystemModes.SCDMA, SystemSubmodes.SCDMA
+	case "LTE":
+		return SystemModes.LTE, UnknownOpt
+	case "CDMA":
+		return SystemModes.CDMA, UnknownOpt
+	case "EVDO", "HDR":
+		return SystemModes.HDR, UnknownOpt
+	}
+	return UnknownOpt, UnknownOpt
+}
+
+// ZPAS sends AT+ZPAS? to the device and parses the output.
+func (p *ZTEProfile) ZPAS() (report *ZPASReport, err error) {
+	reply, err := p.dev.Send(`AT+ZPAS?`)
+	if err != nil {
+		return nil, err
+	}
+	report = new(ZPASReport)
+	err = report.Parse(strings.TrimPrefix(reply, `+ZPAS:`))
+	return
+}
+
+// HandleReport handles the +ZPAS and +ZUSIMR reports of ZTE modems.
+func (p *ZTEProfile) HandleReport(report string) (handled bool, err error) {
+	switch {
+	case strings.HasPrefix(report, "+ZUSIMR:"):
+		// reported periodically by the MF series, carries no useful state
+		return true, nil
+	case strings.HasPrefix(report, "+ZPAS:"):
+		var zpas ZPASReport
+		if err = zpas.Parse(strings.TrimPrefix(report, "+ZPAS:")); err != nil {
+			return true, err
+		}
+		p.dev.UpdateState(func(s *DeviceState) bool {
+			s.SystemMode = zpas.SystemMode
+			s.SystemSubmode = zpas.SystemSubmode
+			if zpas.ServiceDomain != UnknownOpt {
+				s.ServiceDomain = zpas.ServiceDomain
+			}
+			return true
+		})
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,41 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package at
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZTEProfile(t *testing.T) {
+	t.Parallel()
+
+	dev, modem := openTestDevice(t)
+	replyGeneric(modem)
+	modem.Reply("AT^SYSINFO", "^SYSINFO: 2,3,0,5,1", "OK")
+	require.NoError(t, dev.Init(DeviceZTE()))
+	state := dev.State()
+	assert.Equal(t, ServiceStates.Valid, state.ServiceState)
+	assert.Equal(t, SystemModes.WCDMA, state.SystemMode)
+	assert.Equal(t, UnknownOpt, state.SystemSubmode)
+	assert.Equal(t, SimStates.Valid, state.SimState)
+
+	require.NoError(t, dev.handleReport("+ZUSIMR:2"))
+	require.NoError(t, dev.handleReport(`+ZPAS: "LTE","CS_PS"`))
+	state = dev.State()
+	assert.Equal(t, SystemModes.LTE, state.SystemMode)
+	assert.Equal(t, ServiceDomains.Resolve(3), state.ServiceDomain)
+}
+
+func TestZTESystemInfoReport(t *testing.T) {
+	t.Parallel()
+
+	var report zteSystemInfoReport
+	require.NoError(t, report.Parse(" 2,2,0,5,1,,4"))
+	assert.Equal(t, SystemSubmodes.WCDMA, report.SystemSubmode)
+	assert.Equal(t, ServiceDomains.Resolve(2), report.ServiceDomain)
+	assert.Equal(t, ErrParseReport, report.Parse("2,3,0"))
+}